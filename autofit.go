@@ -0,0 +1,317 @@
+package xlsx
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// AutoFitOptions control Sheet.AutoFitColumns.  The zero value fits
+// every column that has content.
+type AutoFitOptions struct {
+	// MinCol and MaxCol limit the fitted columns to a range,
+	// numbered from 1.  Zero leaves that end of the range open.
+	MinCol int
+	MaxCol int
+	// MinWidth and MaxWidth bound the width given to a column.  A
+	// MaxWidth of zero means Excel's limit of 255 characters.
+	MinWidth float64
+	MaxWidth float64
+	// Padding is added to the width of every fitted column.
+	Padding float64
+}
+
+// maxColWidth is the widest a column can be made in Excel.
+const maxColWidth = 255
+
+func (opts AutoFitOptions) includes(col int) bool {
+	return col >= opts.MinCol && (opts.MaxCol == 0 || col <= opts.MaxCol)
+}
+
+func (opts AutoFitOptions) clamp(width float64) float64 {
+	maxWidth := opts.MaxWidth
+	if maxWidth <= 0 || maxWidth > maxColWidth {
+		maxWidth = maxColWidth
+	}
+	return math.Min(math.Max(width+opts.Padding, opts.MinWidth), maxWidth)
+}
+
+// mergedSpan is the content of a horizontally merged cell, which
+// must fit across the columns from min to max.
+type mergedSpan struct {
+	min, max int
+	pixels   float64
+}
+
+// AutoFitColumns sets the width of each column to fit its widest
+// cell.  Unlike SetColAutoWidth, the formatted value of each cell is
+// measured in its own font, using built in width tables for Calibri,
+// Arial, Verdana and Times New Roman, so bold text, proportional
+// fonts and full-width East Asian characters are all sized properly.
+//
+// Text in a cell that wraps only needs to be as wide as its longest
+// word, and each line of text containing newlines is measured on its
+// own.  Cells merged across several columns don't widen any single
+// column; instead, if they don't fit across all the merged columns,
+// the difference is shared between them.  Columns without content are
+// left alone.
+func (s *Sheet) AutoFitColumns(opts AutoFitOptions) error {
+	s.mustBeOpen()
+	mdw := maxDigitWidth(s.File.normalFont())
+	widths := make(map[int]float64)
+	var spans []mergedSpan
+
+	err := s.ForEachRow(func(r *Row) error {
+		return r.ForEachCell(func(c *Cell) error {
+			col := c.num + 1
+			pixels, err := cellTextWidth(c)
+			if err != nil {
+				return err
+			}
+			if pixels == 0 {
+				return nil
+			}
+			if c.HMerge > 0 {
+				spans = append(spans, mergedSpan{min: col, max: col + c.HMerge, pixels: pixels})
+				return nil
+			}
+			if !opts.includes(col) {
+				return nil
+			}
+			if width := pixelsToColWidth(pixels, mdw); width > widths[col] {
+				widths[col] = width
+			}
+			return nil
+		}, SkipEmptyCells)
+	}, SkipEmptyRows)
+	if err != nil {
+		return fmt.Errorf("AutoFitColumns: %w", err)
+	}
+
+	for col, width := range widths {
+		widths[col] = opts.clamp(width)
+	}
+	for _, span := range spans {
+		s.fitMergedSpan(span, widths, mdw, opts)
+	}
+
+	for col, width := range widths {
+		width := width
+		s.setCol(col, col, func(c *Col) {
+			c.SetWidth(width)
+			c.BestFit = bPtr(true)
+		})
+	}
+	return nil
+}
+
+// fitMergedSpan widens the columns under a merged cell, that are to
+// be fitted, until its content fits.
+func (s *Sheet) fitMergedSpan(span mergedSpan, widths map[int]float64, mdw float64, opts AutoFitOptions) {
+	var available float64
+	var fitted []int
+	for col := span.min; col <= span.max; col++ {
+		if width, ok := widths[col]; ok {
+			available += colWidthToPixels(width, mdw)
+		} else {
+			available += colWidthToPixels(s.colWidth(col), mdw)
+		}
+		if opts.includes(col) {
+			fitted = append(fitted, col)
+		}
+	}
+	// Excel only pads the merged cell once, not once per column.
+	available += float64(span.max-span.min) * 5
+	if available >= span.pixels || len(fitted) == 0 {
+		return
+	}
+	extra := (span.pixels - available) / float64(len(fitted))
+	for _, col := range fitted {
+		width, ok := widths[col]
+		if !ok {
+			width = s.colWidth(col)
+		}
+		widths[col] = opts.clamp(width + math.Ceil(extra/mdw*256)/256)
+	}
+}
+
+// colWidth returns the width of column col, numbered from 1, as it
+// currently stands.
+func (s *Sheet) colWidth(col int) float64 {
+	if c := s.Cols.FindColByIndex(col); c != nil && c.Width != nil {
+		return *c.Width
+	}
+	if s.SheetFormat.DefaultColWidth > 0 {
+		return s.SheetFormat.DefaultColWidth
+	}
+	return ColWidth
+}
+
+// cellTextWidth returns the width in pixels that the formatted value
+// of a cell needs.
+func cellTextWidth(c *Cell) (float64, error) {
+	value, err := c.FormattedValue()
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+	style := c.EffectiveStyle()
+	var widest float64
+	for _, line := range strings.Split(value, "\n") {
+		if style.Alignment.WrapText {
+			for _, word := range strings.Fields(line) {
+				widest = math.Max(widest, textWidth(word, style.Font))
+			}
+			continue
+		}
+		widest = math.Max(widest, textWidth(line, style.Font))
+	}
+	return widest, nil
+}
+
+// AutoFitRows sets the height of each row to fit its tallest cell.
+// A cell is as tall as the lines of its formatted value, set in its
+// own font: each newline starts a new line, and text that wraps is
+// broken into as many lines as the width of its column, or columns if
+// it's merged, requires.  Rows that need no more than the sheet's
+// default height keep it.  A height set with Row.SetHeight is left
+// alone, but one set by an earlier call is fitted again, so that the
+// row can shrink back as well as grow.  Cells merged down several rows
+// are ignored, as they are by Excel.
+//
+// Excel fits rows itself when it loads a file, but other spreadsheet
+// programs rely on the heights written in the file.  The
+// AutoFitRowsOnSave option fits every row of every sheet when the
+// File is saved.
+func (s *Sheet) AutoFitRows() error {
+	s.mustBeOpen()
+	normal := s.File.normalFont()
+	mdw := maxDigitWidth(normal)
+	defaultHeight := s.SheetFormat.DefaultRowHeight
+	if defaultHeight <= 0 {
+		defaultHeight = wholePixelHeight(lineHeight(normal))
+	}
+
+	err := s.ForEachRow(func(r *Row) error {
+		// A Row with settings but no cells has no text to fit.
+		if r.cellStoreRow.CellCount() == 0 {
+			return nil
+		}
+		if r.customHeight && !r.autoHeight {
+			return nil
+		}
+		height := defaultHeight
+		err := r.ForEachCell(func(c *Cell) error {
+			if c.VMerge > 0 {
+				return nil
+			}
+			cellHeight, err := s.cellTextHeight(c, mdw)
+			if err != nil {
+				return err
+			}
+			height = math.Max(height, cellHeight)
+			return nil
+		}, SkipEmptyCells)
+		if err != nil {
+			return err
+		}
+		height = wholePixelHeight(height)
+		switch {
+		case height != defaultHeight:
+			r.setHeight(height)
+			r.autoHeight = true
+			r.isCustom = true
+		case r.autoHeight:
+			// The row no longer needs the height fitted before.
+			r.cellStoreRow.Updatable()
+			r.height = 0
+			r.customHeight = false
+			r.autoHeight = false
+			r.isCustom = true
+		}
+		return nil
+	}, SkipEmptyRows)
+	if err != nil {
+		return fmt.Errorf("AutoFitRows: %w", err)
+	}
+	return nil
+}
+
+// wholePixelHeight rounds a height in points up to a whole number of
+// pixels, which are three quarters of a point each, as Excel does for
+// row heights.
+func wholePixelHeight(height float64) float64 {
+	return math.Ceil(height/0.75-1e-9) * 0.75
+}
+
+// autoFitRowsForSave fits the rows of every sheet, if the File was
+// opened with the AutoFitRowsOnSave option.
+func (f *File) autoFitRowsForSave() error {
+	if !f.autoFitRows {
+		return nil
+	}
+	for _, sheet := range f.Sheets {
+		if err := sheet.AutoFitRows(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cellTextHeight returns the height in points that the formatted
+// value of a cell needs.
+func (s *Sheet) cellTextHeight(c *Cell, mdw float64) (float64, error) {
+	value, err := c.FormattedValue()
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+	style := c.EffectiveStyle()
+	width := -1.0
+	if style.Alignment.WrapText {
+		width = 0
+		for col := c.num + 1; col <= c.num+1+c.HMerge; col++ {
+			width += colWidthToPixels(s.colWidth(col), mdw)
+		}
+		width += float64(c.HMerge) * 5
+	}
+	return float64(wrappedLineCount(value, style.Font, width)) * lineHeight(style.Font), nil
+}
+
+// wrappedLineCount returns the number of lines text takes up when set
+// in font and wrapped, between words where possible, to width pixels.
+// A negative width means the text doesn't wrap.
+func wrappedLineCount(text string, font Font, width float64) int {
+	lines := 0
+	space := textWidth(" ", font)
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines++
+		if width < 0 {
+			continue
+		}
+		line := 0.0
+		for _, word := range strings.Fields(paragraph) {
+			w := textWidth(word, font)
+			if line > 0 && line+space+w <= width {
+				line += space + w
+				continue
+			}
+			if line > 0 {
+				lines++
+			}
+			line = w
+			// Words too long for a line of their own are
+			// broken wherever they reach the edge.
+			if width > 0 && line > width {
+				extra := math.Ceil(line/width) - 1
+				lines += int(extra)
+				line -= extra * width
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,262 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFontMetrics(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("TablesComplete", func(c *qt.C) {
+		for _, m := range []*fontMetrics{&calibriMetrics, &arialMetrics, &verdanaMetrics, &timesNewRomanMetrics} {
+			for i, w := range m.widths {
+				c.Assert(w, qt.Not(qt.Equals), uint16(0), qt.Commentf("character %q", rune(i+' ')))
+			}
+		}
+	})
+
+	c.Run("MaxDigitWidth", func(c *qt.C) {
+		c.Assert(maxDigitWidth(Font{Name: "Calibri", Size: 11}), qt.Equals, 7.0)
+		c.Assert(maxDigitWidth(Font{Name: "Arial", Size: 10}), qt.Equals, 7.0)
+		c.Assert(pixelsToColWidth(colWidthToPixels(8.43, 7), 7), qt.Equals, 8.4296875)
+	})
+
+	c.Run("TextWidth", func(c *qt.C) {
+		plain := Font{Name: "Calibri", Size: 11}
+		bold := plain
+		bold.Bold = true
+		c.Assert(textWidth("Total", bold) > textWidth("Total", plain), qt.IsTrue)
+		c.Assert(textWidth("iiii", plain) < textWidth("MMMM", plain), qt.IsTrue)
+		c.Assert(textWidth("日本語", plain), qt.Equals, 3*fontPixelSize(plain))
+		c.Assert(textWidth("abc", Font{Name: "Times New Roman", Size: 11}) <
+			textWidth("abc", Font{Name: "Verdana", Size: 11}), qt.IsTrue)
+		c.Assert(textWidth("abc", Font{Name: "Unknown", Size: 11}), qt.Equals, textWidth("abc", plain))
+		c.Assert(textWidth("abc", Font{Name: "Calibri", Size: 22}), qt.Equals, 2*textWidth("abc", plain))
+	})
+}
+
+func TestAutoFitColumns(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "AutoFitColumns", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+
+		row := sheet.AddRow()
+		row.AddCell().SetString("id")
+		row.AddCell().SetString("A rather long description")
+		row.AddCell().SetString("x")
+		row.AddCell().SetString("short")
+		wrapStyle := NewStyle()
+		wrapStyle.Alignment.WrapText = true
+		cell := row.AddCell()
+		cell.SetString("Wrapped words here")
+		cell.SetStyle(wrapStyle)
+		row.AddCell().SetString("日本語のテキスト")
+
+		row = sheet.AddRow()
+		cell = row.AddCell()
+		cell.SetFloat(0.12345)
+		cell.SetFormat("0.00%")
+		row.AddCell()
+		row.AddCell()
+		bold := row.AddCell()
+		bold.SetString("short")
+		style := NewStyle()
+		style.Font.Bold = true
+		bold.SetStyle(style)
+		wrapped := row.AddCell()
+		wrapped.SetString("Extraordinarily")
+		wrapped.SetStyle(wrapStyle)
+
+		row = sheet.AddRow()
+		merged := row.AddCell()
+		merged.SetString("A heading that is merged across three columns and is long")
+		merged.Merge(2, 0)
+
+		c.Assert(sheet.AutoFitColumns(AutoFitOptions{}), qt.IsNil)
+
+		width := func(col int) float64 {
+			c.Helper()
+			w := sheet.Col(col - 1).Width
+			c.Assert(w, qt.Not(qt.IsNil))
+			return *w
+		}
+		c.Assert(*sheet.Col(0).BestFit, qt.IsTrue)
+		// "12.35%" is wider than "id".
+		c.Assert(width(1) > pixelsToColWidth(textWidth("id", file.normalFont()), 8), qt.IsTrue)
+		c.Assert(width(2) > width(1), qt.IsTrue)
+		// The merged heading doesn't fit in columns A to C as
+		// sized by their own content, so they share the excess.
+		c.Assert(width(1)+width(2)+width(3) >= pixelsToColWidth(
+			textWidth("A heading that is merged across three columns and is long", file.normalFont()), 8), qt.IsTrue)
+		// The bold text is wider than the plain text in column D.
+		plain := NewFont(12, "Verdana")
+		c.Assert(width(4), qt.Equals, pixelsToColWidth(textWidth("short", Font{Name: plain.Name, Size: plain.Size, Bold: true}), 8))
+		// Wrapped text can wrap between words, but the longest word
+		// must fit.
+		c.Assert(width(5), qt.Equals, pixelsToColWidth(textWidth("Extraordinarily", *plain), 8))
+		// The unstyled CJK text is measured in the normal font.
+		c.Assert(width(6), qt.Equals, pixelsToColWidth(8*fontPixelSize(file.normalFont()), 8))
+	})
+
+	csRunO(c, "Options", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		row.AddCell().SetString("a")
+		row.AddCell().SetString("An extremely long value that would normally need a very wide column")
+		row.AddCell().SetString("c")
+
+		c.Assert(sheet.AutoFitColumns(AutoFitOptions{MinCol: 2, MaxCol: 2, MaxWidth: 20}), qt.IsNil)
+		c.Assert(sheet.Col(0), qt.IsNil)
+		c.Assert(*sheet.Col(1).Width, qt.Equals, 20.0)
+		c.Assert(sheet.Col(2), qt.IsNil)
+
+		c.Assert(sheet.AutoFitColumns(AutoFitOptions{MinWidth: 10, Padding: 1}), qt.IsNil)
+		c.Assert(*sheet.Col(0).Width, qt.Equals, 10.0)
+		c.Assert(*sheet.Col(2).Width, qt.Equals, 10.0)
+	})
+}
+
+func TestAutoFitUnstyledCells(t *testing.T) {
+	c := qt.New(t)
+
+	// Cells without a style are shown in the first font of the saved
+	// style sheet, so that is the font they are measured in.
+	csRunO(c, "NewFile", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		text := "Quarterly totals by region"
+		sheet.AddRow().AddCell().SetString(text)
+		sheet.AddRow().AddCell().SetString("one\ntwo")
+
+		c.Assert(sheet.AutoFitColumns(AutoFitOptions{}), qt.IsNil)
+		c.Assert(sheet.AutoFitRows(), qt.IsNil)
+
+		arial := Font{Name: "Arial", Size: 11}
+		mdw := maxDigitWidth(arial)
+		c.Assert(*sheet.Col(0).Width, qt.Equals, pixelsToColWidth(textWidth(text, arial), mdw))
+		c.Assert(*sheet.Col(0).Width, qt.Not(qt.Equals), pixelsToColWidth(textWidth(text, *NewFont(12, "Verdana")), mdw))
+		row, err := sheet.Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetHeight(), qt.Equals, wholePixelHeight(2*lineHeight(arial)))
+	})
+
+	csRunO(c, "DefaultStyle", func(c *qt.C, option FileOption) {
+		style := NewStyle()
+		style.Font = *NewFont(14, "Times New Roman")
+		file := NewFile(option, DefaultStyle(style))
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("Quarterly totals")
+
+		c.Assert(sheet.AutoFitColumns(AutoFitOptions{}), qt.IsNil)
+		c.Assert(*sheet.Col(0).Width, qt.Equals,
+			pixelsToColWidth(textWidth("Quarterly totals", style.Font), maxDigitWidth(style.Font)))
+	})
+}
+
+func TestWrappedLineCount(t *testing.T) {
+	c := qt.New(t)
+	font := Font{Name: "Arial", Size: 10}
+	c.Assert(wrappedLineCount("one line", font, -1), qt.Equals, 1)
+	c.Assert(wrappedLineCount("one\ntwo\nthree", font, -1), qt.Equals, 3)
+	word := textWidth("word", font)
+	space := textWidth(" ", font)
+	c.Assert(wrappedLineCount("word word word", font, 3*word+2*space), qt.Equals, 1)
+	c.Assert(wrappedLineCount("word word word", font, 2*word+space), qt.Equals, 2)
+	c.Assert(wrappedLineCount("word word\nword", font, word), qt.Equals, 3)
+	// A word longer than the line is broken across lines.
+	c.Assert(wrappedLineCount("wordwordword", font, word+1), qt.Equals, 3)
+}
+
+func TestAutoFitRows(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "AutoFitRows", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.SetColWidth(1, 1, 10)
+		sheet.SheetFormat.DefaultRowHeight = 15
+		font := *NewFont(12, "Verdana")
+
+		sheet.AddRow().AddCell().SetString("plain")
+		sheet.AddRow().AddCell().SetString("one\ntwo\nthree")
+
+		wrapStyle := NewStyle()
+		wrapStyle.Alignment.WrapText = true
+		text := "a long run of words that can't fit on a single line of a narrow column"
+		cell := sheet.AddRow().AddCell()
+		cell.SetString(text)
+		cell.SetStyle(wrapStyle)
+
+		big := NewStyle()
+		big.Font.Size = 24
+		cell = sheet.AddRow().AddCell()
+		cell.SetString("big")
+		cell.SetStyle(big)
+
+		custom := sheet.AddRow()
+		custom.AddCell().SetString("too tall")
+		custom.SetHeight(100)
+
+		c.Assert(sheet.AutoFitRows(), qt.IsNil)
+
+		row := func(i int) *Row {
+			c.Helper()
+			r, err := sheet.Row(i)
+			c.Assert(err, qt.IsNil)
+			return r
+		}
+		c.Assert(row(0).customHeight, qt.IsFalse)
+		c.Assert(row(1).GetHeight(), qt.Equals, wholePixelHeight(3*lineHeight(file.normalFont())))
+		mdw := maxDigitWidth(file.normalFont())
+		lines := wrappedLineCount(text, font, colWidthToPixels(10, mdw))
+		c.Assert(lines > 1, qt.IsTrue)
+		c.Assert(row(2).GetHeight(), qt.Equals, wholePixelHeight(float64(lines)*lineHeight(font)))
+		c.Assert(row(3).GetHeight(), qt.Equals, wholePixelHeight(lineHeight(big.Font)))
+		c.Assert(row(4).GetHeight(), qt.Equals, 100.0)
+
+		// A fitted height is fitted again when the text changes, while
+		// one set by the user is kept.
+		c.Assert(row(1).autoHeight, qt.IsTrue)
+		cell, err = sheet.Cell(1, 0)
+		c.Assert(err, qt.IsNil)
+		cell.SetString("one")
+		c.Assert(sheet.AutoFitRows(), qt.IsNil)
+		c.Assert(row(1).customHeight, qt.IsFalse)
+		c.Assert(row(4).GetHeight(), qt.Equals, 100.0)
+	})
+
+	csRunO(c, "OnSave", func(c *qt.C, option FileOption) {
+		file := NewFile(option, AutoFitRowsOnSave())
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("first\nsecond")
+		title := sheet.AddRow()
+		title.AddCell().SetString("Title")
+		title.SetHeight(40)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		title, err = sheet.Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(title.GetHeight(), qt.Equals, 40.0)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		row, err := file.Sheets[0].Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetHeight(), qt.Equals, wholePixelHeight(2*lineHeight(file.normalFont())))
+		title, err = file.Sheets[0].Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(title.GetHeight(), qt.Equals, 40.0)
+	})
+}
@@ -63,6 +63,7 @@ type Cell struct {
 	RichText       []RichTextRun
 	formula        string
 	style          *Style
+	styleID        StyleID
 	NumFmt         string
 	parsedNumFmt   *parsedNumberFormat
 	date1904       bool
@@ -241,6 +242,16 @@ func (c *Cell) GetTime(date1904 bool) (t time.Time, err error) {
 	return TimeFromExcelTime(f, date1904), nil
 }
 
+// isDate1904 reports whether the Cell's dates count from 1904, as set
+// by the Date1904 of the File it is in, or, for a Cell that isn't in a
+// File, by the workbook it was read from.
+func (c *Cell) isDate1904() bool {
+	if c.Row != nil && c.Row.Sheet != nil && c.Row.Sheet.File != nil {
+		return c.Row.Sheet.File.Date1904
+	}
+	return c.date1904
+}
+
 /*
 	The following are samples of format samples.
 
@@ -489,26 +500,166 @@ func (c *Cell) Formula() string {
 	return c.formula
 }
 
-// GetStyle returns the Style associated with a Cell
+// GetStyle returns the Style associated with a Cell.  If the cell
+// uses a registered style (see SetStyleID) it is given its own copy,
+// which can be changed without affecting other cells.
 func (c *Cell) GetStyle() *Style {
 	if c.style == nil {
-		c.style = NewStyle()
+		c.style = c.file().NewStyle()
+	}
+	if c.styleID != 0 {
+		// The style is shared through the File's StyleRegistry,
+		// so the cell gets a copy of its own to change.
+		c.updatable()
+		c.style = c.style.clone()
+		c.styleID = 0
+		c.modified = true
 	}
 	return c.style
 }
 
+// file returns the File that the cell belongs to, or nil if it
+// doesn't belong to one.
+func (c *Cell) file() *File {
+	if c.Row == nil || c.Row.Sheet == nil {
+		return nil
+	}
+	return c.Row.Sheet.File
+}
+
 // SetStyle sets the style of a cell.
 func (c *Cell) SetStyle(style *Style) {
 	c.updatable()
 	c.style = style
+	c.styleID = 0
 	c.modified = true
 }
 
+// SetStyleID sets the style of a cell to one registered with the
+// StyleRegistry of the cell's File.  The cell shares the registered
+// style, so this is much cheaper than SetStyle when the same style is
+// applied to many cells.
+func (c *Cell) SetStyleID(id StyleID) error {
+	f := c.file()
+	if f == nil {
+		return fmt.Errorf("SetStyleID(%d): cell does not belong to a file", id)
+	}
+	style := f.Styles().lookup(id)
+	if style == nil {
+		return fmt.Errorf("SetStyleID: no style registered as %d", id)
+	}
+	c.updatable()
+	c.style = style
+	c.styleID = id
+	c.modified = true
+	return nil
+}
+
+// StyleID returns the ID of the registered style that the cell uses,
+// or zero if it doesn't use one.
+func (c *Cell) StyleID() StyleID {
+	return c.styleID
+}
+
+// SetNamedStyle bases the cell's style on the named cell style called
+// name, which must have been defined with File.AddNamedStyle or read
+// from the file.  The cell takes on the named style's formatting,
+// except for any part its current style applies explicitly (for
+// example Font, when ApplyFont is set), which is kept as an override.
+// Parts that aren't overridden follow the named style when it is
+// later edited in Excel.
+func (c *Cell) SetNamedStyle(name string) error {
+	f := c.file()
+	if f == nil {
+		return fmt.Errorf("SetNamedStyle(%q): cell does not belong to a file", name)
+	}
+	style, ok := f.NamedStyle(name)
+	if !ok {
+		return fmt.Errorf("SetNamedStyle: no named style called %q", name)
+	}
+	style.ApplyBorder = false
+	style.ApplyFill = false
+	style.ApplyFont = false
+	style.ApplyAlignment = false
+	style.ApplyProtection = false
+	if old := c.style; old != nil {
+		if old.ApplyBorder {
+			style.Border, style.ApplyBorder = old.Border, true
+		}
+		if old.ApplyFill {
+			style.Fill, style.ApplyFill = old.Fill, true
+		}
+		if old.ApplyFont {
+			style.Font, style.ApplyFont = old.Font, true
+		}
+		if old.ApplyAlignment {
+			style.Alignment, style.ApplyAlignment = old.Alignment, true
+		}
+		if old.ApplyProtection {
+			style.Protection, style.ApplyProtection = old.Protection, true
+		}
+	}
+	style.NamedStyle = name
+	c.SetStyle(style)
+	return nil
+}
+
+// NamedStyle returns the name of the named cell style that the cell's
+// style is based on, or an empty string if there is none.
+func (c *Cell) NamedStyle() string {
+	if c.style == nil {
+		return ""
+	}
+	return c.style.NamedStyle
+}
+
 // GetNumberFormat returns the number format string for a cell.
 func (c *Cell) GetNumberFormat() string {
 	return c.NumFmt
 }
 
+// effectiveFormat returns the style and number format that Excel
+// shows the cell with.  A cell's own formatting takes precedence over
+// its row's, which in turn takes precedence over its column's.  The
+// style is nil if none of them has one.
+func (c *Cell) effectiveFormat() (*Style, string) {
+	if c.style != nil || c.NumFmt != "" {
+		return c.style, c.NumFmt
+	}
+	if c.Row == nil {
+		return nil, ""
+	}
+	if c.Row.hasFormat() {
+		return c.Row.style, c.Row.numFmt
+	}
+	if c.Row.Sheet != nil && c.Row.Sheet.Cols != nil {
+		if col := c.Row.Sheet.Col(c.num); col != nil {
+			return col.style, col.numFmt
+		}
+	}
+	return nil, ""
+}
+
+// EffectiveStyle returns the Style that Excel shows the cell in: its
+// own style if it has one, or else that of its row, or else that of
+// its column, or failing all of those the default style of the file.
+// Unlike GetStyle it never creates a style for the cell.
+func (c *Cell) EffectiveStyle() *Style {
+	if style, _ := c.effectiveFormat(); style != nil {
+		return style
+	}
+	return c.file().normalStyle()
+}
+
+// EffectiveNumberFormat returns the number format that Excel shows
+// the cell with, following the same precedence as EffectiveStyle.
+func (c *Cell) EffectiveNumberFormat() string {
+	if _, numFmt := c.effectiveFormat(); numFmt != "" {
+		return numFmt
+	}
+	return builtInNumFmt[builtInNumFmtIndex_GENERAL]
+}
+
 // getNumberFormat will update the parsedNumFmt struct if it has become out of date, since a cell's NumFmt string is a
 // public field that could be edited by clients.
 func (c *Cell) getNumberFormat() *parsedNumberFormat {
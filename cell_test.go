@@ -933,6 +933,51 @@ func (fvc *formattedValueChecker) Equals(cell Cell, expected string) {
 	fvc.c.Assert(val, qt.Equals, expected)
 }
 
+func TestCellEffectiveStyle(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "Precedence", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+
+		colStyle := NewStyle()
+		colStyle.Font.Name = "Col"
+		col := NewColForRange(1, 3)
+		col.SetStyle(colStyle)
+		col.numFmt = "0%"
+		sheet.SetColParameters(col)
+
+		rowStyle := NewStyle()
+		rowStyle.Font.Name = "Row"
+
+		plain, err := sheet.Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(plain.EffectiveStyle().Font.Name, qt.Equals, "Col")
+		c.Assert(plain.EffectiveNumberFormat(), qt.Equals, "0%")
+
+		plain.Row.SetStyle(rowStyle)
+		plain.Row.SetNumberFormat("0.00")
+		c.Assert(plain.EffectiveStyle().Font.Name, qt.Equals, "Row")
+		c.Assert(plain.EffectiveNumberFormat(), qt.Equals, "0.00")
+
+		cellStyle := NewStyle()
+		cellStyle.Font.Name = "Cell"
+		plain.SetStyle(cellStyle)
+		c.Assert(plain.EffectiveStyle().Font.Name, qt.Equals, "Cell")
+		c.Assert(plain.EffectiveNumberFormat(), qt.Equals, "general")
+
+		outside, err := sheet.Cell(1, 5)
+		c.Assert(err, qt.IsNil)
+		// Without a style sheet yet, the default is the one that
+		// saving the file writes.
+		c.Assert(outside.EffectiveStyle().Font.Name, qt.Equals, "Arial")
+		c.Assert(outside.EffectiveStyle().Font.Size, qt.Equals, 11.0)
+		c.Assert(outside.EffectiveNumberFormat(), qt.Equals, "general")
+		c.Assert(outside.style, qt.IsNil)
+	})
+}
+
 func TestCellMerge(t *testing.T) {
 	c := qt.New(t)
 	csRunO(c, "MergeAndSave", func(c *qt.C, option FileOption) {
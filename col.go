@@ -71,6 +71,13 @@ func (c *Col) SetType(cellType CellType) {
 	}
 }
 
+// SetFormat sets the number format, such as "#,##0.00", of columns
+// that have this Col applied to them.
+func (c *Col) SetFormat(format string) {
+	c.numFmt = format
+	c.parsedNumFmt = nil
+}
+
 // GetStyle returns the Style associated with a Col
 func (c *Col) GetStyle() *Style {
 	return c.style
@@ -85,6 +92,18 @@ func (c *Col) SetOutlineLevel(outlineLevel uint8) {
 	c.OutlineLevel = &outlineLevel
 }
 
+// SetHidden hides or shows the columns that have this Col applied to
+// them.
+func (c *Col) SetHidden(hidden bool) {
+	c.Hidden = &hidden
+}
+
+// SetCollapsed marks the columns that have this Col applied to them
+// as the summary columns of a collapsed outline group, or not.
+func (c *Col) SetCollapsed(collapsed bool) {
+	c.Collapsed = &collapsed
+}
+
 // copyToRange is an internal convenience function to make a copy of a
 // Col with a different Min and Max value, it is not intended as a
 // general purpose Col copying function as you must still insert the
@@ -0,0 +1,230 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ColorType says how a Color is given.
+type ColorType int
+
+const (
+	// ColorTypeRGB is a colour given directly as ARGB.
+	ColorTypeRGB ColorType = iota
+	// ColorTypeIndexed is an entry in the File's indexed colour
+	// palette.
+	ColorTypeIndexed
+	// ColorTypeTheme is one of the colours of the File's theme.
+	ColorTypeTheme
+	// ColorTypeAuto is the automatic colour, chosen by Excel.
+	ColorTypeAuto
+)
+
+// Theme colour indices that can be used with NewThemeColor.  Note that
+// Excel swaps the light and dark colours around compared to the
+// order of ThemeColors.
+const (
+	ThemeColorLight1 = iota
+	ThemeColorDark1
+	ThemeColorLight2
+	ThemeColorDark2
+	ThemeColorAccent1
+	ThemeColorAccent2
+	ThemeColorAccent3
+	ThemeColorAccent4
+	ThemeColorAccent5
+	ThemeColorAccent6
+	ThemeColorHyperlink
+	ThemeColorFollowedHyperlink
+)
+
+// Color is a colour as Excel stores it.  RGB holds the colour of a
+// ColorTypeRGB, as eight hexadecimal digits "AARRGGBB", while Index
+// is the palette index of a ColorTypeIndexed or the theme colour
+// index of a ColorTypeTheme.  Tint lightens (when positive) or darkens
+// (when negative) the colour, and lies between -1 and 1.  The zero
+// Color means no colour has been given.
+type Color struct {
+	Type  ColorType
+	RGB   string
+	Index int
+	Tint  float64
+}
+
+// NewRGBColor returns the Color given by argb, "AARRGGBB".
+func NewRGBColor(argb string) Color {
+	return Color{Type: ColorTypeRGB, RGB: argb}
+}
+
+// NewIndexedColor returns the Color at index in the File's indexed
+// colour palette.
+func NewIndexedColor(index int) Color {
+	return Color{Type: ColorTypeIndexed, Index: index}
+}
+
+// NewThemeColor returns the theme colour at index, one of the
+// ThemeColor constants, lightened or darkened by tint.
+func NewThemeColor(index int, tint float64) Color {
+	return Color{Type: ColorTypeTheme, Index: index, Tint: tint}
+}
+
+// NewAutoColor returns the automatic colour.
+func NewAutoColor() Color {
+	return Color{Type: ColorTypeAuto}
+}
+
+// IsZero reports whether no colour has been given.
+func (c Color) IsZero() bool {
+	return c == Color{}
+}
+
+// xlsx returns the colour as it's written in styles.xml.
+func (c Color) xlsx() xlsxColor {
+	x := xlsxColor{Tint: c.Tint}
+	switch c.Type {
+	case ColorTypeIndexed:
+		x.Indexed = iPtr(c.Index)
+	case ColorTypeTheme:
+		x.Theme = iPtr(c.Index)
+	case ColorTypeAuto:
+		x.Auto = bPtr(true)
+	default:
+		x.RGB = c.RGB
+	}
+	return x
+}
+
+// colorFromXLSX returns the Color for a colour element of styles.xml.
+func colorFromXLSX(x xlsxColor) Color {
+	c := Color{Tint: x.Tint}
+	switch {
+	case x.Auto != nil && *x.Auto:
+		c.Type = ColorTypeAuto
+	case x.Theme != nil:
+		c.Type, c.Index = ColorTypeTheme, *x.Theme
+	case x.Indexed != nil:
+		c.Type, c.Index = ColorTypeIndexed, *x.Indexed
+	default:
+		c.RGB = x.RGB
+	}
+	return c
+}
+
+// colorSpec returns the Color that describes a colour element exactly,
+// or the zero Color if its ARGB value says everything there is to say
+// about it.
+func colorSpec(x xlsxColor) Color {
+	if x.Auto == nil && x.Theme == nil && x.Indexed == nil && x.Tint == 0 {
+		return Color{}
+	}
+	return colorFromXLSX(x)
+}
+
+// makeXLSXColor returns the colour element for a colour that the high
+// level style model gives both as ARGB and, optionally, as a Color.
+// A non-zero Color is authoritative; the ARGB value is only used when
+// there is none.
+func makeXLSXColor(argb string, spec Color) xlsxColor {
+	if spec.IsZero() {
+		return xlsxColor{RGB: argb}
+	}
+	return spec.xlsx()
+}
+
+var (
+	fallbackThemeOnce sync.Once
+	fallbackTheme     *theme
+)
+
+// resolveColor returns the ARGB value of c.  A nil style sheet, or one
+// without a theme, resolves theme colours against the default Office
+// theme.
+func (styles *xlsxStyleSheet) resolveColor(c Color) string {
+	if c.Type == ColorTypeTheme && (styles == nil || styles.theme == nil) {
+		fallbackThemeOnce.Do(func() {
+			fallbackTheme = officeTheme()
+		})
+		return fallbackTheme.themeColor(int64(c.Index), c.Tint)
+	}
+	return styles.argbValue(c.xlsx())
+}
+
+// ResolveColor returns the ARGB value, "AARRGGBB", that Excel shows c
+// as in this File, taking the File's theme and indexed colour palette
+// into account.  The automatic colour resolves to an empty string.
+func (f *File) ResolveColor(c Color) string {
+	if f.styles == nil {
+		styles := newXlsxStyleSheet(f.theme, f.defaultStyle)
+		return styles.resolveColor(c)
+	}
+	return f.styles.resolveColor(c)
+}
+
+// IndexedColors returns the palette that indexed colours refer to.
+// Unless the File has a palette of its own this is Excel's default
+// palette.
+func (f *File) IndexedColors() []string {
+	if f.styles != nil && f.styles.Colors != nil && len(f.styles.Colors.IndexedColors) > 0 {
+		colors := make([]string, len(f.styles.Colors.IndexedColors))
+		for i, c := range f.styles.Colors.IndexedColors {
+			colors[i] = c.Rgb
+		}
+		return colors
+	}
+	return append([]string(nil), xlsxIndexedColors...)
+}
+
+// SetIndexedColors replaces the palette that indexed colours refer
+// to.  Each colour is given as eight hexadecimal digits, "AARRGGBB",
+// and there may be no more than 64 of them.  Setting an empty palette
+// restores Excel's default.
+func (f *File) SetIndexedColors(colors []string) error {
+	if len(colors) > len(xlsxIndexedColors) {
+		return fmt.Errorf("SetIndexedColors: %d colours given, but the palette holds at most %d", len(colors), len(xlsxIndexedColors))
+	}
+	var rgbColors []xlsxRgbColor
+	for _, c := range colors {
+		argb := strings.ToUpper(c)
+		if _, err := strconv.ParseUint(argb, 16, 32); err != nil || len(argb) != 8 {
+			return fmt.Errorf("SetIndexedColors: invalid colour %q", c)
+		}
+		rgbColors = append(rgbColors, xlsxRgbColor{Rgb: argb})
+	}
+	f.paletteColors().IndexedColors = rgbColors
+	return nil
+}
+
+// MRUColors returns the colours that Excel lists as recently used.
+func (f *File) MRUColors() []Color {
+	if f.styles == nil || f.styles.Colors == nil {
+		return nil
+	}
+	var colors []Color
+	for _, c := range f.styles.Colors.MruColors {
+		colors = append(colors, colorFromXLSX(c))
+	}
+	return colors
+}
+
+// SetMRUColors sets the colours that Excel lists as recently used.
+func (f *File) SetMRUColors(colors []Color) {
+	var xColors []xlsxColor
+	for _, c := range colors {
+		xColors = append(xColors, c.xlsx())
+	}
+	f.paletteColors().MruColors = xColors
+}
+
+// paletteColors returns the colors element of the File's style
+// sheet, creating it if need be.
+func (f *File) paletteColors() *xlsxColors {
+	if f.styles == nil {
+		f.styles = newXlsxStyleSheet(f.theme, f.defaultStyle)
+	}
+	if f.styles.Colors == nil {
+		f.styles.Colors = &xlsxColors{}
+	}
+	return f.styles.Colors
+}
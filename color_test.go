@@ -0,0 +1,167 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestColor(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("XLSXRoundTrip", func(c *qt.C) {
+		for _, color := range []Color{
+			NewRGBColor("FF112233"),
+			NewIndexedColor(10),
+			NewThemeColor(ThemeColorAccent1, -0.25),
+			NewAutoColor(),
+		} {
+			c.Assert(colorFromXLSX(color.xlsx()), qt.Equals, color)
+		}
+		c.Assert(colorSpec(xlsxColor{RGB: "FF112233"}).IsZero(), qt.IsTrue)
+	})
+
+	c.Run("Marshal", func(c *qt.C) {
+		c.Assert((&xlsxColor{}).marshal("color"), qt.Equals, "")
+		tinted := NewThemeColor(ThemeColorAccent2, 0.4).xlsx()
+		c.Assert(tinted.marshal("fgColor"), qt.Equals, `<fgColor theme="5" tint="0.4"/>`)
+		auto := NewAutoColor().xlsx()
+		c.Assert(auto.marshal("color"), qt.Equals, `<color auto="true"/>`)
+		indexed := NewIndexedColor(64).xlsx()
+		c.Assert(indexed.marshal("bgColor"), qt.Equals, `<bgColor indexed="64"/>`)
+	})
+
+	c.Run("Equals", func(c *qt.C) {
+		a := NewThemeColor(ThemeColorDark1, 0).xlsx()
+		b := NewThemeColor(ThemeColorLight1, 0).xlsx()
+		c.Assert(a.Equals(b), qt.IsFalse)
+		c.Assert(a.Equals(NewThemeColor(ThemeColorDark1, 0).xlsx()), qt.IsTrue)
+	})
+
+	c.Run("ApplyTint", func(c *qt.C) {
+		c.Assert(applyTint("808080", 0.5), qt.Equals, "FFC0C0C0")
+		c.Assert(applyTint("7F808080", -0.5), qt.Equals, "7F404040")
+		c.Assert(applyTint("nonsense", 0.5), qt.Equals, "nonsense")
+	})
+
+	c.Run("ResolveColor", func(c *qt.C) {
+		f := NewFile()
+		c.Assert(f.ResolveColor(NewRGBColor("FF112233")), qt.Equals, "FF112233")
+		c.Assert(f.ResolveColor(NewIndexedColor(2)), qt.Equals, "FFFF0000")
+		c.Assert(f.ResolveColor(NewIndexedColor(2)), qt.Equals, f.IndexedColors()[2])
+		c.Assert(f.ResolveColor(NewThemeColor(ThemeColorDark1, 0)), qt.Equals, "FF000000")
+		c.Assert(f.ResolveColor(NewThemeColor(ThemeColorLight1, -0.5)), qt.Equals, "FF808080")
+		c.Assert(f.ResolveColor(NewAutoColor()), qt.Equals, "")
+	})
+
+	c.Run("Palette", func(c *qt.C) {
+		f := NewFile()
+		c.Assert(f.IndexedColors(), qt.HasLen, 64)
+		c.Assert(f.SetIndexedColors([]string{"FF000000", "nope"}), qt.Not(qt.IsNil))
+		c.Assert(f.SetIndexedColors(make([]string, 65)), qt.Not(qt.IsNil))
+		c.Assert(f.SetIndexedColors([]string{"ff000000", "FF0000FF"}), qt.IsNil)
+		c.Assert(f.IndexedColors(), qt.DeepEquals, []string{"FF000000", "FF0000FF"})
+		c.Assert(f.ResolveColor(NewIndexedColor(1)), qt.Equals, "FF0000FF")
+		c.Assert(f.SetIndexedColors(nil), qt.IsNil)
+		c.Assert(f.IndexedColors(), qt.HasLen, 64)
+	})
+}
+
+func TestColorRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "SaveAndRead", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+
+		palette := append([]string(nil), xlsxIndexedColors...)
+		palette[10] = "FF123456"
+		c.Assert(file.SetIndexedColors(palette), qt.IsNil)
+		file.SetMRUColors([]Color{NewRGBColor("FFABCDEF"), NewThemeColor(ThemeColorAccent3, 0.2)})
+
+		style := NewStyle()
+		style.Font.ColorSpec = NewThemeColor(ThemeColorAccent1, -0.25)
+		style.ApplyFont = true
+		style.Fill = *NewFill(Solid_Cell_Fill, "", "")
+		style.Fill.FgColorSpec = NewIndexedColor(10)
+		style.Fill.BgColorSpec = NewAutoColor()
+		style.ApplyFill = true
+		style.Border = *NewBorder("thin", "", "", "")
+		style.Border.LeftColorSpec = NewThemeColor(ThemeColorDark1, 0)
+		style.ApplyBorder = true
+		cell := sheet.AddRow().AddCell()
+		cell.SetString("colours")
+		cell.SetStyle(style)
+
+		// The spec is written even when the ARGB value disagrees with
+		// it; only a style without one falls back to the ARGB value.
+		themed := NewStyle()
+		themed.Font.Color = "FF00FF00"
+		themed.Font.ColorSpec = NewThemeColor(ThemeColorAccent1, 0)
+		themed.ApplyFont = true
+		cell = sheet.AddRow().AddCell()
+		cell.SetString("accent")
+		cell.SetStyle(themed)
+		overridden := NewStyle()
+		overridden.Font.Color = "FFFF0000"
+		overridden.ApplyFont = true
+		cell = sheet.AddRow().AddCell()
+		cell.SetString("red")
+		cell.SetStyle(overridden)
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		styles := parts["xl/styles.xml"]
+		c.Assert(strings.Contains(styles, `<color theme="4" tint="-0.25"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(styles, `<fgColor indexed="10"/><bgColor auto="true"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(styles, `<left style="thin"><color theme="1"/></left>`), qt.IsTrue)
+		c.Assert(strings.Contains(styles, `<color theme="4"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(styles, `<color rgb="FFFF0000"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(styles, `<color rgb="FF00FF00"/>`), qt.IsFalse)
+		c.Assert(strings.Contains(styles, `<mruColors><color rgb="FFABCDEF"/><color theme="6" tint="0.2"/></mruColors>`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+
+		c.Assert(file.IndexedColors()[10], qt.Equals, "FF123456")
+		c.Assert(file.MRUColors(), qt.DeepEquals, []Color{NewRGBColor("FFABCDEF"), NewThemeColor(ThemeColorAccent3, 0.2)})
+
+		cell, err = file.Sheets[0].Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		got := cell.GetStyle()
+		c.Assert(got.Font.ColorSpec, qt.Equals, NewThemeColor(ThemeColorAccent1, -0.25))
+		c.Assert(got.Font.Color, qt.Equals, file.ResolveColor(got.Font.ColorSpec))
+		c.Assert(got.Fill.FgColorSpec, qt.Equals, NewIndexedColor(10))
+		c.Assert(got.Fill.FgColor, qt.Equals, "FF123456")
+		c.Assert(got.Fill.BgColorSpec, qt.Equals, NewAutoColor())
+		c.Assert(got.Border.LeftColorSpec, qt.Equals, NewThemeColor(ThemeColorDark1, 0))
+
+		cell, err = file.Sheets[0].Cell(1, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.GetStyle().Font.ColorSpec, qt.Equals, NewThemeColor(ThemeColorAccent1, 0))
+		c.Assert(cell.GetStyle().Font.Color, qt.Equals, file.ResolveColor(NewThemeColor(ThemeColorAccent1, 0)))
+
+		cell, err = file.Sheets[0].Cell(2, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.GetStyle().Font.Color, qt.Equals, "FFFF0000")
+		c.Assert(cell.GetStyle().Font.ColorSpec.IsZero(), qt.IsTrue)
+
+		// Writing what was read must give the same colours again.
+		parts, err = file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["xl/styles.xml"], `<color theme="4" tint="-0.25"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(parts["xl/styles.xml"], `<fgColor indexed="10"/><bgColor auto="true"/>`), qt.IsTrue)
+
+		// So must writing it under a different theme.
+		c.Assert(file.SetTheme(Theme{Colors: ThemeColors{Accent1: "00FF00"}}), qt.IsNil)
+		parts, err = file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["xl/styles.xml"], `<color theme="4" tint="-0.25"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(parts["xl/styles.xml"], `<color theme="4"/>`), qt.IsTrue)
+	})
+}
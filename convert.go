@@ -38,7 +38,12 @@ func parseValue(s string) interface{} {
 
 // 解析类似 "1,2,3" 或 "a,b,c"
 func parseList(s string) interface{} {
-	parts := strings.Split(s, ",")
+	return parseListSep(s, ",")
+}
+
+// parseListSep 与 parseList 相同，但使用分隔符 sep，如 "1|2|3"
+func parseListSep(s, sep string) interface{} {
+	parts := strings.Split(s, sep)
 	intArr := []int64{}
 	strArr := []string{}
 
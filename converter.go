@@ -0,0 +1,220 @@
+package xlsx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CellMarshaler is the interface implemented by types that can write
+// themselves to a cell.  It is used by Row.WriteStruct, Row.WriteSlice
+// and Sheet.Marshal.
+type CellMarshaler interface {
+	MarshalCell(cell *Cell) error
+}
+
+// CellUnmarshaler is the interface implemented by types that can read
+// themselves from a cell.  It is used by Row.ReadStruct,
+// HeaderDecoder and Sheet.Unmarshal.  UnmarshalCell is only called for
+// cells that aren't empty.
+type CellUnmarshaler interface {
+	UnmarshalCell(cell *Cell) error
+}
+
+// Converter converts values of a type, that can't implement
+// CellMarshaler and CellUnmarshaler itself, to and from cells.  Either
+// function may be nil, if the type is only read or only written.
+type Converter struct {
+	// ToCell sets cell to v, which is of the registered type.
+	ToCell func(v interface{}, cell *Cell) error
+	// FromCell returns the value of cell as the registered type.
+	FromCell func(cell *Cell) (interface{}, error)
+}
+
+var (
+	convertersMu sync.RWMutex
+	converters   = make(map[reflect.Type]Converter)
+)
+
+// RegisterConverter makes conv the way that values of the same type as
+// example, such as a decimal or UUID type from another package, are
+// written to and read from cells.  It takes precedence over any of the
+// interfaces that the type implements.  Registering a Converter with
+// neither function set removes it.
+func RegisterConverter(example interface{}, conv Converter) {
+	typ := reflect.TypeOf(example)
+	if typ == nil {
+		panic("xlsx: RegisterConverter of nil")
+	}
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if conv.ToCell == nil && conv.FromCell == nil {
+		delete(converters, typ)
+		return
+	}
+	converters[typ] = conv
+}
+
+// converterFor returns the Converter registered for typ, if any.
+func converterFor(typ reflect.Type) (Converter, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	conv, ok := converters[typ]
+	return conv, ok
+}
+
+var (
+	cellMarshalerType   = reflect.TypeOf((*CellMarshaler)(nil)).Elem()
+	cellUnmarshalerType = reflect.TypeOf((*CellUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// isCellType reports whether values of typ are read from and written
+// to a single cell, rather than being a struct whose fields map to
+// cells of their own.
+func isCellType(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+	if _, ok := converterFor(typ); ok {
+		return true
+	}
+	ptr := reflect.PtrTo(typ)
+	for _, iface := range []reflect.Type{
+		cellMarshalerType, cellUnmarshalerType,
+		textMarshalerType, textUnmarshalerType,
+		scannerType, valuerType, stringerType,
+	} {
+		if typ.Implements(iface) || ptr.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalCell reads cell into fieldV, which must be settable, if its
+// type has a Converter or implements one of CellUnmarshaler,
+// sql.Scanner or encoding.TextUnmarshaler.  It reports whether it did.
+// A TextUnmarshaler is given the raw value of the cell, unless tag asks
+// for the formatted one.
+func unmarshalCell(cell *Cell, fieldV reflect.Value, tag fieldTag) (bool, error) {
+	if conv, ok := converterFor(fieldV.Type()); ok && conv.FromCell != nil {
+		value, err := conv.FromCell(cell)
+		if err != nil {
+			return true, err
+		}
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			fieldV.Set(reflect.Zero(fieldV.Type()))
+			return true, nil
+		}
+		if !v.Type().AssignableTo(fieldV.Type()) {
+			return true, fmt.Errorf("converter for %s returned a %s", fieldV.Type(), v.Type())
+		}
+		fieldV.Set(v)
+		return true, nil
+	}
+	if !fieldV.CanAddr() {
+		return false, nil
+	}
+	switch target := fieldV.Addr().Interface().(type) {
+	case CellUnmarshaler:
+		return true, target.UnmarshalCell(cell)
+	case sql.Scanner:
+		value, err := cellNativeValue(cell)
+		if err != nil {
+			return true, err
+		}
+		return true, target.Scan(value)
+	case encoding.TextUnmarshaler:
+		text, err := tag.cellText(cell, true)
+		if err != nil {
+			return true, err
+		}
+		return true, target.UnmarshalText([]byte(text))
+	}
+	return false, nil
+}
+
+// cellNativeValue returns the value of cell as one of the types that
+// database/sql passes to a Scanner: bool, int64, float64, time.Time or
+// string.
+func cellNativeValue(cell *Cell) (interface{}, error) {
+	switch cell.Type() {
+	case CellTypeBool:
+		return cell.Bool(), nil
+	case CellTypeNumeric, CellTypeDate:
+		if cell.IsTime() {
+			return cell.GetTime(cell.isDate1904())
+		}
+		if i, err := strconv.ParseInt(cell.Value, 10, 64); err == nil {
+			return i, nil
+		}
+		return cell.Float()
+	}
+	return cell.Value, nil
+}
+
+// marshalSetter returns a function that writes val to a cell, if its
+// type has a Converter or implements one of CellMarshaler,
+// driver.Valuer or encoding.TextMarshaler, or nil if it doesn't.
+func marshalSetter(val reflect.Value) func(*Cell) error {
+	if conv, ok := converterFor(val.Type()); ok && conv.ToCell != nil {
+		v := val.Interface()
+		return func(cell *Cell) error { return conv.ToCell(v, cell) }
+	}
+	target := val.Interface()
+	if _, ok := target.(CellMarshaler); !ok && val.CanAddr() {
+		if m, ok := val.Addr().Interface().(CellMarshaler); ok {
+			target = m
+		}
+	}
+	switch t := target.(type) {
+	case CellMarshaler:
+		return t.MarshalCell
+	case driver.Valuer:
+		return func(cell *Cell) error {
+			value, err := t.Value()
+			if err != nil {
+				return err
+			}
+			if cell.SetString(``); value != nil {
+				cell.SetValue(value)
+			}
+			return nil
+		}
+	case encoding.TextMarshaler:
+		return func(cell *Cell) error {
+			text, err := t.MarshalText()
+			if err != nil {
+				return err
+			}
+			cell.SetString(string(text))
+			return nil
+		}
+	}
+	return nil
+}
+
+// durationFromCell reads a time.Duration from cell.  Text is parsed by
+// time.ParseDuration, while numbers are taken to be a number of days,
+// which is how Excel stores durations such as "[h]:mm".
+func durationFromCell(cell *Cell) (time.Duration, error) {
+	if cell.Type() == CellTypeString || cell.Type() == CellTypeInline {
+		return time.ParseDuration(cell.Value)
+	}
+	days, err := cell.Float()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(days * float64(24*time.Hour)), nil
+}
@@ -0,0 +1,177 @@
+package xlsx
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// convPoint writes itself to a cell as "x;y".
+type convPoint struct{ X, Y int }
+
+func (p convPoint) MarshalCell(cell *Cell) error {
+	cell.SetString(fmt.Sprintf("%d;%d", p.X, p.Y))
+	return nil
+}
+
+func (p *convPoint) UnmarshalCell(cell *Cell) error {
+	_, err := fmt.Sscanf(cell.Value, "%d;%d", &p.X, &p.Y)
+	return err
+}
+
+// convIdent is written as text through encoding.TextMarshaler.
+type convIdent struct{ id string }
+
+func (i convIdent) MarshalText() ([]byte, error) {
+	return []byte("ID-" + i.id), nil
+}
+
+func (i *convIdent) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "ID-") {
+		return errors.New("not an ID")
+	}
+	i.id = strings.TrimPrefix(string(text), "ID-")
+	return nil
+}
+
+// convCents is converted by a registered Converter.
+type convCents struct{ cents int64 }
+
+type convRecord struct {
+	Point    convPoint       `xlsx:"name=Point"`
+	Ident    *convIdent      `xlsx:"name=Ident"`
+	Price    convCents       `xlsx:"name=Price"`
+	Count    sql.NullInt64   `xlsx:"name=Count"`
+	Label    sql.NullString  `xlsx:"name=Label"`
+	Ratio    float32         `xlsx:"name=Ratio"`
+	Small    uint16          `xlsx:"name=Small"`
+	Duration time.Duration   `xlsx:"name=Duration"`
+	Missing  sql.NullFloat64 `xlsx:"name=Missing"`
+}
+
+func TestConverters(t *testing.T) {
+	c := qt.New(t)
+	RegisterConverter(convCents{}, Converter{
+		ToCell: func(v interface{}, cell *Cell) error {
+			cell.SetFloatWithFormat(float64(v.(convCents).cents)/100, "0.00")
+			return nil
+		},
+		FromCell: func(cell *Cell) (interface{}, error) {
+			f, err := cell.Float()
+			return convCents{cents: int64(f*100 + 0.5)}, err
+		},
+	})
+	defer RegisterConverter(convCents{}, Converter{})
+
+	c.Run("IsCellType", func(c *qt.C) {
+		c.Assert(isCellType(timeType), qt.IsTrue)
+		c.Assert(isCellType(typeOf[convPoint]()), qt.IsTrue)
+		c.Assert(isCellType(typeOf[convIdent]()), qt.IsTrue)
+		c.Assert(isCellType(typeOf[convCents]()), qt.IsTrue)
+		c.Assert(isCellType(typeOf[sql.NullTime]()), qt.IsTrue)
+		c.Assert(isCellType(typeOf[marshalSupplier]()), qt.IsFalse)
+	})
+
+	csRunO(c, "RoundTrip", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		records := []convRecord{{
+			Point:    convPoint{3, 4},
+			Ident:    &convIdent{"42"},
+			Price:    convCents{1999},
+			Count:    sql.NullInt64{Int64: 7, Valid: true},
+			Label:    sql.NullString{String: "seven", Valid: true},
+			Ratio:    0.25,
+			Small:    65535,
+			Duration: 90 * time.Minute,
+		}}
+		c.Assert(sheet.Marshal(records, MarshalOptions{}), qt.IsNil)
+
+		cell, err := sheet.Cell(1, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Value, qt.Equals, "3;4")
+		cell, err = sheet.Cell(1, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Value, qt.Equals, "ID-42")
+		cell, err = sheet.Cell(1, 2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Value, qt.Equals, "19.99")
+		c.Assert(cell.GetNumberFormat(), qt.Equals, "0.00")
+		cell, err = sheet.Cell(1, 7)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Value, qt.Equals, "1h30m0s")
+
+		read, err := ReadAll[convRecord](sheet, UnmarshalOptions{HeaderRows: 1})
+		c.Assert(err, qt.IsNil)
+		c.Assert(read, qt.HasLen, 1)
+		got := read[0]
+		c.Assert(got.Point, qt.Equals, records[0].Point)
+		c.Assert(*got.Ident, qt.Equals, *records[0].Ident)
+		c.Assert(got.Price, qt.Equals, records[0].Price)
+		c.Assert(got.Count, qt.Equals, records[0].Count)
+		c.Assert(got.Label, qt.Equals, records[0].Label)
+		c.Assert(got.Ratio, qt.Equals, records[0].Ratio)
+		c.Assert(got.Small, qt.Equals, records[0].Small)
+		c.Assert(got.Duration, qt.Equals, records[0].Duration)
+		c.Assert(got.Missing.Valid, qt.IsFalse)
+	})
+
+	csRunO(c, "ReadStruct", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		row.AddCell().SetString("1;2")
+		row.AddCell().SetFloat(0.5)
+		row.AddCell().SetInt(300)
+		row.AddCell().SetInt(-1)
+		type positional struct {
+			Point    convPoint     `xlsx:"0"`
+			Duration time.Duration `xlsx:"1"`
+			Big      uint16        `xlsx:"2"`
+			Byte     uint8         `xlsx:"2"`
+		}
+		var p positional
+		c.Assert(row.ReadStruct(&p), qt.ErrorMatches, `300 overflows uint8`)
+		c.Assert(p.Point, qt.Equals, convPoint{1, 2})
+		c.Assert(p.Duration, qt.Equals, 12*time.Hour)
+		c.Assert(p.Big, qt.Equals, uint16(300))
+
+		var bad struct {
+			Ident convIdent `xlsx:"0"`
+		}
+		c.Assert(row.ReadStruct(&bad), qt.ErrorMatches, `not an ID`)
+		var negative struct {
+			N uint `xlsx:"3"`
+		}
+		c.Assert(row.ReadStruct(&negative), qt.Not(qt.IsNil))
+	})
+
+	csRunO(c, "WriteStruct", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		value := struct {
+			Point convPoint
+			Ident convIdent
+			Count sql.NullInt32
+		}{convPoint{5, 6}, convIdent{"x"}, sql.NullInt32{}}
+		c.Assert(row.WriteStruct(&value, -1), qt.Equals, 3)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "5;6")
+		c.Assert(row.GetCell(1).Value, qt.Equals, "ID-x")
+		c.Assert(row.GetCell(2).Value, qt.Equals, "")
+	})
+}
+
+// typeOf returns the reflect.Type of T.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
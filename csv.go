@@ -0,0 +1,327 @@
+package xlsx
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var errBadComma = errors.New("invalid CSV field delimiter")
+
+const (
+	utf8BOM = "\uFEFF"
+	// csvDateFormat and csvTimeFormat are the number formats of the
+	// dates and times that ImportCSV finds.
+	csvDateFormat = "yyyy-mm-dd"
+	csvTimeFormat = "yyyy-mm-dd hh:mm:ss"
+)
+
+// CSVWriteOptions control File.WriteCSV.
+type CSVWriteOptions struct {
+	// Comma is the field delimiter.  It defaults to a comma.
+	Comma rune
+	// Raw writes the values of cells as they are stored, rather than
+	// formatted by their number formats.
+	Raw bool
+	// QuoteAll quotes every field, rather than only those that need it.
+	QuoteAll bool
+	// BOM starts the output with a UTF-8 byte order mark, which some
+	// versions of Excel need to read UTF-8 CSV files correctly.
+	BOM bool
+	// UseCRLF ends lines with \r\n rather than \n.
+	UseCRLF bool
+	// DateLayout, if set, is the layout, in the form taken by
+	// time.Time.Format, in which cells holding dates are written,
+	// whatever their number format.
+	DateLayout string
+}
+
+// WriteCSV writes the Sheet called sheetName to w as CSV, one line
+// for each row, with as many fields as the widest row.
+func (f *File) WriteCSV(sheetName string, w io.Writer, opts CSVWriteOptions) error {
+	sheet, ok := f.Sheet[sheetName]
+	if !ok {
+		return fmt.Errorf("WriteCSV: no sheet called %q", sheetName)
+	}
+	comma := opts.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	if !validCSVDelim(comma) {
+		return fmt.Errorf("WriteCSV: %w: %q", errBadComma, comma)
+	}
+	eol := "\n"
+	if opts.UseCRLF {
+		eol = "\r\n"
+	}
+	bw := bufio.NewWriter(w)
+	if opts.BOM {
+		bw.WriteString(utf8BOM)
+	}
+	fields := make([]string, sheet.MaxCol)
+	err := sheet.ForEachRow(func(row *Row) error {
+		for i := range fields {
+			fields[i] = ""
+		}
+		err := row.ForEachCell(func(c *Cell) error {
+			col, _ := c.GetCoordinates()
+			if col >= len(fields) {
+				return nil
+			}
+			value, err := csvCellText(c, opts)
+			if err != nil {
+				return err
+			}
+			fields[col] = value
+			return nil
+		}, SkipEmptyCells)
+		if err != nil {
+			return err
+		}
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteRune(comma)
+			}
+			writeCSVField(bw, field, comma, opts.QuoteAll)
+		}
+		_, err = bw.WriteString(eol)
+		return err
+	})
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("WriteCSV: %w", err)
+	}
+	return nil
+}
+
+// csvCellText returns the text that WriteCSV writes for c.
+func csvCellText(c *Cell, opts CSVWriteOptions) (string, error) {
+	if opts.DateLayout != "" && c.Type() == CellTypeNumeric && c.IsTime() {
+		t, err := c.GetTime(c.isDate1904())
+		if err != nil {
+			return "", err
+		}
+		return t.Format(opts.DateLayout), nil
+	}
+	if opts.Raw {
+		return c.Value, nil
+	}
+	return c.FormattedValue()
+}
+
+// writeCSVField writes field to w, quoted if quote is set or if it
+// needs to be, in the same way as encoding/csv.
+func writeCSVField(w *bufio.Writer, field string, comma rune, quote bool) {
+	if !quote {
+		quote = field != "" && (strings.ContainsRune(field, comma) ||
+			strings.ContainsAny(field, "\"\r\n") ||
+			field[0] == ' ' || field[0] == '\t' || field == `\.`)
+	}
+	if !quote {
+		w.WriteString(field)
+		return
+	}
+	w.WriteByte('"')
+	w.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	w.WriteByte('"')
+}
+
+// validCSVDelim reports whether r can separate the fields of a CSV
+// file, as encoding/csv requires.
+func validCSVDelim(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// CSVImportOptions control ImportCSV.
+type CSVImportOptions struct {
+	// File is the File to which the new Sheet is added.  If it is
+	// nil, a new File is made with FileOptions, such as
+	// UseDiskVCellStore for files too big to hold in memory.
+	File        *File
+	FileOptions []FileOption
+	// SheetName is the name of the new Sheet.  It defaults to
+	// "Sheet1".
+	SheetName string
+	// Comma is the field delimiter.  It defaults to a comma.
+	Comma rune
+	// Comment, if set, is the character that starts comment lines.
+	Comment rune
+	// LazyQuotes allows quotes to appear in unquoted fields, and
+	// unescaped quotes in quoted ones.
+	LazyQuotes bool
+	// TextOnly turns off type inference, so that every field is
+	// imported as text.
+	TextOnly bool
+	// TextColumns are the indexes, from zero, of columns imported as
+	// text whatever they hold.
+	TextColumns []int
+	// DateLayouts are the layouts, in the form taken by time.Parse,
+	// that fields are tried against to find dates.  They default to
+	// DefaultCSVDateLayouts.
+	DateLayouts []string
+}
+
+// DefaultCSVDateLayouts are the layouts that ImportCSV recognises as
+// dates unless told otherwise.  Layouts such as 01/02/2006 are left
+// out as they mean different dates in different places.
+var DefaultCSVDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// ImportCSV reads CSV from r into a new Sheet, a row for each record.
+// The types of fields are inferred, building on parseValue: decimal
+// numbers become numeric cells, TRUE and FALSE become booleans, and
+// fields that match one of the date layouts become dates, at the wall
+// clock time they give, whatever their time zone offset.  Numbers
+// that would be changed by being stored as such, like IDs with leading
+// zeros such as "007", numbers with a leading "+" and integers of more
+// than 15 digits, stay text, as does anything else.
+//
+// Records are read one at a time and handed to the Sheet's CellStore,
+// so files of any size may be imported with UseDiskVCellStore.  A
+// leading UTF-8 byte order mark is skipped.  If a record can't be
+// read, the new Sheet is taken out of the File again.
+func ImportCSV(r io.Reader, opts CSVImportOptions) (*Sheet, error) {
+	file := opts.File
+	if file == nil {
+		file = NewFile(opts.FileOptions...)
+	}
+	name := opts.SheetName
+	if name == "" {
+		name = "Sheet1"
+	}
+	br := bufio.NewReader(r)
+	if lead, err := br.Peek(len(utf8BOM)); err == nil && string(lead) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	reader := csv.NewReader(br)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.Comment = opts.Comment
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	layouts := opts.DateLayouts
+	if layouts == nil {
+		layouts = DefaultCSVDateLayouts
+	}
+	textColumns := make(map[int]bool, len(opts.TextColumns))
+	for _, col := range opts.TextColumns {
+		textColumns[col] = true
+	}
+
+	sheet, err := file.AddSheet(name)
+	if err != nil {
+		return nil, fmt.Errorf("ImportCSV: %w", err)
+	}
+	fail := func(err error) (*Sheet, error) {
+		file.removeSheet(sheet)
+		return nil, fmt.Errorf("ImportCSV: %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if sheet.MaxRow >= Excel2006MaxRowCount {
+			return fail(fmt.Errorf("more than %d rows", Excel2006MaxRowCount))
+		}
+		row := sheet.AddRow()
+		for col, field := range record {
+			cell := row.AddCell()
+			if opts.TextOnly || textColumns[col] {
+				cell.SetString(field)
+				continue
+			}
+			setInferred(cell, field, layouts)
+		}
+	}
+	return sheet, nil
+}
+
+// setInferred sets cell to field, as a number, boolean or date if it
+// reads as one, and otherwise as text.
+func setInferred(cell *Cell, field string, layouts []string) {
+	if field == "" {
+		return
+	}
+	if isCSVNumber(field) {
+		switch n := parseValue(field).(type) {
+		case int64:
+			cell.SetInt64(n)
+			return
+		case float64:
+			cell.SetFloat(n)
+			return
+		}
+	}
+	switch {
+	case strings.EqualFold(field, "true"):
+		cell.SetBool(true)
+		return
+	case strings.EqualFold(field, "false"):
+		cell.SetBool(false)
+		return
+	}
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, field)
+		if err != nil {
+			continue
+		}
+		format := csvTimeFormat
+		if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0 {
+			format = csvDateFormat
+		}
+		cell.SetDateWithOptions(t, DateTimeOptions{Location: t.Location(), ExcelTimeFormat: format})
+		return
+	}
+	cell.SetString(field)
+}
+
+// isCSVNumber reports whether field is a plain decimal number, such as
+// -12, 3.5 or 1e6, that is stored as a number without changing how it
+// reads.  Leading zeros, a leading "+", and integers too long to be
+// held exactly, mark identifiers rather than numbers.
+func isCSVNumber(field string) bool {
+	digits := strings.TrimPrefix(field, "-")
+	mantissa := digits
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		mantissa = digits[:i]
+		exp := strings.TrimLeft(digits[i+1:], "+-")
+		if len(digits[i+1:])-len(exp) > 1 || !isDigits(exp) {
+			return false
+		}
+	}
+	whole, frac, hasPoint := strings.Cut(mantissa, ".")
+	switch {
+	case whole == "" && frac == "":
+		return false
+	case whole != "" && !isDigits(whole):
+		return false
+	case frac != "" && !isDigits(frac):
+		return false
+	case len(whole) > 1 && whole[0] == '0':
+		return false
+	case !hasPoint && mantissa == digits && len(whole) > 15:
+		return false
+	}
+	if _, err := strconv.ParseFloat(field, 64); err != nil {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestIsCSVNumber(t *testing.T) {
+	c := qt.New(t)
+	for field, want := range map[string]bool{
+		"0": true, "-12": true, "3.5": true, ".5": true, "0.25": true, "1e6": true, "-2.5E-3": true,
+		"007": false, "+44": false, "00.5": false, "1234567890123456": false, "12345678901234.5": true,
+		"": false, "-": false, ".": false, "1e": false, "1e+-2": false, "Inf": false, "NaN": false,
+		"0x10": false, "1_000": false, "1,000": false, " 1": false,
+	} {
+		c.Assert(isCSVNumber(field), qt.Equals, want, qt.Commentf("%q", field))
+	}
+}
+
+func TestCSV(t *testing.T) {
+	c := qt.New(t)
+
+	const input = "\uFEFFid,name,amount,when,active,note\n" +
+		"007,\"Smith, J\",1234.5,2025-03-04,TRUE,\n" +
+		"42,\"Says \"\"hi\"\"\",-3,2025-03-04 13:30:00,false,+44 20 7946 0000\n" +
+		"1,x\n"
+
+	csRunO(c, "ImportCSV", func(c *qt.C, option FileOption) {
+		sheet, err := ImportCSV(strings.NewReader(input), CSVImportOptions{
+			FileOptions: []FileOption{option},
+			SheetName:   "Imported",
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.Name, qt.Equals, "Imported")
+		c.Assert(sheet.File.Sheet["Imported"], qt.Equals, sheet)
+		c.Assert(sheet.MaxRow, qt.Equals, 4)
+		c.Assert(sheet.MaxCol, qt.Equals, 6)
+
+		row, err := sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "id")
+
+		row, err = sheet.Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(0).Type(), qt.Equals, CellTypeString)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "007")
+		c.Assert(row.GetCell(1).Value, qt.Equals, "Smith, J")
+		c.Assert(row.GetCell(2).Type(), qt.Equals, CellTypeNumeric)
+		c.Assert(row.GetCell(2).Value, qt.Equals, "1234.5")
+		when, err := row.GetCell(3).GetTime(false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(when, qt.Equals, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC))
+		c.Assert(row.GetCell(3).NumFmt, qt.Equals, "yyyy-mm-dd")
+		c.Assert(row.GetCell(4).Type(), qt.Equals, CellTypeBool)
+		c.Assert(row.GetCell(4).Bool(), qt.IsTrue)
+		c.Assert(row.GetCell(5).Value, qt.Equals, "")
+
+		row, err = sheet.Row(2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(0).Type(), qt.Equals, CellTypeNumeric)
+		c.Assert(row.GetCell(1).Value, qt.Equals, `Says "hi"`)
+		c.Assert(row.GetCell(3).NumFmt, qt.Equals, "yyyy-mm-dd hh:mm:ss")
+		c.Assert(row.GetCell(4).Bool(), qt.IsFalse)
+		c.Assert(row.GetCell(5).Type(), qt.Equals, CellTypeString)
+
+		var out bytes.Buffer
+		c.Assert(sheet.File.WriteCSV("Imported", &out, CSVWriteOptions{}), qt.IsNil)
+		c.Assert(out.String(), qt.Equals, "id,name,amount,when,active,note\n"+
+			"007,\"Smith, J\",1234.5,2025-03-04,TRUE,\n"+
+			"42,\"Says \"\"hi\"\"\",-3,2025-03-04 13:30:00,FALSE,+44 20 7946 0000\n"+
+			"1,x,,,,\n")
+	})
+
+	csRunO(c, "ImportOptions", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := ImportCSV(strings.NewReader("# comment\n1;2025-01-02;03/04/2025\n"), CSVImportOptions{
+			File:        file,
+			Comma:       ';',
+			Comment:     '#',
+			TextColumns: []int{0},
+			DateLayouts: []string{"01/02/2006"},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.Name, qt.Equals, "Sheet1")
+		c.Assert(file.Sheets, qt.HasLen, 1)
+		row, err := sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(0).Type(), qt.Equals, CellTypeString)
+		c.Assert(row.GetCell(1).Type(), qt.Equals, CellTypeString)
+		c.Assert(row.GetCell(2).IsTime(), qt.IsTrue)
+
+		_, err = ImportCSV(strings.NewReader("1\n"), CSVImportOptions{File: file})
+		c.Assert(err, qt.ErrorMatches, `ImportCSV: duplicate sheet name 'Sheet1'`)
+		_, err = ImportCSV(strings.NewReader("\"a\nb"), CSVImportOptions{TextOnly: true})
+		c.Assert(err, qt.ErrorMatches, `ImportCSV: .*`)
+
+		// A failed import leaves the File as it was.
+		_, err = ImportCSV(strings.NewReader("1\n\"a\nb"), CSVImportOptions{File: file, SheetName: "Broken"})
+		c.Assert(err, qt.ErrorMatches, `ImportCSV: .*`)
+		c.Assert(file.Sheets, qt.HasLen, 1)
+		c.Assert(file.Sheet["Broken"], qt.IsNil)
+
+		// Times with an offset keep their wall clock time.
+		sheet, err = ImportCSV(strings.NewReader("2025-03-04T13:30:00+02:00\n"), CSVImportOptions{File: file, SheetName: "Zoned"})
+		c.Assert(err, qt.IsNil)
+		row, err = sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		when, err := row.GetCell(0).GetTime(false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(when, qt.Equals, time.Date(2025, 3, 4, 13, 30, 0, 0, time.UTC))
+	})
+
+	csRunO(c, "WriteOptions", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Out")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		row.AddCell().SetFloatWithFormat(0.5, "0%")
+		row.AddCell().SetDate(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC))
+		row.AddCell().SetString("a;b")
+		row.AddCell().SetBool(true)
+
+		var out bytes.Buffer
+		c.Assert(file.WriteCSV("Out", &out, CSVWriteOptions{}), qt.IsNil)
+		c.Assert(out.String(), qt.Equals, "50%,03-04-25,a;b,TRUE\n")
+
+		out.Reset()
+		err = file.WriteCSV("Out", &out, CSVWriteOptions{
+			Comma: ';', Raw: true, BOM: true, UseCRLF: true, DateLayout: "02/01/2006",
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(out.String(), qt.Equals, "\uFEFF0.5;04/03/2025;\"a;b\";1\r\n")
+
+		out.Reset()
+		c.Assert(file.WriteCSV("Out", &out, CSVWriteOptions{QuoteAll: true}), qt.IsNil)
+		c.Assert(out.String(), qt.Equals, `"50%","03-04-25","a;b","TRUE"`+"\n")
+
+		err = file.WriteCSV("Missing", &out, CSVWriteOptions{})
+		c.Assert(err, qt.ErrorMatches, `WriteCSV: no sheet called "Missing"`)
+		err = file.WriteCSV("Out", &out, CSVWriteOptions{Comma: '"'})
+		c.Assert(errors.Is(err, errBadComma), qt.IsTrue)
+	})
+}
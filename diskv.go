@@ -133,6 +133,11 @@ func (dvr *DiskVRow) readCell(key string) (*Cell, error) {
 	if c.num, err = readInt(buf); err != nil {
 		return c, err
 	}
+	var styleID int
+	if styleID, err = readInt(buf); err != nil {
+		return c, err
+	}
+	c.styleID = StyleID(styleID)
 	if c.RichText, err = readRichText(buf); err != nil {
 		return c, err
 	}
@@ -210,6 +215,9 @@ func (dvr *DiskVRow) writeCell(c *Cell) error {
 	if err = writeInt(&dvr.buf, c.num); err != nil {
 		return err
 	}
+	if err = writeInt(&dvr.buf, int(c.styleID)); err != nil {
+		return err
+	}
 	if err = writeRichText(&dvr.buf, c.RichText); err != nil {
 		return err
 	}
@@ -654,6 +662,38 @@ func readEndOfRecord(reader *bytes.Reader) error {
 	return nil
 }
 
+func writeColor(buf *bytes.Buffer, c Color) error {
+	if err := writeInt(buf, int(c.Type)); err != nil {
+		return err
+	}
+	if err := writeString(buf, c.RGB); err != nil {
+		return err
+	}
+	if err := writeInt(buf, c.Index); err != nil {
+		return err
+	}
+	return writeFloat(buf, c.Tint)
+}
+
+func readColor(reader *bytes.Reader) (Color, error) {
+	c := Color{}
+	colorType, err := readInt(reader)
+	if err != nil {
+		return c, err
+	}
+	c.Type = ColorType(colorType)
+	if c.RGB, err = readString(reader); err != nil {
+		return c, err
+	}
+	if c.Index, err = readInt(reader); err != nil {
+		return c, err
+	}
+	if c.Tint, err = readFloat(reader); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
 func writeBorder(buf *bytes.Buffer, b Border) error {
 	if err := writeString(buf, b.Left); err != nil {
 		return err
@@ -661,24 +701,51 @@ func writeBorder(buf *bytes.Buffer, b Border) error {
 	if err := writeString(buf, b.LeftColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, b.LeftColorSpec); err != nil {
+		return err
+	}
 	if err := writeString(buf, b.Right); err != nil {
 		return err
 	}
 	if err := writeString(buf, b.RightColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, b.RightColorSpec); err != nil {
+		return err
+	}
 	if err := writeString(buf, b.Top); err != nil {
 		return err
 	}
 	if err := writeString(buf, b.TopColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, b.TopColorSpec); err != nil {
+		return err
+	}
 	if err := writeString(buf, b.Bottom); err != nil {
 		return err
 	}
 	if err := writeString(buf, b.BottomColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, b.BottomColorSpec); err != nil {
+		return err
+	}
+	if err := writeString(buf, b.Diagonal); err != nil {
+		return err
+	}
+	if err := writeString(buf, b.DiagonalColor); err != nil {
+		return err
+	}
+	if err := writeColor(buf, b.DiagonalColorSpec); err != nil {
+		return err
+	}
+	if err := writeBool(buf, b.DiagonalUp); err != nil {
+		return err
+	}
+	if err := writeBool(buf, b.DiagonalDown); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -691,24 +758,51 @@ func readBorder(reader *bytes.Reader) (Border, error) {
 	if b.LeftColor, err = readString(reader); err != nil {
 		return b, err
 	}
+	if b.LeftColorSpec, err = readColor(reader); err != nil {
+		return b, err
+	}
 	if b.Right, err = readString(reader); err != nil {
 		return b, err
 	}
 	if b.RightColor, err = readString(reader); err != nil {
 		return b, err
 	}
+	if b.RightColorSpec, err = readColor(reader); err != nil {
+		return b, err
+	}
 	if b.Top, err = readString(reader); err != nil {
 		return b, err
 	}
 	if b.TopColor, err = readString(reader); err != nil {
 		return b, err
 	}
+	if b.TopColorSpec, err = readColor(reader); err != nil {
+		return b, err
+	}
 	if b.Bottom, err = readString(reader); err != nil {
 		return b, err
 	}
 	if b.BottomColor, err = readString(reader); err != nil {
 		return b, err
 	}
+	if b.BottomColorSpec, err = readColor(reader); err != nil {
+		return b, err
+	}
+	if b.Diagonal, err = readString(reader); err != nil {
+		return b, err
+	}
+	if b.DiagonalColor, err = readString(reader); err != nil {
+		return b, err
+	}
+	if b.DiagonalColorSpec, err = readColor(reader); err != nil {
+		return b, err
+	}
+	if b.DiagonalUp, err = readBool(reader); err != nil {
+		return b, err
+	}
+	if b.DiagonalDown, err = readBool(reader); err != nil {
+		return b, err
+	}
 	return b, nil
 }
 
@@ -719,12 +813,84 @@ func writeFill(buf *bytes.Buffer, f Fill) error {
 	if err := writeString(buf, f.BgColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, f.BgColorSpec); err != nil {
+		return err
+	}
 	if err := writeString(buf, f.FgColor); err != nil {
 		return err
 	}
+	if err := writeColor(buf, f.FgColorSpec); err != nil {
+		return err
+	}
+	return writeGradientFill(buf, f.Gradient)
+}
+
+func writeGradientFill(buf *bytes.Buffer, g *GradientFill) error {
+	if err := writeBool(buf, g == nil); err != nil {
+		return err
+	}
+	if g == nil {
+		return nil
+	}
+	if err := writeString(buf, g.Type); err != nil {
+		return err
+	}
+	for _, f := range []float64{g.Degree, g.Left, g.Right, g.Top, g.Bottom} {
+		if err := writeFloat(buf, f); err != nil {
+			return err
+		}
+	}
+	if err := writeInt(buf, len(g.Stops)); err != nil {
+		return err
+	}
+	for _, stop := range g.Stops {
+		if err := writeFloat(buf, stop.Position); err != nil {
+			return err
+		}
+		if err := writeString(buf, stop.Color); err != nil {
+			return err
+		}
+		if err := writeColor(buf, stop.ColorSpec); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func readGradientFill(reader *bytes.Reader) (*GradientFill, error) {
+	isNil, err := readBool(reader)
+	if err != nil || isNil {
+		return nil, err
+	}
+	g := &GradientFill{}
+	if g.Type, err = readString(reader); err != nil {
+		return g, err
+	}
+	for _, f := range []*float64{&g.Degree, &g.Left, &g.Right, &g.Top, &g.Bottom} {
+		if *f, err = readFloat(reader); err != nil {
+			return g, err
+		}
+	}
+	count, err := readInt(reader)
+	if err != nil {
+		return g, err
+	}
+	for i := 0; i < count; i++ {
+		stop := GradientStop{}
+		if stop.Position, err = readFloat(reader); err != nil {
+			return g, err
+		}
+		if stop.Color, err = readString(reader); err != nil {
+			return g, err
+		}
+		if stop.ColorSpec, err = readColor(reader); err != nil {
+			return g, err
+		}
+		g.Stops = append(g.Stops, stop)
+	}
+	return g, nil
+}
+
 func readFill(reader *bytes.Reader) (Fill, error) {
 	var err error
 	f := Fill{}
@@ -734,9 +900,18 @@ func readFill(reader *bytes.Reader) (Fill, error) {
 	if f.BgColor, err = readString(reader); err != nil {
 		return f, err
 	}
+	if f.BgColorSpec, err = readColor(reader); err != nil {
+		return f, err
+	}
 	if f.FgColor, err = readString(reader); err != nil {
 		return f, err
 	}
+	if f.FgColorSpec, err = readColor(reader); err != nil {
+		return f, err
+	}
+	if f.Gradient, err = readGradientFill(reader); err != nil {
+		return f, err
+	}
 	return f, nil
 }
 
@@ -756,6 +931,9 @@ func writeFont(buf *bytes.Buffer, f Font) error {
 	if err := writeString(buf, f.Color); err != nil {
 		return err
 	}
+	if err := writeColor(buf, f.ColorSpec); err != nil {
+		return err
+	}
 	if err := writeBool(buf, f.Bold); err != nil {
 		return err
 	}
@@ -765,6 +943,18 @@ func writeFont(buf *bytes.Buffer, f Font) error {
 	if err := writeBool(buf, f.Underline); err != nil {
 		return err
 	}
+	if err := writeString(buf, f.UnderlineStyle); err != nil {
+		return err
+	}
+	if err := writeBool(buf, f.Strike); err != nil {
+		return err
+	}
+	if err := writeString(buf, f.VertAlign); err != nil {
+		return err
+	}
+	if err := writeString(buf, f.Scheme); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -786,6 +976,9 @@ func readFont(reader *bytes.Reader) (Font, error) {
 	if f.Color, err = readString(reader); err != nil {
 		return f, err
 	}
+	if f.ColorSpec, err = readColor(reader); err != nil {
+		return f, err
+	}
 	if f.Bold, err = readBool(reader); err != nil {
 		return f, err
 	}
@@ -795,6 +988,18 @@ func readFont(reader *bytes.Reader) (Font, error) {
 	if f.Underline, err = readBool(reader); err != nil {
 		return f, err
 	}
+	if f.UnderlineStyle, err = readString(reader); err != nil {
+		return f, err
+	}
+	if f.Strike, err = readBool(reader); err != nil {
+		return f, err
+	}
+	if f.VertAlign, err = readString(reader); err != nil {
+		return f, err
+	}
+	if f.Scheme, err = readString(reader); err != nil {
+		return f, err
+	}
 	return f, nil
 }
 
@@ -818,6 +1023,12 @@ func writeAlignment(buf *bytes.Buffer, a Alignment) error {
 	if err = writeBool(buf, a.WrapText); err != nil {
 		return err
 	}
+	if err = writeInt(buf, a.RelativeIndent); err != nil {
+		return err
+	}
+	if err = writeInt(buf, a.ReadingOrder); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -842,9 +1053,43 @@ func readAlignment(reader *bytes.Reader) (Alignment, error) {
 	if a.WrapText, err = readBool(reader); err != nil {
 		return a, err
 	}
+	if a.RelativeIndent, err = readInt(reader); err != nil {
+		return a, err
+	}
+	if a.ReadingOrder, err = readInt(reader); err != nil {
+		return a, err
+	}
 	return a, nil
 }
 
+func writeProtection(buf *bytes.Buffer, p *Protection) error {
+	if err := writeBool(buf, p == nil); err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	if err := writeBool(buf, p.Locked); err != nil {
+		return err
+	}
+	return writeBool(buf, p.Hidden)
+}
+
+func readProtection(reader *bytes.Reader) (*Protection, error) {
+	isNil, err := readBool(reader)
+	if err != nil || isNil {
+		return nil, err
+	}
+	p := &Protection{}
+	if p.Locked, err = readBool(reader); err != nil {
+		return p, err
+	}
+	if p.Hidden, err = readBool(reader); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
 func writeStyle(buf *bytes.Buffer, s *Style) error {
 	var err error
 	if err = writeBorder(buf, s.Border); err != nil {
@@ -871,6 +1116,15 @@ func writeStyle(buf *bytes.Buffer, s *Style) error {
 	if err = writeBool(buf, s.ApplyAlignment); err != nil {
 		return err
 	}
+	if err = writeBool(buf, s.ApplyProtection); err != nil {
+		return err
+	}
+	if err = writeProtection(buf, s.Protection); err != nil {
+		return err
+	}
+	if err = writeString(buf, s.NamedStyle); err != nil {
+		return err
+	}
 	if err = writeEndOfRecord(buf); err != nil {
 		return err
 	}
@@ -904,6 +1158,15 @@ func readStyle(reader *bytes.Reader) (*Style, error) {
 	if s.ApplyAlignment, err = readBool(reader); err != nil {
 		return s, err
 	}
+	if s.ApplyProtection, err = readBool(reader); err != nil {
+		return s, err
+	}
+	if s.Protection, err = readProtection(reader); err != nil {
+		return s, err
+	}
+	if s.NamedStyle, err = readString(reader); err != nil {
+		return s, err
+	}
 	if err = readEndOfRecord(reader); err != nil {
 		return s, err
 	}
@@ -1020,12 +1283,32 @@ func writeRow(buf *bytes.Buffer, r *Row) error {
 	if err = writeBool(buf, r.isCustom); err != nil {
 		return err
 	}
+	if err = writeBool(buf, r.customHeight); err != nil {
+		return err
+	}
+	if err = writeBool(buf, r.autoHeight); err != nil {
+		return err
+	}
+	if err = writeBool(buf, r.collapsed); err != nil {
+		return err
+	}
 	if err = writeInt(buf, r.num); err != nil {
 		return err
 	}
 	if err = writeInt(buf, r.cellStoreRow.MaxCol()); err != nil {
 		return err
 	}
+	if err = writeString(buf, r.numFmt); err != nil {
+		return err
+	}
+	if err = writeBool(buf, r.style != nil); err != nil {
+		return err
+	}
+	if r.style != nil {
+		if err = writeStyle(buf, r.style); err != nil {
+			return err
+		}
+	}
 	if err = writeEndOfRecord(buf); err != nil {
 		return err
 	}
@@ -1089,6 +1372,9 @@ func writeCell(buf *bytes.Buffer, c *Cell) error {
 	if err = writeInt(buf, c.num); err != nil {
 		return err
 	}
+	if err = writeInt(buf, int(c.styleID)); err != nil {
+		return err
+	}
 	if err = writeRichText(buf, c.RichText); err != nil {
 		return err
 	}
@@ -1138,6 +1424,18 @@ func readRow(reader *bytes.Reader, store *diskv.Diskv, sheet *Sheet) (*Row, erro
 	if err != nil {
 		return nil, err
 	}
+	r.customHeight, err = readBool(reader)
+	if err != nil {
+		return nil, err
+	}
+	r.autoHeight, err = readBool(reader)
+	if err != nil {
+		return nil, err
+	}
+	r.collapsed, err = readBool(reader)
+	if err != nil {
+		return nil, err
+	}
 	r.num, err = readInt(reader)
 	if err != nil {
 		return nil, err
@@ -1146,6 +1444,20 @@ func readRow(reader *bytes.Reader, store *diskv.Diskv, sheet *Sheet) (*Row, erro
 	if err != nil {
 		return nil, err
 	}
+	r.numFmt, err = readString(reader)
+	if err != nil {
+		return nil, err
+	}
+	hasStyle, err := readBool(reader)
+	if err != nil {
+		return nil, err
+	}
+	if hasStyle {
+		r.style, err = readStyle(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err = readEndOfRecord(reader)
 	if err != nil {
 		return r, err
@@ -1214,6 +1526,11 @@ func readCell(reader *bytes.Reader) (*Cell, error) {
 	if c.num, err = readInt(reader); err != nil {
 		return c, err
 	}
+	var styleID int
+	if styleID, err = readInt(reader); err != nil {
+		return c, err
+	}
+	c.styleID = StyleID(styleID)
 	if c.RichText, err = readRichText(reader); err != nil {
 		return c, err
 	}
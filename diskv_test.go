@@ -349,42 +349,62 @@ line!`)
 		buf := bytes.NewBufferString("")
 		s := Style{
 			Border: Border{
-				Left:        "left",
-				LeftColor:   "leftColor",
-				Right:       "right",
-				RightColor:  "rightColor",
-				Top:         "top",
-				TopColor:    "topColor",
-				Bottom:      "bottom",
-				BottomColor: "bottomColor",
+				Left:          "left",
+				LeftColor:     "leftColor",
+				Right:         "right",
+				RightColor:    "rightColor",
+				Top:           "top",
+				TopColor:      "topColor",
+				Bottom:        "bottom",
+				BottomColor:   "bottomColor",
+				Diagonal:      "diagonal",
+				DiagonalColor: "diagonalColor",
+				DiagonalUp:    true,
 			},
 			Fill: Fill{
 				PatternType: "PatternType",
 				BgColor:     "BgColor",
 				FgColor:     "FgColor",
+				Gradient: &GradientFill{
+					Type:   "path",
+					Degree: 45,
+					Left:   0.5,
+					Stops: []GradientStop{
+						{Position: 0, Color: "FFFFFFFF"},
+						{Position: 1, Color: "FF0000FF"},
+					},
+				},
 			},
 			Font: Font{
-				Size:      1,
-				Name:      "Font",
-				Family:    2,
-				Charset:   3,
-				Color:     "Red",
-				Bold:      true,
-				Italic:    true,
-				Underline: true,
+				Size:           1,
+				Name:           "Font",
+				Family:         2,
+				Charset:        3,
+				Color:          "Red",
+				Bold:           true,
+				Italic:         true,
+				Underline:      true,
+				UnderlineStyle: UnderlineDoubleAccounting,
+				Strike:         true,
+				VertAlign:      VertAlignSuperscript,
+				Scheme:         "minor",
 			},
 			Alignment: Alignment{
-				Horizontal:   "left",
-				Indent:       1,
-				ShrinkToFit:  true,
-				TextRotation: 90,
-				Vertical:     "top",
-				WrapText:     true,
+				Horizontal:     "left",
+				Indent:         1,
+				ShrinkToFit:    true,
+				TextRotation:   90,
+				Vertical:       "top",
+				WrapText:       true,
+				RelativeIndent: -1,
+				ReadingOrder:   ReadingOrderRightToLeft,
 			},
-			ApplyBorder:    true,
-			ApplyFill:      true,
-			ApplyFont:      true,
-			ApplyAlignment: true,
+			ApplyBorder:     true,
+			ApplyFill:       true,
+			ApplyFont:       true,
+			ApplyAlignment:  true,
+			ApplyProtection: true,
+			Protection:      NewProtection(false, true),
 		}
 		err := writeStyle(buf, &s)
 		c.Assert(err, qt.IsNil)
@@ -401,6 +421,8 @@ line!`)
 		c.Assert(s2.ApplyFill, qt.Equals, s.ApplyFill)
 		c.Assert(s2.ApplyFont, qt.Equals, s.ApplyFont)
 		c.Assert(s2.ApplyAlignment, qt.Equals, s.ApplyAlignment)
+		c.Assert(s2.ApplyProtection, qt.Equals, s.ApplyProtection)
+		c.Assert(s2.Protection, qt.DeepEquals, s.Protection)
 		_, err = readStyle(reader)
 		c.Assert(err, qt.Not(qt.IsNil))
 
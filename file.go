@@ -10,6 +10,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // File is a high level structure providing a slice of Sheet structs
@@ -24,10 +26,16 @@ type File struct {
 	Sheet                map[string]*Sheet
 	theme                *theme
 	DefinedNames         []*xlsxDefinedName
+	Properties           Properties
+	readModified         time.Time
 	cellStoreConstructor CellStoreConstructor
 	rowLimit             int
 	colLimit             int
 	valueOnly            bool
+	defaultStyle         *Style
+	autoFitRows          bool
+	styleRegistry        *StyleRegistry
+	styleRegistryOnce    sync.Once
 }
 
 const NoRowLimit int = -1
@@ -61,6 +69,57 @@ func ValueOnly() FileOption {
 	}
 }
 
+// DefaultStyle sets the default font, fill, border and alignment of
+// the file.  They are used by File.NewStyle, by cells that are given
+// a style without one being set, and for the "Normal" cell style that
+// Excel shows unstyled cells in.  Without this option the package
+// level defaults, as set by SetDefaultFont, are used instead.
+func DefaultStyle(style *Style) FileOption {
+	return func(f *File) {
+		if style == nil {
+			f.defaultStyle = nil
+			return
+		}
+		defaultStyle := *style
+		defaultStyle.NamedStyle = ""
+		defaultStyle.NamedStyleIndex = nil
+		f.defaultStyle = &defaultStyle
+	}
+}
+
+// AutoFitRowsOnSave makes the File call Sheet.AutoFitRows on each of
+// its sheets whenever it is saved, so that rows holding wrapped or
+// multi-line text are tall enough to show it in spreadsheet programs
+// that don't fit rows themselves.
+func AutoFitRowsOnSave() FileOption {
+	return func(f *File) {
+		f.autoFitRows = true
+	}
+}
+
+// NewStyle returns a new Style initialised with the File's default
+// style, or with the package level defaults if it has none.
+func (f *File) NewStyle() *Style {
+	if f == nil || f.defaultStyle == nil {
+		return NewStyle()
+	}
+	return f.defaultStyle.clone()
+}
+
+// normalStyle returns the style of cell format 0, in which cells
+// without a style of their own are shown.  Until the File is saved or
+// read it has no style sheet, so the style is the one that saving
+// would write.
+func (f *File) normalStyle() *Style {
+	if f == nil {
+		return newXlsxStyleSheet(nil, nil).normalStyle()
+	}
+	if f.styles != nil && f.styles.CellXfs.Count > 0 {
+		return f.styles.getStyle(0)
+	}
+	return newXlsxStyleSheet(f.theme, f.defaultStyle).normalStyle()
+}
+
 // NewFile creates a new File struct. You may pass it zero, one or
 // many FileOption functions that affect the behaviour of the file.
 func NewFile(options ...FileOption) *File {
@@ -223,6 +282,18 @@ func (f *File) AddSheetWithCellStore(sheetName string, constructor CellStoreCons
 	return sheet, nil
 }
 
+// removeSheet takes sheet out of the File again and closes it.
+func (f *File) removeSheet(sheet *Sheet) {
+	delete(f.Sheet, sheet.Name)
+	for i, s := range f.Sheets {
+		if s == sheet {
+			f.Sheets = append(f.Sheets[:i], f.Sheets[i+1:]...)
+			break
+		}
+	}
+	sheet.Close()
+}
+
 // Appends an existing Sheet, with the provided name, to a File
 func (f *File) AppendSheet(sheet Sheet, sheetName string) (*Sheet, error) {
 	if _, exists := f.Sheet[sheetName]; exists {
@@ -331,6 +402,26 @@ func autoFilterDefinedName(sheet *Sheet, sheetIndex int) (*xlsxDefinedName, erro
 	}, nil
 }
 
+// sheetDefinedNames returns the defined names that are derived from
+// the properties of a sheet, rather than added with AddDefinedName.
+func sheetDefinedNames(sheet *Sheet, sheetIndex int) ([]xlsxDefinedName, error) {
+	var definedNames []xlsxDefinedName
+	definedName, err := autoFilterDefinedName(sheet, sheetIndex)
+	if err != nil {
+		return nil, err
+	}
+	for _, dn := range []*xlsxDefinedName{
+		definedName,
+		printAreaDefinedName(sheet, sheetIndex),
+		printTitlesDefinedName(sheet, sheetIndex),
+	} {
+		if dn != nil {
+			definedNames = append(definedNames, *dn)
+		}
+	}
+	return definedNames, nil
+}
+
 // MakeStreamParts constructs a map of file name to XML content
 // representing the file in terms of the structure of an XLSX file.
 func (f *File) MakeStreamParts() (map[string]string, error) {
@@ -354,8 +445,11 @@ func (f *File) MakeStreamParts() (map[string]string, error) {
 	workbook = f.makeWorkbook()
 	sheetIndex := 1
 
+	if err := f.autoFitRowsForSave(); err != nil {
+		return nil, err
+	}
 	if f.styles == nil {
-		f.styles = newXlsxStyleSheet(f.theme)
+		f.styles = newXlsxStyleSheet(f.theme, f.defaultStyle)
 	}
 	f.styles.reset()
 	if len(f.Sheets) == 0 {
@@ -406,12 +500,11 @@ func (f *File) MakeStreamParts() (map[string]string, error) {
 			}
 		}
 
-		definedName, err := autoFilterDefinedName(sheet, sheetIndex)
+		definedNames, err := sheetDefinedNames(sheet, sheetIndex)
 		if err != nil {
 			return parts, err
-		} else if definedName != nil {
-			workbook.DefinedNames.DefinedName = append(workbook.DefinedNames.DefinedName, *definedName)
 		}
+		workbook.DefinedNames.DefinedName = append(workbook.DefinedNames.DefinedName, definedNames...)
 
 		sheetIndex++
 	}
@@ -430,11 +523,17 @@ func (f *File) MakeStreamParts() (map[string]string, error) {
 		return parts, err
 	}
 
-	parts["_rels/.rels"] = TEMPLATE__RELS_DOT_RELS
-	parts["docProps/app.xml"] = TEMPLATE_DOCPROPS_APP
-	// TODO - do this properly, modification and revision information
-	parts["docProps/core.xml"] = TEMPLATE_DOCPROPS_CORE
-	parts["xl/theme/theme1.xml"] = TEMPLATE_XL_THEME_THEME
+	docProps, err := f.makeDocPropsParts(&types)
+	if err != nil {
+		return parts, err
+	}
+	for partName, part := range docProps {
+		parts[partName] = part
+	}
+	parts["xl/theme/theme1.xml"], err = f.makeThemeXML()
+	if err != nil {
+		return parts, err
+	}
 
 	xSST := refTable.makeXLSXSST()
 	parts["xl/sharedStrings.xml"], err = marshal(xSST)
@@ -500,8 +599,11 @@ func (f *File) MarshallParts(zipWriter *zip.Writer) error {
 	workbook = f.makeWorkbook()
 	sheetIndex := 1
 
+	if err := f.autoFitRowsForSave(); err != nil {
+		return wrap(err)
+	}
 	if f.styles == nil {
-		f.styles = newXlsxStyleSheet(f.theme)
+		f.styles = newXlsxStyleSheet(f.theme, f.defaultStyle)
 	}
 	f.styles.reset()
 	if len(f.Sheets) == 0 {
@@ -555,12 +657,11 @@ func (f *File) MarshallParts(zipWriter *zip.Writer) error {
 			}
 		}
 
-		definedName, err := autoFilterDefinedName(sheet, sheetIndex)
+		definedNames, err := sheetDefinedNames(sheet, sheetIndex)
 		if err != nil {
 			return wrap(err)
-		} else if definedName != nil {
-			workbook.DefinedNames.DefinedName = append(workbook.DefinedNames.DefinedName, *definedName)
 		}
+		workbook.DefinedNames.DefinedName = append(workbook.DefinedNames.DefinedName, definedNames...)
 
 		sheetIndex++
 	}
@@ -579,21 +680,25 @@ func (f *File) MarshallParts(zipWriter *zip.Writer) error {
 		return err
 	}
 
-	err = writePart("_rels/.rels", TEMPLATE__RELS_DOT_RELS)
+	docProps, err := f.makeDocPropsParts(&types)
 	if err != nil {
-		return err
+		return wrap(err)
 	}
-
-	err = writePart("docProps/app.xml", TEMPLATE_DOCPROPS_APP)
-	if err != nil {
-		return err
+	for _, partName := range []string{"_rels/.rels", "docProps/app.xml", "docProps/core.xml", "docProps/custom.xml"} {
+		part, ok := docProps[partName]
+		if !ok {
+			continue
+		}
+		err = writePart(partName, part)
+		if err != nil {
+			return err
+		}
 	}
-	// TODO - do this properly, modification and revision information
-	err = writePart("docProps/core.xml", TEMPLATE_DOCPROPS_CORE)
+	themeXML, err := f.makeThemeXML()
 	if err != nil {
-		return err
+		return wrap(err)
 	}
-	err = writePart("xl/theme/theme1.xml", TEMPLATE_XL_THEME_THEME)
+	err = writePart("xl/theme/theme1.xml", themeXML)
 	if err != nil {
 		return err
 	}
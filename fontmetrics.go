@@ -0,0 +1,198 @@
+package xlsx
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// fontMetrics holds the advance widths of the printable ASCII
+// characters, from space to tilde, of a typeface in thousandths of an
+// em.  They're close enough to measure text for sizing columns and
+// rows, without needing the fonts themselves.
+type fontMetrics struct {
+	widths [95]uint16
+	// boldScale approximates how much wider the bold face is.
+	boldScale float64
+	// lineHeight is the height Excel gives a row holding a line of
+	// text, in ems.
+	lineHeight float64
+}
+
+var calibriMetrics = fontMetrics{
+	widths: [95]uint16{
+		226, 326, 401, 498, 507, 715, 682, 221, 303, 303, 498, 498, 250, 306, 252, 386,
+		507, 507, 507, 507, 507, 507, 507, 507, 507, 507, 268, 268, 498, 498, 498, 463,
+		894, 579, 544, 533, 615, 488, 459, 631, 623, 252, 319, 520, 420, 855, 646, 662,
+		517, 673, 543, 459, 487, 642, 567, 890, 519, 487, 468, 307, 386, 307, 498, 498,
+		291, 479, 525, 423, 525, 498, 305, 471, 525, 229, 239, 455, 229, 799, 525, 527,
+		525, 525, 349, 391, 335, 525, 452, 715, 433, 453, 395, 314, 460, 314, 498,
+	},
+	boldScale:  1.04,
+	lineHeight: 1.36,
+}
+
+var arialMetrics = fontMetrics{
+	widths: [95]uint16{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	boldScale:  1.07,
+	lineHeight: 1.28,
+}
+
+var verdanaMetrics = fontMetrics{
+	widths: [95]uint16{
+		352, 394, 459, 818, 636, 1076, 727, 269, 454, 454, 636, 818, 364, 454, 364, 454,
+		636, 636, 636, 636, 636, 636, 636, 636, 636, 636, 454, 454, 818, 818, 818, 545,
+		1000, 684, 686, 698, 771, 632, 575, 775, 751, 421, 455, 693, 557, 843, 748, 787,
+		603, 787, 695, 684, 616, 732, 684, 989, 685, 615, 685, 454, 454, 454, 818, 636,
+		636, 601, 623, 521, 623, 596, 352, 623, 633, 274, 344, 592, 274, 973, 633, 607,
+		623, 623, 427, 521, 394, 633, 592, 818, 592, 592, 525, 635, 454, 635, 818,
+	},
+	boldScale:  1.14,
+	lineHeight: 1.25,
+}
+
+var timesNewRomanMetrics = fontMetrics{
+	widths: [95]uint16{
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	},
+	boldScale:  1.05,
+	lineHeight: 1.31,
+}
+
+// metricsForFont returns the width table for the named typeface, or
+// for a similar one.  Fonts we know nothing about are measured as
+// Calibri, Excel's default.
+func metricsForFont(name string) *fontMetrics {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "arial", "helvetica", "liberation sans", "arimo":
+		return &arialMetrics
+	case "verdana", "dejavu sans":
+		return &verdanaMetrics
+	case "times new roman", "times", "liberation serif", "tinos":
+		return &timesNewRomanMetrics
+	default:
+		return &calibriMetrics
+	}
+}
+
+// isFullWidth reports whether r is drawn a full em wide, as CJK
+// ideographs, kana, hangul and full-width forms are.
+func isFullWidth(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115F,
+		r >= 0x2E80 && r <= 0x303E,
+		r >= 0x3041 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7A3,
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFE30 && r <= 0xFE4F,
+		r >= 0xFF00 && r <= 0xFF60,
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x20000 && r <= 0x3FFFD:
+		return true
+	}
+	return false
+}
+
+// runeWidth returns the advance width of r in thousandths of an em.
+func (m *fontMetrics) runeWidth(r rune) float64 {
+	switch {
+	case r >= ' ' && r <= '~':
+		return float64(m.widths[r-' '])
+	case isFullWidth(r):
+		return 1000
+	case unicode.Is(unicode.Mn, r) || unicode.IsControl(r):
+		return 0
+	default:
+		// Other scripts are measured as a typical lower
+		// case letter.
+		return float64(m.widths['o'-' '])
+	}
+}
+
+// fontPixelSize returns the size of font in pixels at 96dpi.
+func fontPixelSize(font Font) float64 {
+	size := font.Size
+	if size <= 0 {
+		size = 11
+	}
+	return size * 96 / 72
+}
+
+// textWidth returns the width in pixels of a single line of text set
+// in font.
+func textWidth(text string, font Font) float64 {
+	m := metricsForFont(font.Name)
+	var units float64
+	for _, r := range text {
+		units += m.runeWidth(r)
+	}
+	width := units / 1000 * fontPixelSize(font)
+	if font.Bold {
+		width *= m.boldScale
+	}
+	return width
+}
+
+// lineHeight returns the height in points of a line of text set in
+// font.
+func lineHeight(font Font) float64 {
+	size := font.Size
+	if size <= 0 {
+		size = 11
+	}
+	return size * metricsForFont(font.Name).lineHeight
+}
+
+// maxDigitWidth returns the width in whole pixels of the widest digit
+// of font, the unit that Excel measures column widths in.
+func maxDigitWidth(font Font) float64 {
+	m := metricsForFont(font.Name)
+	var widest uint16
+	for d := '0'; d <= '9'; d++ {
+		if w := m.widths[d-' ']; w > widest {
+			widest = w
+		}
+	}
+	return math.Max(1, math.Round(float64(widest)/1000*fontPixelSize(font)))
+}
+
+// pixelsToColWidth converts a width in pixels to a column width, as
+// a number of characters of the maximum digit width mdw, allowing for
+// the padding Excel puts either side of the cell's content.
+func pixelsToColWidth(pixels, mdw float64) float64 {
+	return math.Trunc((pixels+5)/mdw*256) / 256
+}
+
+// colWidthToPixels converts a column width back to pixels of content.
+func colWidthToPixels(width, mdw float64) float64 {
+	return math.Max(0, width*mdw-5)
+}
+
+// normalFont returns the font of the File's "Normal" style, which
+// Excel measures column widths with.
+func (f *File) normalFont() Font {
+	if f != nil && f.defaultStyle != nil {
+		return f.defaultStyle.Font
+	}
+	if f != nil && f.styles != nil && len(f.styles.Fonts.Font) > 0 {
+		xFont := f.styles.Fonts.Font[0]
+		font := Font{Name: xFont.Name.Val}
+		font.Size, _ = strconv.ParseFloat(xFont.Sz.Val, 64)
+		return font
+	}
+	// This matches the first font written by xlsxStyleSheet.reset.
+	return Font{Size: 11, Name: "Arial"}
+}
@@ -0,0 +1,330 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// HeaderDecoder reads rows of a Sheet into structs, finding the column
+// for each field by the text of a header row rather than by its
+// position, so that inserting or reordering columns doesn't break the
+// mapping.  Fields are tagged with the header of their column:
+//
+//	type Contact struct {
+//		Email string `xlsx:"name=Email Address;alias=E-mail|Mail;required"`
+//		Name  string `xlsx:"name=Full Name"`
+//		Notes string `xlsx:"5"`
+//	}
+//
+// Headers are matched ignoring case and differences in white space,
+// and a field may give several aliases for its header.  Fields with a
+// positional tag, such as "5", read the cell at that index whatever
+// the header says, just as Row.ReadStruct does.
+type HeaderDecoder struct {
+	// DisallowUnknownColumns makes a header that no field maps to an
+	// error, rather than the column being ignored.
+	DisallowUnknownColumns bool
+
+	sheet     *Sheet
+	headerRow int
+	headers   []string       // The header of each column, as written
+	columns   map[string]int // Column index by normalized header
+	mapping   *headerMapping
+}
+
+// headerMapping is the mapping of the fields of a struct type to the
+// columns of a HeaderDecoder.
+type headerMapping struct {
+	typ     reflect.Type
+	fields  []headerField
+	missing []string
+	unknown []string
+}
+
+// headerField is a field, found by its index path, that is read from
+// the column col.  Its name is the path of field names, such as
+// "Address.City".
+type headerField struct {
+	index []int
+	name  string
+	col   int
+	tag   fieldTag
+}
+
+// HeaderError reports the ways in which the header row of a Sheet
+// doesn't match the fields of a struct.
+type HeaderError struct {
+	Missing []string // Headers of required fields not found in the header row
+	Unknown []string // Headers that no field maps to
+}
+
+func (e *HeaderError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing required columns %q", e.Missing))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("unknown columns %q", e.Unknown))
+	}
+	return "header mismatch: " + strings.Join(problems, ", ")
+}
+
+// NewHeaderDecoder returns a HeaderDecoder that maps fields by the
+// headers in the row headerRow, numbered from zero.
+func (s *Sheet) NewHeaderDecoder(headerRow int) (*HeaderDecoder, error) {
+	s.mustBeOpen()
+	if headerRow < 0 || headerRow >= s.MaxRow {
+		return nil, fmt.Errorf("NewHeaderDecoder: header row %d is outside the sheet", headerRow)
+	}
+	row, err := s.Row(headerRow)
+	if err != nil {
+		return nil, fmt.Errorf("NewHeaderDecoder: %w", err)
+	}
+	d := &HeaderDecoder{sheet: s, headerRow: headerRow, columns: make(map[string]int)}
+	err = row.ForEachCell(func(c *Cell) error {
+		header, err := c.FormattedValue()
+		if err != nil {
+			return err
+		}
+		col, _ := c.GetCoordinates()
+		for len(d.headers) <= col {
+			d.headers = append(d.headers, "")
+		}
+		d.headers[col] = header
+		key := normalizeHeader(header)
+		if _, exists := d.columns[key]; key != "" && !exists {
+			d.columns[key] = col
+		}
+		return nil
+	}, SkipEmptyCells)
+	if err != nil {
+		return nil, fmt.Errorf("NewHeaderDecoder: %w", err)
+	}
+	return d, nil
+}
+
+// HeaderRow returns the index of the header row, numbered from zero.
+func (d *HeaderDecoder) HeaderRow() int {
+	return d.headerRow
+}
+
+// Headers returns the text of the header of each column, with "" for
+// columns that have none.
+func (d *HeaderDecoder) Headers() []string {
+	return append([]string(nil), d.headers...)
+}
+
+// UnknownColumns returns the headers that none of the fields of the
+// struct that ptr points to map to.
+func (d *HeaderDecoder) UnknownColumns(ptr interface{}) ([]string, error) {
+	typ, err := structType(ptr)
+	if err != nil {
+		return nil, err
+	}
+	m, err := d.mappingFor(typ)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string(nil), m.unknown...), nil
+}
+
+// Check returns a *HeaderError if the header row lacks the column of a
+// required field of the struct that ptr points to, or, when
+// DisallowUnknownColumns is set, if it has a column that no field maps
+// to.  A malformed xlsx tag on one of the fields is also an error.
+func (d *HeaderDecoder) Check(ptr interface{}) error {
+	typ, err := structType(ptr)
+	if err != nil {
+		return err
+	}
+	m, err := d.mappingFor(typ)
+	if err != nil {
+		return err
+	}
+	return d.check(m)
+}
+
+func (d *HeaderDecoder) check(m *headerMapping) error {
+	headerErr := &HeaderError{Missing: m.missing}
+	if d.DisallowUnknownColumns {
+		headerErr.Unknown = m.unknown
+	}
+	if len(headerErr.Missing) == 0 && len(headerErr.Unknown) == 0 {
+		return nil
+	}
+	return headerErr
+}
+
+// Decode reads row into the struct that ptr points to.  Fields for
+// empty cells keep their values, unless their tags give a default or
+// make them required, which makes an empty cell an error.  The
+// first cell that can't be read is returned as a *CellError.  If ptr
+// implements XLSXUnmarshaler, it is left to unmarshal the row itself.
+func (d *HeaderDecoder) Decode(row *Row, ptr interface{}) error {
+	if ptr == nil {
+		return errNilInterface
+	}
+	if unmarshaller, ok := ptr.(XLSXUnmarshaler); ok {
+		return unmarshaller.Unmarshal(row)
+	}
+	typ, err := structType(ptr)
+	if err != nil {
+		return err
+	}
+	m, err := d.mappingFor(typ)
+	if err != nil {
+		return err
+	}
+	if err := d.check(m); err != nil {
+		return err
+	}
+	var cellErr *CellError
+	d.decode(row, reflect.ValueOf(ptr).Elem(), m, func(err *CellError) bool {
+		cellErr = err
+		return false
+	})
+	if cellErr != nil {
+		return cellErr
+	}
+	return nil
+}
+
+// decode reads row into the struct v using the mapping m, passing a
+// CellError for each cell that can't be read to report.  Decoding
+// stops early if report returns false.
+func (d *HeaderDecoder) decode(row *Row, v reflect.Value, m *headerMapping, report func(*CellError) bool) {
+	for _, field := range m.fields {
+		cell := row.GetCell(field.col)
+		if cell.Value == "" && !field.tag.hasDefault && !field.tag.required {
+			continue
+		}
+		fieldV := fieldByIndexAlloc(v, field.index)
+		if !fieldV.CanSet() {
+			continue
+		}
+		if err := row.readField(cell, fieldV, field.tag); err != nil {
+			cellErr := &CellError{
+				Cell:  GetCellIDStringFromCoords(field.col, row.num),
+				Field: field.name,
+				Value: cell.Value,
+				Err:   err,
+			}
+			if d.sheet != nil {
+				cellErr.Sheet = d.sheet.Name
+			}
+			if !report(cellErr) {
+				return
+			}
+		}
+	}
+}
+
+// structType returns the type of the struct that ptr points to.
+func structType(ptr interface{}) (reflect.Type, error) {
+	if ptr == nil {
+		return nil, errNilInterface
+	}
+	typ := reflect.TypeOf(ptr)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil, errNotStructPointer
+	}
+	return typ.Elem(), nil
+}
+
+// mappingFor returns the mapping of the fields of typ to columns,
+// reusing the last one if it was for the same type.
+func (d *HeaderDecoder) mappingFor(typ reflect.Type) (*headerMapping, error) {
+	if d.mapping != nil && d.mapping.typ == typ {
+		return d.mapping, nil
+	}
+	m := &headerMapping{typ: typ}
+	used := make(map[int]bool)
+	if err := d.mapFields(m, typ, nil, "", used); err != nil {
+		return nil, err
+	}
+	for col, header := range d.headers {
+		if header != "" && !used[col] {
+			m.unknown = append(m.unknown, header)
+		}
+	}
+	d.mapping = m
+	return m, nil
+}
+
+// mapFields adds the fields of typ, and of the structs within it, to
+// m.  Struct fields without a tag of their own are mapped field by
+// field, as Row.ReadStruct does.  It fails on the first field whose
+// tag can't be parsed.
+func (d *HeaderDecoder) mapFields(m *headerMapping, typ reflect.Type, path []int, prefix string, used map[int]bool) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name := prefix + field.Name
+		tag, err := parseFieldTag(field.Tag.Get("xlsx"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		if tag.skip {
+			continue
+		}
+		index := append(path[:len(path):len(path)], i)
+		if !tag.hasIndex() && tag.name == "" {
+			if isNestedStruct(field.Type) {
+				elem := field.Type
+				if elem.Kind() == reflect.Ptr {
+					elem = elem.Elem()
+				}
+				if err := d.mapFields(m, elem, index, name+".", used); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		col := tag.index
+		if !tag.hasIndex() {
+			col = d.findColumn(tag)
+			if col < 0 {
+				if tag.required {
+					m.missing = append(m.missing, tag.name)
+				}
+				continue
+			}
+		}
+		used[col] = true
+		m.fields = append(m.fields, headerField{index: index, name: name, col: col, tag: tag})
+	}
+	return nil
+}
+
+// findColumn returns the index of the column whose header matches the
+// name or one of the aliases of tag, or -1 if there is none.
+func (d *HeaderDecoder) findColumn(tag fieldTag) int {
+	for _, header := range tag.headers() {
+		if col, ok := d.columns[header]; ok {
+			return col
+		}
+	}
+	return -1
+}
+
+// fieldByIndexAlloc returns the field of v at index, like
+// reflect.Value.FieldByIndex, allocating any nil pointers to structs
+// on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
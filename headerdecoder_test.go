@@ -0,0 +1,167 @@
+package xlsx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseFieldTag(t *testing.T) {
+	c := qt.New(t)
+
+	tag, err := parseFieldTag("3")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.index, qt.Equals, 3)
+
+	tag, err = parseFieldTag("name= Email  Address ;alias=E-mail| Mail ;required")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.hasIndex(), qt.IsFalse)
+	c.Assert(tag.name, qt.Equals, "Email  Address")
+	c.Assert(tag.required, qt.IsTrue)
+	c.Assert(tag.headers(), qt.DeepEquals, []string{"email address", "e-mail", "mail"})
+
+	tag, err = parseFieldTag("-")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.skip, qt.IsTrue)
+
+	_, err = parseFieldTag("name=A;bogus")
+	c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue)
+	_, err = parseFieldTag("alias=A")
+	c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue)
+	_, err = parseFieldTag("x")
+	c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue)
+}
+
+type headerContact struct {
+	Email   string    `xlsx:"name=Email Address;alias=E-mail|Mail;required"`
+	Name    string    `xlsx:"name=full name"`
+	Age     *int      `xlsx:"name=Age"`
+	Joined  time.Time `xlsx:"name=Joined"`
+	Tags    []string  `xlsx:"name=Tags"`
+	Notes   string    `xlsx:"5"`
+	Ignored string    `xlsx:"-"`
+	Address *headerAddress
+}
+
+type headerAddress struct {
+	City string `xlsx:"name=City"`
+}
+
+func TestHeaderDecoder(t *testing.T) {
+	c := qt.New(t)
+
+	makeSheet := func(c *qt.C, option FileOption, headers ...string) *Sheet {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Contacts")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		for _, header := range headers {
+			row.AddCell().SetString(header)
+		}
+		return sheet
+	}
+
+	csRunO(c, "Decode", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, "Age", "  FULL\nName ", "e-mail", "Joined", "Tags", "Notes", "City", "Extra")
+		row := sheet.AddRow()
+		row.AddCell().SetInt(42)
+		row.AddCell().SetString("Ada Lovelace")
+		row.AddCell().SetString("ada@example.com")
+		joined := time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)
+		row.AddCell().SetDate(joined)
+		row.AddCell().SetString("a,b")
+		row.AddCell().SetString("a note")
+		row.AddCell().SetString("London")
+		row.AddCell().SetString("ignored")
+
+		d, err := sheet.NewHeaderDecoder(0)
+		c.Assert(err, qt.IsNil)
+		var contact headerContact
+		c.Assert(d.Decode(row, &contact), qt.IsNil)
+		c.Assert(contact.Email, qt.Equals, "ada@example.com")
+		c.Assert(contact.Name, qt.Equals, "Ada Lovelace")
+		c.Assert(*contact.Age, qt.Equals, 42)
+		c.Assert(contact.Joined, qt.Equals, joined)
+		c.Assert(contact.Tags, qt.DeepEquals, []string{"a", "b"})
+		c.Assert(contact.Notes, qt.Equals, "a note")
+		c.Assert(contact.Address.City, qt.Equals, "London")
+
+		unknown, err := d.UnknownColumns(&contact)
+		c.Assert(err, qt.IsNil)
+		c.Assert(unknown, qt.DeepEquals, []string{"Extra"})
+		c.Assert(d.Check(&contact), qt.IsNil)
+		d.DisallowUnknownColumns = true
+		err = d.Decode(row, &contact)
+		var headerErr *HeaderError
+		c.Assert(errors.As(err, &headerErr), qt.IsTrue)
+		c.Assert(headerErr.Unknown, qt.DeepEquals, []string{"Extra"})
+		c.Assert(err, qt.ErrorMatches, `header mismatch: unknown columns \["Extra"\]`)
+	})
+
+	csRunO(c, "MissingRequired", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, "Full Name", "Unexpected")
+		d, err := sheet.NewHeaderDecoder(0)
+		c.Assert(err, qt.IsNil)
+		d.DisallowUnknownColumns = true
+		err = d.Check(&headerContact{})
+		var headerErr *HeaderError
+		c.Assert(errors.As(err, &headerErr), qt.IsTrue)
+		c.Assert(headerErr.Missing, qt.DeepEquals, []string{"Email Address"})
+		c.Assert(headerErr.Unknown, qt.DeepEquals, []string{"Unexpected"})
+
+		_, err = sheet.NewHeaderDecoder(3)
+		c.Assert(err, qt.ErrorMatches, `NewHeaderDecoder: header row 3 is outside the sheet`)
+	})
+
+	csRunO(c, "BadValue", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, "Email Address", "Age")
+		row := sheet.AddRow()
+		row.AddCell().SetString("someone@example.com")
+		row.AddCell().SetString("old")
+		d, err := sheet.NewHeaderDecoder(0)
+		c.Assert(err, qt.IsNil)
+		err = d.Decode(row, &headerContact{})
+		var cellErr *CellError
+		c.Assert(errors.As(err, &cellErr), qt.IsTrue)
+		c.Assert(cellErr.Cell, qt.Equals, "B2")
+		c.Assert(cellErr.Field, qt.Equals, "Age")
+		c.Assert(err, qt.ErrorMatches, `Contacts!B2: field Age, value "old": .*`)
+	})
+
+	csRunO(c, "BadTag", func(c *qt.C, option FileOption) {
+		type misspelt struct {
+			Name  string `xlsx:"name=Full Name"`
+			Email string `xlsx:"nmae=Email"`
+		}
+		sheet := makeSheet(c, option, "Full Name", "Email")
+		row := sheet.AddRow()
+		row.AddCell().SetString("Ada Lovelace")
+		row.AddCell().SetString("ada@example.com")
+		d, err := sheet.NewHeaderDecoder(0)
+		c.Assert(err, qt.IsNil)
+
+		err = d.Check(&misspelt{})
+		c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue)
+		c.Assert(err, qt.ErrorMatches, `field Email: invalid tag: .*: unknown option "nmae=Email" in "nmae=Email"`)
+		_, err = d.UnknownColumns(&misspelt{})
+		c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue)
+		c.Assert(errors.Is(d.Decode(row, &misspelt{}), errInvalidTag), qt.IsTrue)
+		var all []misspelt
+		err = sheet.Unmarshal(&all, UnmarshalOptions{HeaderRows: 1})
+		c.Assert(err, qt.ErrorMatches, `Unmarshal: field Email: invalid tag: .*`)
+		c.Assert(all, qt.HasLen, 0)
+	})
+
+	csRunO(c, "ReadStructSkipsNamedFields", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, "x", "y", "z", "w", "v", "positional")
+		var contact headerContact
+		row, err := sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		contact.Address = &headerAddress{}
+		c.Assert(row.ReadStruct(&contact), qt.IsNil)
+		c.Assert(contact.Notes, qt.Equals, "positional")
+		c.Assert(contact.Email, qt.Equals, "")
+	})
+}
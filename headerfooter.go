@@ -0,0 +1,447 @@
+package xlsx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderFooterPartKind identifies what a HeaderFooterPart represents
+// within a section of a page header or footer.
+type HeaderFooterPartKind int
+
+// These are the kinds of part that Excel understands in a header or
+// footer.  Each of them, apart from HeaderFooterPartText, maps to one
+// of the "&" control codes described in section 18.3.1.39 of ECMA-376
+// (part 1, 4th edition).
+const (
+	HeaderFooterPartText            HeaderFooterPartKind = iota // Literal text
+	HeaderFooterPartPageNumber                                  // &P
+	HeaderFooterPartPageCount                                   // &N
+	HeaderFooterPartDate                                        // &D
+	HeaderFooterPartTime                                        // &T
+	HeaderFooterPartFileName                                    // &F
+	HeaderFooterPartFilePath                                    // &Z
+	HeaderFooterPartSheetName                                   // &A
+	HeaderFooterPartPicture                                     // &G
+	HeaderFooterPartFont                                        // &"name,style"
+	HeaderFooterPartFontSize                                    // &nn
+	HeaderFooterPartFontColor                                   // &Kxxxxxx
+	HeaderFooterPartBold                                        // &B
+	HeaderFooterPartItalic                                      // &I
+	HeaderFooterPartUnderline                                   // &U
+	HeaderFooterPartDoubleUnderline                             // &E
+	HeaderFooterPartStrikethrough                               // &S
+	HeaderFooterPartSuperscript                                 // &X
+	HeaderFooterPartSubscript                                   // &Y
+	HeaderFooterPartOutline                                     // &O
+	HeaderFooterPartShadow                                      // &H
+)
+
+// headerFooterCodes maps the single letter control codes to the kind
+// of part they represent.
+var headerFooterCodes = map[byte]HeaderFooterPartKind{
+	'P': HeaderFooterPartPageNumber,
+	'N': HeaderFooterPartPageCount,
+	'D': HeaderFooterPartDate,
+	'T': HeaderFooterPartTime,
+	'F': HeaderFooterPartFileName,
+	'Z': HeaderFooterPartFilePath,
+	'A': HeaderFooterPartSheetName,
+	'G': HeaderFooterPartPicture,
+	'B': HeaderFooterPartBold,
+	'I': HeaderFooterPartItalic,
+	'U': HeaderFooterPartUnderline,
+	'E': HeaderFooterPartDoubleUnderline,
+	'S': HeaderFooterPartStrikethrough,
+	'X': HeaderFooterPartSuperscript,
+	'Y': HeaderFooterPartSubscript,
+	'O': HeaderFooterPartOutline,
+	'H': HeaderFooterPartShadow,
+}
+
+var headerFooterCodesInv = make(map[HeaderFooterPartKind]byte, len(headerFooterCodes))
+
+func init() {
+	for k, v := range headerFooterCodes {
+		headerFooterCodesInv[v] = k
+	}
+}
+
+// HeaderFooterPart is a single element of a HeaderFooterSection.
+// Text holds the literal text of a HeaderFooterPartText, and the six
+// character colour (e.g. "FF0000") of a HeaderFooterPartFontColor.
+// FontName and FontStyle are only used by HeaderFooterPartFont, and
+// FontSize only by HeaderFooterPartFontSize.
+type HeaderFooterPart struct {
+	Kind      HeaderFooterPartKind
+	Text      string
+	FontName  string
+	FontStyle string
+	FontSize  int
+}
+
+// HeaderFooterSection is the ordered list of parts that make up the
+// left, center or right portion of a page header or footer.  Its
+// methods can be chained to build up a section, for example:
+//
+//	hf.OddFooter.Center.Text("Page ").PageNumber().Text(" of ").PageCount()
+type HeaderFooterSection struct {
+	Parts []HeaderFooterPart
+}
+
+func (s *HeaderFooterSection) add(part HeaderFooterPart) *HeaderFooterSection {
+	s.Parts = append(s.Parts, part)
+	return s
+}
+
+func (s *HeaderFooterSection) addKind(kind HeaderFooterPartKind) *HeaderFooterSection {
+	return s.add(HeaderFooterPart{Kind: kind})
+}
+
+// Text appends literal text to the section.
+func (s *HeaderFooterSection) Text(text string) *HeaderFooterSection {
+	return s.add(HeaderFooterPart{Kind: HeaderFooterPartText, Text: text})
+}
+
+// PageNumber appends the current page number to the section.
+func (s *HeaderFooterSection) PageNumber() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartPageNumber)
+}
+
+// PageCount appends the total number of pages to the section.
+func (s *HeaderFooterSection) PageCount() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartPageCount)
+}
+
+// Date appends the date of printing to the section.
+func (s *HeaderFooterSection) Date() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartDate)
+}
+
+// Time appends the time of printing to the section.
+func (s *HeaderFooterSection) Time() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartTime)
+}
+
+// FileName appends the name of the workbook file to the section.
+func (s *HeaderFooterSection) FileName() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartFileName)
+}
+
+// FilePath appends the path of the workbook file to the section.
+func (s *HeaderFooterSection) FilePath() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartFilePath)
+}
+
+// SheetName appends the name of the sheet being printed to the section.
+func (s *HeaderFooterSection) SheetName() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartSheetName)
+}
+
+// Font switches the font used for the rest of the section.  Pass an
+// empty name to keep the current font and only change its style
+// (e.g. "Bold", "Italic" or "Bold Italic").
+func (s *HeaderFooterSection) Font(name, style string) *HeaderFooterSection {
+	return s.add(HeaderFooterPart{Kind: HeaderFooterPartFont, FontName: name, FontStyle: style})
+}
+
+// FontSize switches the font size, in points, used for the rest of the section.
+func (s *HeaderFooterSection) FontSize(size int) *HeaderFooterSection {
+	return s.add(HeaderFooterPart{Kind: HeaderFooterPartFontSize, FontSize: size})
+}
+
+// FontColor switches the font colour used for the rest of the section.
+// The colour is given as six hexadecimal digits, "RRGGBB".
+func (s *HeaderFooterSection) FontColor(rgb string) *HeaderFooterSection {
+	return s.add(HeaderFooterPart{Kind: HeaderFooterPartFontColor, Text: rgb})
+}
+
+// Bold toggles bold text on or off.
+func (s *HeaderFooterSection) Bold() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartBold)
+}
+
+// Italic toggles italic text on or off.
+func (s *HeaderFooterSection) Italic() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartItalic)
+}
+
+// Underline toggles single underlining on or off.
+func (s *HeaderFooterSection) Underline() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartUnderline)
+}
+
+// DoubleUnderline toggles double underlining on or off.
+func (s *HeaderFooterSection) DoubleUnderline() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartDoubleUnderline)
+}
+
+// Strikethrough toggles struck through text on or off.
+func (s *HeaderFooterSection) Strikethrough() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartStrikethrough)
+}
+
+// Superscript toggles superscript text on or off.
+func (s *HeaderFooterSection) Superscript() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartSuperscript)
+}
+
+// Subscript toggles subscript text on or off.
+func (s *HeaderFooterSection) Subscript() *HeaderFooterSection {
+	return s.addKind(HeaderFooterPartSubscript)
+}
+
+// IsEmpty returns true if the section contains no parts.
+func (s *HeaderFooterSection) IsEmpty() bool {
+	return len(s.Parts) == 0
+}
+
+// String returns the section encoded with Excel's header and footer
+// control codes.
+func (s *HeaderFooterSection) String() string {
+	var sb strings.Builder
+	for i, part := range s.Parts {
+		switch part.Kind {
+		case HeaderFooterPartText:
+			sb.WriteString(strings.ReplaceAll(part.Text, "&", "&&"))
+		case HeaderFooterPartFont:
+			name := part.FontName
+			if name == "" {
+				name = "-"
+			}
+			style := part.FontStyle
+			if style == "" {
+				style = "Regular"
+			}
+			sb.WriteString(`&"` + name + "," + style + `"`)
+		case HeaderFooterPartFontSize:
+			sb.WriteString("&" + strconv.Itoa(part.FontSize))
+			// A size followed directly by digits would be read
+			// back as a larger size, so Excel separates them.
+			// Text that starts with a space gets the separator
+			// too, so that the one dropped on reading is ours.
+			if i+1 < len(s.Parts) {
+				next := s.Parts[i+1]
+				if next.Kind == HeaderFooterPartText && isFontSizeSeparated(next.Text) {
+					sb.WriteString(" ")
+				}
+			}
+		case HeaderFooterPartFontColor:
+			sb.WriteString("&K" + part.Text)
+		default:
+			if code, ok := headerFooterCodesInv[part.Kind]; ok {
+				sb.WriteByte('&')
+				sb.WriteByte(code)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// isFontSizeSeparated reports whether text that follows a font size is
+// separated from it by a space: text starting with a digit or a space.
+func isFontSizeSeparated(text string) bool {
+	return text != "" && (text[0] == ' ' || text[0] >= '0' && text[0] <= '9')
+}
+
+// HeaderFooterContent is a single page header or footer, split into
+// the left, center and right aligned sections that Excel supports.
+type HeaderFooterContent struct {
+	Left   HeaderFooterSection
+	Center HeaderFooterSection
+	Right  HeaderFooterSection
+}
+
+// IsEmpty returns true if none of the sections contain any parts.
+func (c *HeaderFooterContent) IsEmpty() bool {
+	return c.Left.IsEmpty() && c.Center.IsEmpty() && c.Right.IsEmpty()
+}
+
+// String returns the header or footer encoded with Excel's control
+// codes, as it is stored in the worksheet XML.
+func (c *HeaderFooterContent) String() string {
+	var sb strings.Builder
+	if !c.Left.IsEmpty() {
+		sb.WriteString("&L" + c.Left.String())
+	}
+	if !c.Center.IsEmpty() {
+		sb.WriteString("&C" + c.Center.String())
+	}
+	if !c.Right.IsEmpty() {
+		sb.WriteString("&R" + c.Right.String())
+	}
+	return sb.String()
+}
+
+// ParseHeaderFooterContent breaks down a header or footer string, such
+// as `&L&"Arial,Bold"&P of &N&RConfidential`, into its sections and
+// parts. Text that appears before any section code belongs to the
+// center section, as it does in Excel.  Unrecognised codes are kept
+// as literal text.
+func ParseHeaderFooterContent(s string) HeaderFooterContent {
+	content := HeaderFooterContent{}
+	section := &content.Center
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			section.Text(text.String())
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '&' || i+1 >= len(s) {
+			text.WriteByte(s[i])
+			continue
+		}
+		i++
+		c := s[i]
+		switch {
+		case c == '&':
+			text.WriteByte('&')
+		case c == 'L' || c == 'C' || c == 'R':
+			flush()
+			switch c {
+			case 'L':
+				section = &content.Left
+			case 'C':
+				section = &content.Center
+			case 'R':
+				section = &content.Right
+			}
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				text.WriteString(s[i-1:])
+				i = len(s)
+				continue
+			}
+			flush()
+			font := s[i+1 : i+1+end]
+			name, style, _ := strings.Cut(font, ",")
+			if name == "-" {
+				name = ""
+			}
+			section.Font(name, style)
+			i += end + 1
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+				end++
+			}
+			size, _ := strconv.Atoi(s[i:end])
+			flush()
+			section.FontSize(size)
+			// Skip the separator written between a size and
+			// the text after it.
+			if end+1 < len(s) && s[end] == ' ' && isFontSizeSeparated(s[end+1:]) {
+				end++
+			}
+			i = end - 1
+		case c == 'K' && i+6 < len(s):
+			flush()
+			section.FontColor(s[i+1 : i+7])
+			i += 6
+		default:
+			kind, ok := headerFooterCodes[c]
+			if !ok {
+				text.WriteByte('&')
+				text.WriteByte(c)
+				continue
+			}
+			flush()
+			section.addKind(kind)
+		}
+	}
+	flush()
+	return content
+}
+
+// HeaderFooter defines the headers and footers printed on each page of
+// a Sheet.  The OddHeader and OddFooter are used on every page unless
+// DifferentOddEven or DifferentFirst are set, in which case the
+// EvenHeader/EvenFooter and FirstHeader/FirstFooter apply to the
+// corresponding pages.
+type HeaderFooter struct {
+	DifferentFirst   bool
+	DifferentOddEven bool
+	ScaleWithDoc     *bool
+	AlignWithMargins *bool
+	OddHeader        HeaderFooterContent
+	OddFooter        HeaderFooterContent
+	EvenHeader       HeaderFooterContent
+	EvenFooter       HeaderFooterContent
+	FirstHeader      HeaderFooterContent
+	FirstFooter      HeaderFooterContent
+}
+
+// makeXLSXHeaderFooter converts the HeaderFooter into its XML
+// representation, returning nil if there's nothing to write.
+func (hf *HeaderFooter) makeXLSXHeaderFooter() *xlsxHeaderFooter {
+	if hf == nil {
+		return nil
+	}
+	xhf := &xlsxHeaderFooter{
+		ScaleWithDoc:     hf.ScaleWithDoc,
+		AlignWithMargins: hf.AlignWithMargins,
+	}
+	if hf.DifferentFirst {
+		xhf.DifferentFirst = bPtr(true)
+	}
+	if hf.DifferentOddEven {
+		xhf.DifferentOddEven = bPtr(true)
+	}
+	if !hf.OddHeader.IsEmpty() {
+		xhf.OddHeader = []xlsxOddHeader{{Content: hf.OddHeader.String()}}
+	}
+	if !hf.OddFooter.IsEmpty() {
+		xhf.OddFooter = []xlsxOddFooter{{Content: hf.OddFooter.String()}}
+	}
+	if !hf.EvenHeader.IsEmpty() {
+		xhf.EvenHeader = []xlsxEvenHeader{{Content: hf.EvenHeader.String()}}
+	}
+	if !hf.EvenFooter.IsEmpty() {
+		xhf.EvenFooter = []xlsxEvenFooter{{Content: hf.EvenFooter.String()}}
+	}
+	if !hf.FirstHeader.IsEmpty() {
+		xhf.FirstHeader = []xlsxFirstHeader{{Content: hf.FirstHeader.String()}}
+	}
+	if !hf.FirstFooter.IsEmpty() {
+		xhf.FirstFooter = []xlsxFirstFooter{{Content: hf.FirstFooter.String()}}
+	}
+	return xhf
+}
+
+// readHeaderFooter builds a HeaderFooter from the headerFooter element
+// of a worksheet, parsing each of the encoded header and footer strings.
+func readHeaderFooter(xhf *xlsxHeaderFooter) *HeaderFooter {
+	if xhf == nil {
+		return nil
+	}
+	hf := &HeaderFooter{
+		DifferentFirst:   xhf.DifferentFirst != nil && *xhf.DifferentFirst,
+		DifferentOddEven: xhf.DifferentOddEven != nil && *xhf.DifferentOddEven,
+		ScaleWithDoc:     xhf.ScaleWithDoc,
+		AlignWithMargins: xhf.AlignWithMargins,
+	}
+	if len(xhf.OddHeader) > 0 {
+		hf.OddHeader = ParseHeaderFooterContent(xhf.OddHeader[0].Content)
+	}
+	if len(xhf.OddFooter) > 0 {
+		hf.OddFooter = ParseHeaderFooterContent(xhf.OddFooter[0].Content)
+	}
+	if len(xhf.EvenHeader) > 0 {
+		hf.EvenHeader = ParseHeaderFooterContent(xhf.EvenHeader[0].Content)
+	}
+	if len(xhf.EvenFooter) > 0 {
+		hf.EvenFooter = ParseHeaderFooterContent(xhf.EvenFooter[0].Content)
+	}
+	if len(xhf.FirstHeader) > 0 {
+		hf.FirstHeader = ParseHeaderFooterContent(xhf.FirstHeader[0].Content)
+	}
+	if len(xhf.FirstFooter) > 0 {
+		hf.FirstFooter = ParseHeaderFooterContent(xhf.FirstFooter[0].Content)
+	}
+	return hf
+}
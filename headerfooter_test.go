@@ -0,0 +1,141 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHeaderFooterSection(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Builder", func(c *qt.C) {
+		content := HeaderFooterContent{}
+		content.Left.Font("Arial", "Bold").FontSize(14).Text("Report")
+		content.Center.Text("Page ").PageNumber().Text(" of ").PageCount()
+		content.Right.Date().Text(" & ").Time()
+		c.Assert(content.String(), qt.Equals,
+			`&L&"Arial,Bold"&14Report&CPage &P of &N&R&D && &T`)
+	})
+
+	c.Run("SizeFollowedByDigit", func(c *qt.C) {
+		s := &HeaderFooterSection{}
+		s.FontSize(12).Text("2021")
+		c.Assert(s.String(), qt.Equals, "&12 2021")
+	})
+
+	c.Run("SizeFollowedBySpace", func(c *qt.C) {
+		for _, text := range []string{" 3", " x", "3", "x"} {
+			s := &HeaderFooterSection{}
+			s.FontSize(12).Text(text)
+			content := ParseHeaderFooterContent("&L" + s.String())
+			c.Assert(content.Left.Parts, qt.DeepEquals, s.Parts, qt.Commentf("%q", s.String()))
+		}
+		s := &HeaderFooterSection{}
+		s.FontSize(12).Text(" 3")
+		c.Assert(s.String(), qt.Equals, "&12  3")
+	})
+
+	c.Run("Empty", func(c *qt.C) {
+		content := HeaderFooterContent{}
+		c.Assert(content.IsEmpty(), qt.IsTrue)
+		c.Assert(content.String(), qt.Equals, "")
+	})
+}
+
+func TestParseHeaderFooterContent(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Sections", func(c *qt.C) {
+		content := ParseHeaderFooterContent(`&L&"Arial,Bold"&P of &N&C&A&R&F`)
+		c.Assert(content.Left.Parts, qt.DeepEquals, []HeaderFooterPart{
+			{Kind: HeaderFooterPartFont, FontName: "Arial", FontStyle: "Bold"},
+			{Kind: HeaderFooterPartPageNumber},
+			{Kind: HeaderFooterPartText, Text: " of "},
+			{Kind: HeaderFooterPartPageCount},
+		})
+		c.Assert(content.Center.Parts, qt.DeepEquals, []HeaderFooterPart{
+			{Kind: HeaderFooterPartSheetName},
+		})
+		c.Assert(content.Right.Parts, qt.DeepEquals, []HeaderFooterPart{
+			{Kind: HeaderFooterPartFileName},
+		})
+	})
+
+	c.Run("DefaultsToCenter", func(c *qt.C) {
+		content := ParseHeaderFooterContent("Confidential && private")
+		c.Assert(content.Left.IsEmpty(), qt.IsTrue)
+		c.Assert(content.Center.Parts, qt.DeepEquals, []HeaderFooterPart{
+			{Kind: HeaderFooterPartText, Text: "Confidential & private"},
+		})
+	})
+
+	c.Run("SizeAndColour", func(c *qt.C) {
+		content := ParseHeaderFooterContent("&C&16 2021&KFF0000red")
+		c.Assert(content.Center.Parts, qt.DeepEquals, []HeaderFooterPart{
+			{Kind: HeaderFooterPartFontSize, FontSize: 16},
+			{Kind: HeaderFooterPartText, Text: "2021"},
+			{Kind: HeaderFooterPartFontColor, Text: "FF0000"},
+			{Kind: HeaderFooterPartText, Text: "red"},
+		})
+	})
+
+	c.Run("RoundTrip", func(c *qt.C) {
+		for _, s := range []string{
+			`&L&"Arial,Bold"&P of &N`,
+			`&CPage &P&R&D &T`,
+			`&L&B&I&UTitle&C&12 34&R&Z&F`,
+		} {
+			content := ParseHeaderFooterContent(s)
+			c.Assert(content.String(), qt.Equals, s)
+		}
+	})
+}
+
+func TestHeaderFooterRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "SaveAndRead", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+
+		hf := &HeaderFooter{DifferentFirst: true}
+		hf.OddHeader.Center.SheetName()
+		hf.OddFooter.Right.Text("Page ").PageNumber().Text(" of ").PageCount()
+		hf.FirstHeader.Left.Bold().Text("Cover")
+		sheet.HeaderFooter = hf
+
+		var buf bytes.Buffer
+		err = file.Write(&buf)
+		c.Assert(err, qt.IsNil)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		read := file.Sheets[0].HeaderFooter
+		c.Assert(read, qt.Not(qt.IsNil))
+		c.Assert(read.DifferentFirst, qt.IsTrue)
+		c.Assert(read.DifferentOddEven, qt.IsFalse)
+		c.Assert(read.OddHeader.String(), qt.Equals, "&C&A")
+		c.Assert(read.OddFooter.String(), qt.Equals, "&RPage &P of &N")
+		c.Assert(read.FirstHeader.String(), qt.Equals, "&L&BCover")
+		c.Assert(read.EvenHeader.IsEmpty(), qt.IsTrue)
+	})
+
+	csRunO(c, "NoHeaderFooter", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+
+		var buf bytes.Buffer
+		err = file.Write(&buf)
+		c.Assert(err, qt.IsNil)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Sheets[0].HeaderFooter, qt.IsNil)
+	})
+}
@@ -31,8 +31,11 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package xlsx
 
 import (
+	"fmt"
 	"image/color"
 	"math"
+	"strconv"
+	"strings"
 )
 
 // HSLModel converts any color.Color to a HSL color.
@@ -143,3 +146,27 @@ func hueToRGB(p, q, t float64) float64 {
 	}
 	return p
 }
+
+// applyTint lightens (for a positive tint) or darkens (for a negative
+// one) an "RRGGBB" or "AARRGGBB" colour the way Excel does, by scaling
+// its luminance.  The result is always "AARRGGBB", keeping any alpha
+// channel and defaulting it to opaque.  Colours that can't be parsed
+// are returned unchanged.
+func applyTint(argb string, tint float64) string {
+	alpha, rgb := "FF", argb
+	if len(argb) == 8 {
+		alpha, rgb = strings.ToUpper(argb[:2]), argb[2:]
+	}
+	v, err := strconv.ParseUint(rgb, 16, 32)
+	if err != nil || len(rgb) != 6 {
+		return argb
+	}
+	h, s, l := RGBToHSL(uint8(v>>16), uint8(v>>8), uint8(v))
+	if tint < 0 {
+		l *= 1 + tint
+	} else {
+		l = l*(1-tint) + tint
+	}
+	r, g, b := HSLToRGB(h, s, l)
+	return fmt.Sprintf("%s%02X%02X%02X", alpha, r, g, b)
+}
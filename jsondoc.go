@@ -0,0 +1,1026 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jsonDocumentVersion is the version of the document that ExportJSON
+// writes and ImportJSON reads.
+const jsonDocumentVersion = 1
+
+var errJSONVersion = errors.New("unsupported JSON document version")
+
+// JSONExportOptions control File.ExportJSON.
+type JSONExportOptions struct {
+	// Records exports each Sheet as an array of objects, one for each
+	// row after the first, keyed by the text of the first row's cells,
+	// rather than as a full document.
+	Records bool
+	// Sheet, if set, is the name of the only Sheet exported.  Records
+	// are then given as that Sheet's array alone, rather than in an
+	// object keyed by sheet name.
+	Sheet string
+	// Indent, if set, is the indentation of each level of the JSON.
+	Indent string
+}
+
+// jsonDocument is the full-fidelity form of a File that ExportJSON
+// writes.  Styles are shared by cells, which refer to them by index,
+// and may be based on one of the NamedStyles, which they refer to by
+// name.
+type jsonDocument struct {
+	Version     int              `json:"version"`
+	Date1904    bool             `json:"date1904,omitempty"`
+	NamedStyles []jsonNamedStyle `json:"namedStyles,omitempty"`
+	Styles      []*jsonStyle     `json:"styles,omitempty"`
+	Sheets      []jsonSheet      `json:"sheets"`
+}
+
+type jsonSheet struct {
+	Name     string    `json:"name"`
+	Hidden   bool      `json:"hidden,omitempty"`
+	Selected bool      `json:"selected,omitempty"`
+	Cols     []jsonCol `json:"cols,omitempty"`
+	Merges   []string  `json:"merges,omitempty"`
+	Rows     []jsonRow `json:"rows"`
+}
+
+type jsonCol struct {
+	Min    int      `json:"min"`
+	Max    int      `json:"max"`
+	Width  *float64 `json:"width,omitempty"`
+	Hidden bool     `json:"hidden,omitempty"`
+}
+
+// jsonRow is a row of a jsonSheet.  Row is the row number, counting
+// from 1 as in cell references.
+type jsonRow struct {
+	Row          int        `json:"row"`
+	Height       float64    `json:"height,omitempty"`
+	Hidden       bool       `json:"hidden,omitempty"`
+	OutlineLevel uint8      `json:"outlineLevel,omitempty"`
+	Cells        []jsonCell `json:"cells,omitempty"`
+}
+
+// jsonCell is a cell of a jsonRow.  Value is a number for numeric
+// cells, a boolean for boolean ones, and a string otherwise.  Time is
+// the value of a numeric cell with a date or time format, as RFC 3339
+// text, given for the reader's benefit and ignored by ImportJSON.
+type jsonCell struct {
+	Ref       string            `json:"ref"`
+	Type      string            `json:"type"`
+	Value     interface{}       `json:"value,omitempty"`
+	Time      string            `json:"time,omitempty"`
+	Formula   string            `json:"formula,omitempty"`
+	NumFmt    string            `json:"numFmt,omitempty"`
+	Style     *int              `json:"style,omitempty"`
+	RichText  []jsonRichTextRun `json:"richText,omitempty"`
+	Hyperlink *jsonHyperlink    `json:"hyperlink,omitempty"`
+}
+
+type jsonRichTextRun struct {
+	Text string        `json:"text"`
+	Font *jsonRichFont `json:"font,omitempty"`
+}
+
+type jsonRichFont struct {
+	Name      string             `json:"name,omitempty"`
+	Size      float64            `json:"size,omitempty"`
+	Family    RichTextFontFamily `json:"family,omitempty"`
+	Charset   RichTextCharset    `json:"charset,omitempty"`
+	Color     *jsonColor         `json:"color,omitempty"`
+	Bold      bool               `json:"bold,omitempty"`
+	Italic    bool               `json:"italic,omitempty"`
+	Strike    bool               `json:"strike,omitempty"`
+	VertAlign RichTextVertAlign  `json:"vertAlign,omitempty"`
+	Underline RichTextUnderline  `json:"underline,omitempty"`
+}
+
+// jsonColor is a colour as Excel stores it: given as ARGB, or as a
+// theme or indexed colour, or the automatic one, with an optional tint.
+type jsonColor struct {
+	RGB     string  `json:"rgb,omitempty"`
+	Theme   *int    `json:"theme,omitempty"`
+	Tint    float64 `json:"tint,omitempty"`
+	Indexed *int    `json:"indexed,omitempty"`
+	Auto    bool    `json:"auto,omitempty"`
+}
+
+// jsonNamedStyle is a named cell style, as defined by File.AddNamedStyle.
+type jsonNamedStyle struct {
+	Name  string    `json:"name"`
+	Style jsonStyle `json:"style"`
+}
+
+// jsonStyle is a Style.  Each colour is given by its ARGB value, with
+// a colorSpec alongside it when the colour is more than that, such as
+// a theme colour.  NamedStyle is the name of the named style that the
+// Style is based on.
+type jsonStyle struct {
+	Font            jsonFont        `json:"font"`
+	Fill            jsonFill        `json:"fill"`
+	Border          jsonBorder      `json:"border"`
+	Alignment       jsonAlignment   `json:"alignment"`
+	Protection      *jsonProtection `json:"protection,omitempty"`
+	ApplyFont       bool            `json:"applyFont,omitempty"`
+	ApplyFill       bool            `json:"applyFill,omitempty"`
+	ApplyBorder     bool            `json:"applyBorder,omitempty"`
+	ApplyAlignment  bool            `json:"applyAlignment,omitempty"`
+	ApplyProtection bool            `json:"applyProtection,omitempty"`
+	NamedStyle      string          `json:"namedStyle,omitempty"`
+}
+
+type jsonFont struct {
+	Size           float64    `json:"size,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	Family         int        `json:"family,omitempty"`
+	Charset        int        `json:"charset,omitempty"`
+	Color          string     `json:"color,omitempty"`
+	ColorSpec      *jsonColor `json:"colorSpec,omitempty"`
+	Bold           bool       `json:"bold,omitempty"`
+	Italic         bool       `json:"italic,omitempty"`
+	Underline      bool       `json:"underline,omitempty"`
+	UnderlineStyle string     `json:"underlineStyle,omitempty"`
+	Strike         bool       `json:"strike,omitempty"`
+	VertAlign      string     `json:"vertAlign,omitempty"`
+	Scheme         string     `json:"scheme,omitempty"`
+}
+
+type jsonFill struct {
+	PatternType string            `json:"patternType,omitempty"`
+	FgColor     string            `json:"fgColor,omitempty"`
+	FgColorSpec *jsonColor        `json:"fgColorSpec,omitempty"`
+	BgColor     string            `json:"bgColor,omitempty"`
+	BgColorSpec *jsonColor        `json:"bgColorSpec,omitempty"`
+	Gradient    *jsonGradientFill `json:"gradient,omitempty"`
+}
+
+type jsonGradientFill struct {
+	Type   string             `json:"type,omitempty"`
+	Degree float64            `json:"degree,omitempty"`
+	Left   float64            `json:"left,omitempty"`
+	Right  float64            `json:"right,omitempty"`
+	Top    float64            `json:"top,omitempty"`
+	Bottom float64            `json:"bottom,omitempty"`
+	Stops  []jsonGradientStop `json:"stops,omitempty"`
+}
+
+type jsonGradientStop struct {
+	Position  float64    `json:"position"`
+	Color     string     `json:"color,omitempty"`
+	ColorSpec *jsonColor `json:"colorSpec,omitempty"`
+}
+
+// jsonBorder gives each edge of a Border, leaving out the edges that
+// have neither a line style nor a colour.
+type jsonBorder struct {
+	Left         *jsonBorderEdge `json:"left,omitempty"`
+	Right        *jsonBorderEdge `json:"right,omitempty"`
+	Top          *jsonBorderEdge `json:"top,omitempty"`
+	Bottom       *jsonBorderEdge `json:"bottom,omitempty"`
+	Diagonal     *jsonBorderEdge `json:"diagonal,omitempty"`
+	DiagonalUp   bool            `json:"diagonalUp,omitempty"`
+	DiagonalDown bool            `json:"diagonalDown,omitempty"`
+}
+
+type jsonBorderEdge struct {
+	Style     string     `json:"style,omitempty"`
+	Color     string     `json:"color,omitempty"`
+	ColorSpec *jsonColor `json:"colorSpec,omitempty"`
+}
+
+// jsonAlignment and jsonProtection have the fields of Alignment and
+// Protection, so that they convert to and from them directly.
+type jsonAlignment struct {
+	Horizontal     string `json:"horizontal,omitempty"`
+	Indent         int    `json:"indent,omitempty"`
+	RelativeIndent int    `json:"relativeIndent,omitempty"`
+	ShrinkToFit    bool   `json:"shrinkToFit,omitempty"`
+	TextRotation   int    `json:"textRotation,omitempty"`
+	Vertical       string `json:"vertical,omitempty"`
+	WrapText       bool   `json:"wrapText,omitempty"`
+	ReadingOrder   int    `json:"readingOrder,omitempty"`
+}
+
+type jsonProtection struct {
+	Locked bool `json:"locked"`
+	Hidden bool `json:"hidden"`
+}
+
+type jsonHyperlink struct {
+	Link          string `json:"link,omitempty"`
+	Location      string `json:"location,omitempty"`
+	DisplayString string `json:"displayString,omitempty"`
+	Tooltip       string `json:"tooltip,omitempty"`
+}
+
+// jsonCellTypes names the CellTypes in a jsonCell.
+var jsonCellTypes = map[CellType]string{
+	CellTypeString:        "string",
+	CellTypeStringFormula: "stringFormula",
+	CellTypeNumeric:       "number",
+	CellTypeBool:          "bool",
+	CellTypeInline:        "inline",
+	CellTypeError:         "error",
+	CellTypeDate:          "date",
+}
+
+// MarshalJSON returns the File as a full JSON document, as given by
+// ExportJSON with the default options.
+func (f *File) MarshalJSON() ([]byte, error) {
+	return f.ExportJSON(JSONExportOptions{})
+}
+
+// ExportJSON returns the File as JSON, in one of two forms.
+//
+// By default it is a full document, from which ImportJSON rebuilds the
+// File: each Sheet with its columns' widths, merged ranges and rows,
+// and each cell with its type, typed value, formula, number format,
+// rich text, hyperlink, and the index of its style in the document's
+// list of distinct styles.  Empty cells are left out.  A style gives
+// its font, fill, border, alignment and protection, and the name of
+// the named style it is based on, if any; the File's named styles are
+// given too, so that ImportJSON can define them again.
+//
+// With Records set, each Sheet is an array of objects, one for each
+// row after the first, keyed by the text of the first row's cells in
+// column order.  Numbers and booleans are given as such, dates and
+// times as RFC 3339 text, and empty cells as null.  Empty rows are
+// left out.  The arrays are given in an object keyed by sheet name,
+// unless a single Sheet is asked for.
+func (f *File) ExportJSON(opts JSONExportOptions) ([]byte, error) {
+	sheets := f.Sheets
+	if opts.Sheet != "" {
+		sheet, ok := f.Sheet[opts.Sheet]
+		if !ok {
+			return nil, fmt.Errorf("ExportJSON: no sheet called %q", opts.Sheet)
+		}
+		sheets = []*Sheet{sheet}
+	}
+	var out []byte
+	var err error
+	if opts.Records {
+		out, err = exportRecords(sheets, opts.Sheet != "")
+		if err == nil && opts.Indent != "" {
+			var buf bytes.Buffer
+			if err = json.Indent(&buf, out, "", opts.Indent); err == nil {
+				out = buf.Bytes()
+			}
+		}
+	} else {
+		var doc *jsonDocument
+		doc, err = f.jsonDocument(sheets)
+		if err == nil {
+			if opts.Indent != "" {
+				out, err = json.MarshalIndent(doc, "", opts.Indent)
+			} else {
+				out, err = json.Marshal(doc)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ExportJSON: %w", err)
+	}
+	return out, nil
+}
+
+// jsonDocument builds the full document of the File's sheets.
+func (f *File) jsonDocument(sheets []*Sheet) (*jsonDocument, error) {
+	doc := &jsonDocument{
+		Version:  jsonDocumentVersion,
+		Date1904: f.Date1904,
+		Sheets:   make([]jsonSheet, 0, len(sheets)),
+	}
+	styles := make(map[string]int)
+	styleIndex := func(style *Style) (*int, error) {
+		if style == nil {
+			return nil, nil
+		}
+		key, err := styleKey(style)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := styles[key]
+		if !ok {
+			i = len(doc.Styles)
+			styles[key] = i
+			doc.Styles = append(doc.Styles, newJSONStyle(style))
+		}
+		return &i, nil
+	}
+	for _, name := range f.NamedStyles() {
+		style, _ := f.NamedStyle(name)
+		doc.NamedStyles = append(doc.NamedStyles, jsonNamedStyle{Name: name, Style: *newJSONStyle(style)})
+	}
+
+	for _, sheet := range sheets {
+		js := jsonSheet{
+			Name:     sheet.Name,
+			Hidden:   sheet.Hidden,
+			Selected: sheet.Selected,
+			Rows:     []jsonRow{},
+		}
+		if sheet.Cols != nil {
+			sheet.Cols.ForEach(func(_ int, col *Col) {
+				jc := jsonCol{Min: col.Min, Max: col.Max, Width: col.Width}
+				if col.Hidden != nil {
+					jc.Hidden = *col.Hidden
+				}
+				js.Cols = append(js.Cols, jc)
+			})
+		}
+		err := sheet.ForEachRow(func(row *Row) error {
+			jr := jsonRow{
+				Row:          row.num + 1,
+				Hidden:       row.Hidden,
+				OutlineLevel: row.outlineLevel,
+			}
+			if row.customHeight {
+				jr.Height = row.height
+			}
+			// Every cell is visited, as SkipEmptyCells passes over
+			// cells that hold nothing but a merge.
+			err := row.ForEachCell(func(c *Cell) error {
+				if isEmptyJSONCell(c) {
+					return nil
+				}
+				jc, err := newJSONCell(c, GetCellIDStringFromCoords(c.num, row.num))
+				if err != nil {
+					return err
+				}
+				if jc.Style, err = styleIndex(c.style); err != nil {
+					return err
+				}
+				jr.Cells = append(jr.Cells, jc)
+				if c.HMerge > 0 || c.VMerge > 0 {
+					end := GetCellIDStringFromCoords(c.num+c.HMerge, row.num+c.VMerge)
+					js.Merges = append(js.Merges, jc.Ref+":"+end)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(jr.Cells) > 0 || jr.Height != 0 || jr.Hidden || jr.OutlineLevel != 0 {
+				js.Rows = append(js.Rows, jr)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", sheet.Name, err)
+		}
+		doc.Sheets = append(doc.Sheets, js)
+	}
+	return doc, nil
+}
+
+// isEmptyJSONCell reports whether c holds nothing that the full
+// document would record.
+func isEmptyJSONCell(c *Cell) bool {
+	return c.Value == "" && c.formula == "" && len(c.RichText) == 0 &&
+		c.style == nil && isGeneralFormat(c.NumFmt) &&
+		c.Hyperlink == (Hyperlink{}) && c.HMerge == 0 && c.VMerge == 0
+}
+
+func isGeneralFormat(format string) bool {
+	return format == "" || format == builtInNumFmt[builtInNumFmtIndex_GENERAL]
+}
+
+// formatJSONTime formats the time of a date cell.  The serial number
+// of a time such as 08:00 rarely converts back to it exactly, so t is
+// rounded to the millisecond first.
+func formatJSONTime(t time.Time) string {
+	return t.Round(time.Millisecond).Format(time.RFC3339Nano)
+}
+
+// newJSONCell returns the jsonCell for c, whose reference is ref,
+// without its style.
+func newJSONCell(c *Cell, ref string) (jsonCell, error) {
+	jc := jsonCell{
+		Ref:     ref,
+		Type:    jsonCellTypes[c.cellType],
+		Formula: c.formula,
+	}
+	if !isGeneralFormat(c.NumFmt) {
+		jc.NumFmt = c.NumFmt
+	}
+	switch {
+	case c.Value == "":
+	case c.cellType == CellTypeNumeric && isJSONNumber(c.Value):
+		jc.Value = json.Number(c.Value)
+		if c.IsTime() {
+			t, err := c.GetTime(c.isDate1904())
+			if err != nil {
+				return jc, fmt.Errorf("cell %s: %w", jc.Ref, err)
+			}
+			jc.Time = formatJSONTime(t)
+		}
+	case c.cellType == CellTypeBool:
+		jc.Value = c.Value == "1"
+	default:
+		jc.Value = c.Value
+	}
+	for _, run := range c.RichText {
+		jc.RichText = append(jc.RichText, newJSONRichTextRun(run))
+	}
+	if h := c.Hyperlink; h != (Hyperlink{}) {
+		jc.Hyperlink = &jsonHyperlink{
+			Link:          h.Link,
+			Location:      h.Location,
+			DisplayString: h.DisplayString,
+			Tooltip:       h.Tooltip,
+		}
+	}
+	return jc, nil
+}
+
+// isJSONNumber reports whether s, the value of a numeric cell, is
+// written as a JSON number.
+func isJSONNumber(s string) bool {
+	return s != "" && (s[0] == '-' || s[0] >= '0' && s[0] <= '9') &&
+		strings.TrimSpace(s) == s && json.Valid([]byte(s))
+}
+
+func newJSONRichTextRun(run RichTextRun) jsonRichTextRun {
+	jr := jsonRichTextRun{Text: run.Text}
+	if font := run.Font; font != nil {
+		jr.Font = &jsonRichFont{
+			Name:      font.Name,
+			Size:      font.Size,
+			Family:    font.Family,
+			Charset:   font.Charset,
+			Bold:      font.Bold,
+			Italic:    font.Italic,
+			Strike:    font.Strike,
+			VertAlign: font.VertAlign,
+			Underline: font.Underline,
+		}
+		if color := font.Color; color != nil {
+			jr.Font.Color = newJSONColor(color.coreColor)
+		}
+	}
+	return jr
+}
+
+func (jr jsonRichTextRun) richTextRun() RichTextRun {
+	run := RichTextRun{Text: jr.Text}
+	if font := jr.Font; font != nil {
+		run.Font = &RichTextFont{
+			Name:      font.Name,
+			Size:      font.Size,
+			Family:    font.Family,
+			Charset:   font.Charset,
+			Bold:      font.Bold,
+			Italic:    font.Italic,
+			Strike:    font.Strike,
+			VertAlign: font.VertAlign,
+			Underline: font.Underline,
+		}
+		if color := font.Color; color != nil {
+			run.Font.Color = &RichTextColor{coreColor: color.xlsx()}
+		}
+	}
+	return run
+}
+
+func newJSONColor(x xlsxColor) *jsonColor {
+	return &jsonColor{
+		RGB:     x.RGB,
+		Theme:   x.Theme,
+		Tint:    x.Tint,
+		Indexed: x.Indexed,
+		Auto:    x.Auto != nil && *x.Auto,
+	}
+}
+
+func (jc *jsonColor) xlsx() xlsxColor {
+	x := xlsxColor{RGB: jc.RGB, Theme: jc.Theme, Tint: jc.Tint, Indexed: jc.Indexed}
+	if jc.Auto {
+		x.Auto = bPtr(true)
+	}
+	return x
+}
+
+// newJSONColorSpec returns the jsonColor for the ColorSpec c of a
+// Style, or nil if it's the zero Color.
+func newJSONColorSpec(c Color) *jsonColor {
+	if c.IsZero() {
+		return nil
+	}
+	return newJSONColor(c.xlsx())
+}
+
+// colorSpec returns the Color that jc gives, or the zero Color if jc
+// is nil.
+func (jc *jsonColor) colorSpec() Color {
+	if jc == nil {
+		return Color{}
+	}
+	return colorFromXLSX(jc.xlsx())
+}
+
+// newJSONStyle returns the jsonStyle for style.  Its NamedStyleIndex,
+// which only means something within a single styles.xml, is left out;
+// the name of the named style is enough.
+func newJSONStyle(style *Style) *jsonStyle {
+	font, fill, border := style.Font, style.Fill, style.Border
+	js := &jsonStyle{
+		Font: jsonFont{
+			Size:           font.Size,
+			Name:           font.Name,
+			Family:         font.Family,
+			Charset:        font.Charset,
+			Color:          font.Color,
+			ColorSpec:      newJSONColorSpec(font.ColorSpec),
+			Bold:           font.Bold,
+			Italic:         font.Italic,
+			Underline:      font.Underline,
+			UnderlineStyle: font.UnderlineStyle,
+			Strike:         font.Strike,
+			VertAlign:      font.VertAlign,
+			Scheme:         font.Scheme,
+		},
+		Fill: jsonFill{
+			PatternType: fill.PatternType,
+			FgColor:     fill.FgColor,
+			FgColorSpec: newJSONColorSpec(fill.FgColorSpec),
+			BgColor:     fill.BgColor,
+			BgColorSpec: newJSONColorSpec(fill.BgColorSpec),
+		},
+		Border: jsonBorder{
+			Left:         newJSONBorderEdge(border.Left, border.LeftColor, border.LeftColorSpec),
+			Right:        newJSONBorderEdge(border.Right, border.RightColor, border.RightColorSpec),
+			Top:          newJSONBorderEdge(border.Top, border.TopColor, border.TopColorSpec),
+			Bottom:       newJSONBorderEdge(border.Bottom, border.BottomColor, border.BottomColorSpec),
+			Diagonal:     newJSONBorderEdge(border.Diagonal, border.DiagonalColor, border.DiagonalColorSpec),
+			DiagonalUp:   border.DiagonalUp,
+			DiagonalDown: border.DiagonalDown,
+		},
+		Alignment:       jsonAlignment(style.Alignment),
+		ApplyFont:       style.ApplyFont,
+		ApplyFill:       style.ApplyFill,
+		ApplyBorder:     style.ApplyBorder,
+		ApplyAlignment:  style.ApplyAlignment,
+		ApplyProtection: style.ApplyProtection,
+		NamedStyle:      style.NamedStyle,
+	}
+	if g := fill.Gradient; g != nil {
+		jg := &jsonGradientFill{
+			Type:   g.Type,
+			Degree: g.Degree,
+			Left:   g.Left,
+			Right:  g.Right,
+			Top:    g.Top,
+			Bottom: g.Bottom,
+		}
+		for _, stop := range g.Stops {
+			jg.Stops = append(jg.Stops, jsonGradientStop{
+				Position:  stop.Position,
+				Color:     stop.Color,
+				ColorSpec: newJSONColorSpec(stop.ColorSpec),
+			})
+		}
+		js.Fill.Gradient = jg
+	}
+	if style.Protection != nil {
+		protection := jsonProtection(*style.Protection)
+		js.Protection = &protection
+	}
+	return js
+}
+
+func newJSONBorderEdge(style, color string, spec Color) *jsonBorderEdge {
+	if style == "" && color == "" && spec.IsZero() {
+		return nil
+	}
+	return &jsonBorderEdge{Style: style, Color: color, ColorSpec: newJSONColorSpec(spec)}
+}
+
+// edge returns the line style and colours of the edge e, which may be
+// nil.
+func (e *jsonBorderEdge) edge() (style, color string, spec Color) {
+	if e == nil {
+		return "", "", Color{}
+	}
+	return e.Style, e.Color, e.ColorSpec.colorSpec()
+}
+
+// style returns the Style that js describes.
+func (js *jsonStyle) style() *Style {
+	font, fill, border := js.Font, js.Fill, js.Border
+	style := &Style{
+		Font: Font{
+			Size:           font.Size,
+			Name:           font.Name,
+			Family:         font.Family,
+			Charset:        font.Charset,
+			Color:          font.Color,
+			ColorSpec:      font.ColorSpec.colorSpec(),
+			Bold:           font.Bold,
+			Italic:         font.Italic,
+			Underline:      font.Underline,
+			UnderlineStyle: font.UnderlineStyle,
+			Strike:         font.Strike,
+			VertAlign:      font.VertAlign,
+			Scheme:         font.Scheme,
+		},
+		Fill: Fill{
+			PatternType: fill.PatternType,
+			FgColor:     fill.FgColor,
+			FgColorSpec: fill.FgColorSpec.colorSpec(),
+			BgColor:     fill.BgColor,
+			BgColorSpec: fill.BgColorSpec.colorSpec(),
+		},
+		Border: Border{
+			DiagonalUp:   border.DiagonalUp,
+			DiagonalDown: border.DiagonalDown,
+		},
+		Alignment:       Alignment(js.Alignment),
+		ApplyFont:       js.ApplyFont,
+		ApplyFill:       js.ApplyFill,
+		ApplyBorder:     js.ApplyBorder,
+		ApplyAlignment:  js.ApplyAlignment,
+		ApplyProtection: js.ApplyProtection,
+		NamedStyle:      js.NamedStyle,
+	}
+	b := &style.Border
+	b.Left, b.LeftColor, b.LeftColorSpec = border.Left.edge()
+	b.Right, b.RightColor, b.RightColorSpec = border.Right.edge()
+	b.Top, b.TopColor, b.TopColorSpec = border.Top.edge()
+	b.Bottom, b.BottomColor, b.BottomColorSpec = border.Bottom.edge()
+	b.Diagonal, b.DiagonalColor, b.DiagonalColorSpec = border.Diagonal.edge()
+	if jg := fill.Gradient; jg != nil {
+		g := &GradientFill{
+			Type:   jg.Type,
+			Degree: jg.Degree,
+			Left:   jg.Left,
+			Right:  jg.Right,
+			Top:    jg.Top,
+			Bottom: jg.Bottom,
+		}
+		for _, stop := range jg.Stops {
+			g.Stops = append(g.Stops, GradientStop{
+				Position:  stop.Position,
+				Color:     stop.Color,
+				ColorSpec: stop.ColorSpec.colorSpec(),
+			})
+		}
+		style.Fill.Gradient = g
+	}
+	if js.Protection != nil {
+		protection := Protection(*js.Protection)
+		style.Protection = &protection
+	}
+	return style
+}
+
+// exportRecords returns the records of sheets, as an object keyed by
+// sheet name, or as the records of the only Sheet if single is set.
+func exportRecords(sheets []*Sheet, single bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if !single {
+		buf.WriteByte('{')
+	}
+	for i, sheet := range sheets {
+		if !single {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(sheet.Name)
+			buf.Write(name)
+			buf.WriteByte(':')
+		}
+		if err := writeSheetRecords(&buf, sheet); err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", sheet.Name, err)
+		}
+	}
+	if !single {
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
+// writeSheetRecords writes the rows of sheet after the first to buf as
+// an array of objects keyed by the first row's text.  The objects are
+// written by hand, as a map would lose the order of the columns.
+func writeSheetRecords(buf *bytes.Buffer, sheet *Sheet) error {
+	var keys [][]byte
+	values := make([]interface{}, sheet.MaxCol)
+	buf.WriteByte('[')
+	count := 0
+	err := sheet.ForEachRow(func(row *Row) error {
+		if keys == nil {
+			var err error
+			keys, err = recordKeys(row)
+			return err
+		}
+		for i := range values {
+			values[i] = nil
+		}
+		empty := true
+		err := row.ForEachCell(func(c *Cell) error {
+			if c.num >= len(values) {
+				return nil
+			}
+			v, err := recordValue(c)
+			if err != nil {
+				return fmt.Errorf("cell %s: %w", GetCellIDStringFromCoords(c.num, row.num), err)
+			}
+			values[c.num] = v
+			empty = empty && v == nil
+			return nil
+		}, SkipEmptyCells)
+		if err != nil || empty {
+			return err
+		}
+		if count > 0 {
+			buf.WriteByte(',')
+		}
+		count++
+		buf.WriteByte('{')
+		for i, v := range values {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(keys[i])
+			buf.WriteByte(':')
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// recordKeys returns the keys, encoded as JSON, of the records of the
+// Sheet whose first row is header.  Columns without a heading are
+// keyed by their letters, and repeated headings are numbered from the
+// second on, as in "Total (2)".
+func recordKeys(header *Row) ([][]byte, error) {
+	names := make([]string, header.Sheet.MaxCol)
+	err := header.ForEachCell(func(c *Cell) error {
+		if c.num >= len(names) {
+			return nil
+		}
+		text, err := c.FormattedValue()
+		if err != nil {
+			return err
+		}
+		names[c.num] = strings.TrimSpace(text)
+		return nil
+	}, SkipEmptyCells)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]int, len(names))
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		if name == "" {
+			name = ColIndexToLetters(i)
+		}
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name += " (" + strconv.Itoa(n) + ")"
+		}
+		if keys[i], err = json.Marshal(name); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// recordValue returns the value of c in a record, or nil if it is
+// empty.
+func recordValue(c *Cell) (interface{}, error) {
+	if len(c.RichText) > 0 {
+		var text strings.Builder
+		for _, run := range c.RichText {
+			text.WriteString(run.Text)
+		}
+		return text.String(), nil
+	}
+	if c.Value == "" {
+		return nil, nil
+	}
+	switch c.cellType {
+	case CellTypeNumeric:
+		if !isJSONNumber(c.Value) {
+			return c.Value, nil
+		}
+		if c.IsTime() {
+			t, err := c.GetTime(c.isDate1904())
+			if err != nil {
+				return nil, err
+			}
+			return formatJSONTime(t), nil
+		}
+		return json.Number(c.Value), nil
+	case CellTypeBool:
+		return c.Value == "1", nil
+	}
+	return c.Value, nil
+}
+
+// ImportJSON rebuilds a File from a full document written by
+// File.ExportJSON.  The options are those given to NewFile, such as
+// UseDiskVCellStore.  The named styles are defined again with
+// File.AddNamedStyle, and the cells' styles are registered with the
+// File's StyleRegistry, so cells that shared a style share it again.
+func ImportJSON(data []byte, options ...FileOption) (*File, error) {
+	wrap := func(err error) (*File, error) {
+		return nil, fmt.Errorf("ImportJSON: %w", err)
+	}
+	var doc jsonDocument
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return wrap(err)
+	}
+	if doc.Version != jsonDocumentVersion {
+		return wrap(fmt.Errorf("%w: %d", errJSONVersion, doc.Version))
+	}
+	f := NewFile(options...)
+	f.Date1904 = doc.Date1904
+	for _, ns := range doc.NamedStyles {
+		if err := f.AddNamedStyle(ns.Name, ns.Style.style()); err != nil {
+			return wrap(err)
+		}
+	}
+	ids := make([]StyleID, len(doc.Styles))
+	for i, style := range doc.Styles {
+		if style == nil {
+			return wrap(fmt.Errorf("style %d is null", i))
+		}
+		id, err := f.Styles().Register(style.style())
+		if err != nil {
+			return wrap(err)
+		}
+		ids[i] = id
+	}
+	for _, js := range doc.Sheets {
+		if err := importJSONSheet(f, js, ids); err != nil {
+			return wrap(fmt.Errorf("sheet %q: %w", js.Name, err))
+		}
+	}
+	return f, nil
+}
+
+func importJSONSheet(f *File, js jsonSheet, ids []StyleID) error {
+	sheet, err := f.AddSheet(js.Name)
+	if err != nil {
+		return err
+	}
+	sheet.Hidden = js.Hidden
+	sheet.Selected = js.Selected
+	for _, jc := range js.Cols {
+		if jc.Min < 1 || jc.Max < jc.Min {
+			return fmt.Errorf("invalid column range %d:%d", jc.Min, jc.Max)
+		}
+		col := NewColForRange(jc.Min, jc.Max)
+		if jc.Width != nil {
+			col.SetWidth(*jc.Width)
+		}
+		if jc.Hidden {
+			hidden := true
+			col.Hidden = &hidden
+		}
+		sheet.SetColParameters(col)
+	}
+	for _, jr := range js.Rows {
+		if jr.Row < 1 || jr.Row > Excel2006MaxRowCount {
+			return fmt.Errorf("invalid row number %d", jr.Row)
+		}
+		row, err := sheet.Row(jr.Row - 1)
+		if err != nil {
+			return err
+		}
+		if jr.Height != 0 {
+			row.SetHeight(jr.Height)
+		}
+		if jr.Hidden {
+			row.SetHidden(true)
+		}
+		if jr.OutlineLevel != 0 {
+			row.SetOutlineLevel(jr.OutlineLevel)
+		}
+		for _, jc := range jr.Cells {
+			col, y, err := GetCoordsFromCellIDString(jc.Ref)
+			if err != nil {
+				return err
+			}
+			if y != row.num {
+				return fmt.Errorf("cell %s is not in row %d", jc.Ref, jr.Row)
+			}
+			cell := row.GetCell(col)
+			cell.Row = row
+			if col >= sheet.MaxCol {
+				sheet.MaxCol = col + 1
+			}
+			if err := jc.apply(cell, ids); err != nil {
+				return fmt.Errorf("cell %s: %w", jc.Ref, err)
+			}
+		}
+		// The row must be kept by the CellStore, as if its cells had
+		// been added with AddCell.
+		row.isCustom = true
+	}
+	for _, merge := range js.Merges {
+		from, to, _ := strings.Cut(merge, ":")
+		x1, y1, err := GetCoordsFromCellIDString(from)
+		if err != nil {
+			return err
+		}
+		x2, y2, err := GetCoordsFromCellIDString(to)
+		if err != nil {
+			return err
+		}
+		if x2 < x1 || y2 < y1 {
+			return fmt.Errorf("invalid merged range %q", merge)
+		}
+		cell, err := sheet.Cell(y1, x1)
+		if err != nil {
+			return err
+		}
+		cell.Merge(x2-x1, y2-y1)
+	}
+	return nil
+}
+
+// apply sets cell to the contents of jc, with the style registered as
+// ids[*jc.Style].
+func (jc jsonCell) apply(cell *Cell, ids []StyleID) error {
+	var cellType CellType
+	found := false
+	for t, name := range jsonCellTypes {
+		if name == jc.Type {
+			cellType, found = t, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown cell type %q", jc.Type)
+	}
+	var value string
+	switch v := jc.Value.(type) {
+	case nil:
+	case json.Number:
+		value = string(v)
+	case bool:
+		value = "0"
+		if v {
+			value = "1"
+		}
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("value of type %T is not allowed", v)
+	}
+	numFmt := jc.NumFmt
+	if numFmt == "" && cellType == CellTypeNumeric {
+		numFmt = builtInNumFmt[builtInNumFmtIndex_GENERAL]
+	}
+
+	cell.updatable()
+	cell.Value = value
+	cell.RichText = nil
+	for _, run := range jc.RichText {
+		cell.RichText = append(cell.RichText, run.richTextRun())
+	}
+	cell.formula = jc.Formula
+	cell.cellType = cellType
+	cell.NumFmt = numFmt
+	cell.parsedNumFmt = nil
+	if h := jc.Hyperlink; h != nil {
+		cell.Hyperlink = Hyperlink{
+			Link:          h.Link,
+			Location:      h.Location,
+			DisplayString: h.DisplayString,
+			Tooltip:       h.Tooltip,
+		}
+		if h.Link != "" {
+			cell.Row.Sheet.addRelation(RelationshipTypeHyperlink, h.Link, RelationshipTargetModeExternal)
+		}
+	}
+	cell.modified = true
+	if jc.Style != nil {
+		if *jc.Style < 0 || *jc.Style >= len(ids) {
+			return fmt.Errorf("no style %d", *jc.Style)
+		}
+		return cell.SetStyleID(ids[*jc.Style])
+	}
+	return nil
+}
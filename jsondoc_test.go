@@ -0,0 +1,279 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestIsJSONNumber(t *testing.T) {
+	c := qt.New(t)
+	for s, want := range map[string]bool{
+		"0": true, "-1.5": true, "1E-7": true, "12345678901234567890": true,
+		"": false, "NaN": false, "Inf": false, "+1": false, " 1": false, "1 ": false, ".5": false, "0x10": false,
+	} {
+		c.Assert(isJSONNumber(s), qt.Equals, want, qt.Commentf("%q", s))
+	}
+}
+
+// makeJSONTestFile returns a File exercising everything that the full
+// JSON document records.
+func makeJSONTestFile(c *qt.C, option FileOption) *File {
+	f := NewFile(option)
+	sheet, err := f.AddSheet("Data")
+	c.Assert(err, qt.IsNil)
+	sheet.SetColWidth(1, 2, 18.5)
+
+	bold := NewStyle()
+	bold.Font.Bold = true
+	bold.ApplyFont = true
+
+	header := sheet.AddRow()
+	for _, name := range []string{"Name", "Amount", "Due", "Paid", "Name"} {
+		cell := header.AddCell()
+		cell.SetString(name)
+		cell.SetStyle(bold)
+	}
+	header.SetHeight(24)
+
+	row := sheet.AddRow()
+	row.AddCell().SetString("Widget")
+	amount := row.AddCell()
+	amount.SetFloat(1234.5)
+	amount.SetFormat("#,##0.00")
+	row.AddCell().SetDateWithOptions(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), DateTimeOptions{
+		Location: timeLocationUTC, ExcelTimeFormat: "yyyy-mm-dd",
+	})
+	row.AddCell().SetBool(true)
+
+	sheet.AddRow()
+
+	row = sheet.AddRow()
+	row.AddCell().SetRichText([]RichTextRun{
+		{Text: "Big ", Font: &RichTextFont{Bold: true, Color: NewRichTextColorFromARGB(255, 255, 0, 0)}},
+		{Text: "deal"},
+	})
+	total := row.AddCell()
+	total.SetFormula("SUM(B2:B2)")
+	total.Value = "1234.5"
+	link := row.AddCell()
+	link.SetHyperlink("https://example.com/", "Example", "Go there")
+	row.AddCell().Merge(1, 0)
+	row.SetHidden(true)
+
+	hidden, err := f.AddSheet("Hidden")
+	c.Assert(err, qt.IsNil)
+	hidden.Hidden = true
+	hidden.AddRow()
+	hidden.AddRow()
+	row = hidden.AddRow()
+	row.AddCell()
+	cell := row.AddCell()
+	cell.SetStringFormula(`"a"&"b"`)
+	cell.Value = "ab"
+	cell.SetStyle(bold)
+	return f
+}
+
+func TestExportJSON(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "Document", func(c *qt.C, option FileOption) {
+		f := makeJSONTestFile(c, option)
+		out, err := json.Marshal(f)
+		c.Assert(err, qt.IsNil)
+
+		var doc jsonDocument
+		c.Assert(json.Unmarshal(out, &doc), qt.IsNil)
+		c.Assert(doc.Version, qt.Equals, 1)
+		c.Assert(doc.Styles, qt.HasLen, 1)
+		c.Assert(doc.Styles[0].Font.Bold, qt.IsTrue)
+		c.Assert(doc.Sheets, qt.HasLen, 2)
+
+		data := doc.Sheets[0]
+		c.Assert(data.Name, qt.Equals, "Data")
+		c.Assert(data.Selected, qt.IsTrue)
+		c.Assert(data.Cols, qt.HasLen, 1)
+		c.Assert(*data.Cols[0].Width, qt.Equals, 18.5)
+		c.Assert(data.Merges, qt.DeepEquals, []string{"D4:E4"})
+		c.Assert(data.Rows, qt.HasLen, 3)
+		c.Assert(data.Rows[0].Height, qt.Equals, 24.0)
+		c.Assert(*data.Rows[0].Cells[4].Style, qt.Equals, 0)
+
+		cells := data.Rows[1].Cells
+		c.Assert(cells[1].Type, qt.Equals, "number")
+		c.Assert(cells[1].Value, qt.Equals, 1234.5)
+		c.Assert(cells[1].NumFmt, qt.Equals, "#,##0.00")
+		c.Assert(cells[1].Style, qt.IsNil)
+		c.Assert(cells[2].Time, qt.Equals, "2025-03-04T00:00:00Z")
+		c.Assert(cells[3].Type, qt.Equals, "bool")
+		c.Assert(cells[3].Value, qt.Equals, true)
+
+		c.Assert(data.Rows[2].Row, qt.Equals, 4)
+		c.Assert(data.Rows[2].Hidden, qt.IsTrue)
+		cells = data.Rows[2].Cells
+		c.Assert(cells[0].RichText, qt.HasLen, 2)
+		c.Assert(cells[0].RichText[0].Font.Color.RGB, qt.Equals, "FFFF0000")
+		c.Assert(cells[1].Formula, qt.Equals, "SUM(B2:B2)")
+		c.Assert(cells[2].Hyperlink.Link, qt.Equals, "https://example.com/")
+		c.Assert(cells[3].Ref, qt.Equals, "D4")
+
+		c.Assert(doc.Sheets[1].Hidden, qt.IsTrue)
+		c.Assert(doc.Sheets[1].Rows[0].Cells, qt.DeepEquals, []jsonCell{{
+			Ref: "B3", Type: "stringFormula", Value: "ab", Formula: `"a"&"b"`, Style: iPtr(0),
+		}})
+	})
+
+	csRunO(c, "Records", func(c *qt.C, option FileOption) {
+		f := makeJSONTestFile(c, option)
+		out, err := f.ExportJSON(JSONExportOptions{Records: true})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(out), qt.Equals, `{"Data":[`+
+			`{"Name":"Widget","Amount":1234.5,"Due":"2025-03-04T00:00:00Z","Paid":true,"Name (2)":null},`+
+			`{"Name":"Big deal","Amount":1234.5,"Due":"Example","Paid":null,"Name (2)":null}],`+
+			`"Hidden":[{"A":null,"B":"ab"}]}`)
+
+		out, err = f.ExportJSON(JSONExportOptions{Records: true, Sheet: "Data", Indent: " "})
+		c.Assert(err, qt.IsNil)
+		var records []map[string]interface{}
+		c.Assert(json.Unmarshal(out, &records), qt.IsNil)
+		c.Assert(records, qt.HasLen, 2)
+		c.Assert(string(out[:4]), qt.Equals, "[\n {")
+
+		// Times are rounded to the millisecond, undoing the error of
+		// their serial numbers.
+		sheet, err := f.AddSheet("Times")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("When")
+		sheet.AddRow().AddCell().SetFloatWithFormat(45720+1.0/3, "yyyy-mm-dd hh:mm")
+		out, err = f.ExportJSON(JSONExportOptions{Records: true, Sheet: "Times"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(out), qt.Equals, `[{"When":"2025-03-04T08:00:00Z"}]`)
+		out, err = f.ExportJSON(JSONExportOptions{Sheet: "Times"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(out), qt.Contains, `"time":"2025-03-04T08:00:00Z"`)
+
+		_, err = f.ExportJSON(JSONExportOptions{Sheet: "Missing"})
+		c.Assert(err, qt.ErrorMatches, `ExportJSON: no sheet called "Missing"`)
+	})
+}
+
+func TestImportJSON(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "RoundTrip", func(c *qt.C, option FileOption) {
+		out, err := makeJSONTestFile(c, option).ExportJSON(JSONExportOptions{Indent: "  "})
+		c.Assert(err, qt.IsNil)
+
+		f, err := ImportJSON(out, option)
+		c.Assert(err, qt.IsNil)
+		again, err := f.ExportJSON(JSONExportOptions{Indent: "  "})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(again), qt.Equals, string(out))
+
+		sheet := f.Sheet["Data"]
+		c.Assert(sheet.MaxRow, qt.Equals, 4)
+		c.Assert(sheet.MaxCol, qt.Equals, 5)
+		cell, err := sheet.Cell(1, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Type(), qt.Equals, CellTypeNumeric)
+		c.Assert(cell.Value, qt.Equals, "1234.5")
+		c.Assert(cell.NumFmt, qt.Equals, "#,##0.00")
+		cell, err = sheet.Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.StyleID(), qt.Not(qt.Equals), StyleID(0))
+		c.Assert(cell.GetStyle().Font.Bold, qt.IsTrue)
+		c.Assert(sheet.Relations, qt.HasLen, 1)
+		cell, err = sheet.Cell(3, 3)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.HMerge, qt.Equals, 1)
+
+		// The rebuilt File saves and reopens like any other.
+		path := c.TempDir() + "/imported.xlsx"
+		c.Assert(f.Save(path), qt.IsNil)
+		reopened, err := OpenFile(path)
+		c.Assert(err, qt.IsNil)
+		cell, err = reopened.Sheet["Data"].Cell(3, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Formula(), qt.Equals, "SUM(B2:B2)")
+		c.Assert(reopened.Sheet["Hidden"].Hidden, qt.IsTrue)
+	})
+
+	csRunO(c, "Styles", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		heading := NewStyle()
+		heading.Font.Size = 15
+		heading.Font.ColorSpec = NewThemeColor(3, 0)
+		heading.ApplyFont = true
+		c.Assert(f.AddNamedStyle("Heading 1", heading), qt.IsNil)
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		title := row.AddCell()
+		title.SetString("Title")
+		c.Assert(title.SetNamedStyle("Heading 1"), qt.IsNil)
+
+		style := NewStyle()
+		style.Fill.Gradient = &GradientFill{Degree: 90, Stops: []GradientStop{
+			{Position: 0, ColorSpec: NewThemeColor(4, 0.5)},
+			{Position: 1, Color: "FFFFFFFF"},
+		}}
+		style.Border.Bottom = "thin"
+		style.Border.BottomColorSpec = NewAutoColor()
+		style.Alignment.WrapText = true
+		style.Protection = &Protection{Locked: false, Hidden: true}
+		style.ApplyFill, style.ApplyBorder, style.ApplyAlignment, style.ApplyProtection = true, true, true, true
+		row.AddCell().SetStyle(style)
+		row.GetCell(1).SetString("Body")
+
+		out, err := f.ExportJSON(JSONExportOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(out), qt.Contains, `"namedStyles":[{"name":"Heading 1","style":{"font":{"size":15,"name":"Verdana","colorSpec":{"theme":3}}`)
+		c.Assert(string(out), qt.Contains, `"bottom":{"style":"thin","colorSpec":{"auto":true}}`)
+		c.Assert(string(out), qt.Contains, `"protection":{"locked":false,"hidden":true}`)
+
+		f, err = ImportJSON(out, option)
+		c.Assert(err, qt.IsNil)
+		named, ok := f.NamedStyle("Heading 1")
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(named.Font.ColorSpec, qt.Equals, NewThemeColor(3, 0))
+		cell, err := f.Sheet["Sheet1"].Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.NamedStyle(), qt.Equals, "Heading 1")
+		cell, err = f.Sheet["Sheet1"].Cell(0, 1)
+		c.Assert(err, qt.IsNil)
+		got := cell.GetStyle()
+		c.Assert(got.Fill.Gradient, qt.DeepEquals, style.Fill.Gradient)
+		c.Assert(got.Border.BottomColorSpec, qt.Equals, NewAutoColor())
+		c.Assert(got.Alignment, qt.Equals, style.Alignment)
+		c.Assert(*got.Protection, qt.Equals, *style.Protection)
+
+		again, err := f.ExportJSON(JSONExportOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(again), qt.Equals, string(out))
+	})
+
+	c.Run("Errors", func(c *qt.C) {
+		for _, test := range []struct {
+			doc, err string
+		}{
+			{`{"version":2,"sheets":[]}`, `ImportJSON: unsupported JSON document version: 2`},
+			{`{"version":1,"sheets":[{"name":"S","rows":[{"row":1,"cells":[{"ref":"A1","type":"blob"}]}]}]}`,
+				`ImportJSON: sheet "S": cell A1: unknown cell type "blob"`},
+			{`{"version":1,"sheets":[{"name":"S","rows":[{"row":1,"cells":[{"ref":"A2","type":"string"}]}]}]}`,
+				`ImportJSON: sheet "S": cell A2 is not in row 1`},
+			{`{"version":1,"sheets":[{"name":"S","rows":[{"row":1,"cells":[{"ref":"A1","type":"string","style":0}]}]}]}`,
+				`ImportJSON: sheet "S": cell A1: no style 0`},
+			{`{"version":1,"sheets":[{"name":"S","cols":[{"min":0,"max":1}],"rows":[]}]}`,
+				`ImportJSON: sheet "S": invalid column range 0:1`},
+		} {
+			_, err := ImportJSON([]byte(test.doc))
+			c.Assert(err, qt.ErrorMatches, test.err)
+		}
+		_, err := ImportJSON([]byte(`{"version":2}`))
+		c.Assert(errors.Is(err, errJSONVersion), qt.IsTrue)
+	})
+}
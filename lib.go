@@ -539,12 +539,17 @@ func readRowsFromSheet(Worksheet *xlsxWorksheet, file *File, sheet *Sheet, rowLi
 		row.num = rawrow.R - 1
 
 		row.Hidden = rawrow.Hidden
+		row.collapsed = rawrow.Collapsed
 		height, err := strconv.ParseFloat(rawrow.Ht, 64)
 		if err == nil {
 			row.SetHeight(height)
 		}
 		row.isCustom = rawrow.CustomHeight
 		row.SetOutlineLevel(rawrow.OutlineLevel)
+		if rawrow.CustomFormat && file.styles != nil {
+			row.style = file.styles.getStyle(rawrow.S)
+			row.numFmt, _ = file.styles.getNumberFormat(rawrow.S)
+		}
 
 		for _, rawcell := range rawrow.C {
 			if rawcell.R == "" {
@@ -587,6 +592,11 @@ func readRowsFromSheet(Worksheet *xlsxWorksheet, file *File, sheet *Sheet, rowLi
 			cell.modified = true
 		}
 		sheet.cellStore.WriteRow(row)
+		// Rows that are hidden or sized, but have no cells, lie
+		// outside the sheet's dimension.
+		if rawrow.R > rowCount {
+			rowCount = rawrow.R
+		}
 
 		insertRowIndex++
 	}
@@ -627,6 +637,20 @@ func readSheetViews(xSheetViews xlsxSheetViews) []SheetView {
 			pane.State = xlsxPane.State
 			sheetView.Pane = pane
 		}
+		sheetView.ZoomScale = int(xSheetView.ZoomScale)
+		sheetView.HideGridLines = !xSheetView.ShowGridLines
+		sheetView.HideRowColHeaders = !xSheetView.ShowRowColHeaders
+		sheetView.HideZeros = !xSheetView.ShowZeros
+		sheetView.RightToLeft = xSheetView.RightToLeft
+		sheetView.View = xSheetView.View
+		sheetView.TopLeftCell = xSheetView.TopLeftCell
+		for _, xSelection := range xSheetView.Selection {
+			sheetView.Selections = append(sheetView.Selections, Selection{
+				Pane:       xSelection.Pane,
+				ActiveCell: xSelection.ActiveCell,
+				SQRef:      xSelection.SQRef,
+			})
+		}
 		sheetViews = append(sheetViews, sheetView)
 	}
 	return sheetViews
@@ -773,11 +797,18 @@ func readSheetFromFile(rsheet xlsxSheet, fi *File, sheetXMLMap map[string]string
 
 		sheet.AutoFilter = &AutoFilter{autoFilterBounds[0], bottomRightCell}
 	}
+	sheet.HeaderFooter = readHeaderFooter(worksheet.HeaderFooter)
+	sheet.RowBreaks = readXLSXBreaks(worksheet.RowBreaks)
+	sheet.ColBreaks = readXLSXBreaks(worksheet.ColBreaks)
 
 	sheet.SheetFormat.DefaultColWidth = worksheet.SheetFormatPr.DefaultColWidth
 	sheet.SheetFormat.DefaultRowHeight = worksheet.SheetFormatPr.DefaultRowHeight
 	sheet.SheetFormat.OutlineLevelCol = worksheet.SheetFormatPr.OutlineLevelCol
 	sheet.SheetFormat.OutlineLevelRow = worksheet.SheetFormatPr.OutlineLevelRow
+	sheet.Outline = readOutlinePr(worksheet.SheetPr.OutlinePr)
+	if worksheet.SheetPr.TabColor != nil {
+		sheet.TabColor = colorFromXLSX(*worksheet.SheetPr.TabColor)
+	}
 	if nil != worksheet.DataValidations {
 		for _, dd := range worksheet.DataValidations.DataValidation {
 			sheet.AddDataValidation(dd)
@@ -816,10 +847,6 @@ func readSheetsFromZipFile(f *zip.File, file *File, sheetXMLMap map[string]strin
 	}
 	file.Date1904 = workbook.WorkbookPr.Date1904
 
-	for entryNum := range workbook.DefinedNames.DefinedName {
-		file.DefinedNames = append(file.DefinedNames, &workbook.DefinedNames.DefinedName[entryNum])
-	}
-
 	// Only try and read sheets that have corresponding files.
 	// Notably this excludes chartsheets don't right now
 	var workbookSheets []xlsxSheet
@@ -870,6 +897,20 @@ func readSheetsFromZipFile(f *zip.File, file *File, sheetXMLMap map[string]strin
 		}
 	}
 	close(sheetChan)
+
+	for entryNum := range workbook.DefinedNames.DefinedName {
+		dn := &workbook.DefinedNames.DefinedName[entryNum]
+		if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 && *dn.LocalSheetID < len(workbook.Sheets.Sheet) {
+			// Print areas and titles are exposed on the Sheet
+			// itself, and regenerated from there on save.
+			sheet := sheetsByName[workbook.Sheets.Sheet[*dn.LocalSheetID].Name]
+			if sheet != nil && readPrintDefinedName(dn, sheet) {
+				continue
+			}
+		}
+		file.DefinedNames = append(file.DefinedNames, dn)
+	}
+
 	if errFound {
 		err = errors.New(sb.String())
 	}
@@ -994,7 +1035,7 @@ func readSharedStringsFromZipFile(f *zip.File) (*RefTable, error) {
 // readStylesFromZipFile() is an internal helper function to
 // extract a style table from the style.xml file within
 // the XLSX zip file.
-func readStylesFromZipFile(f *zip.File, theme *theme) (*xlsxStyleSheet, error) {
+func readStylesFromZipFile(f *zip.File, theme *theme, defaultStyle *Style) (*xlsxStyleSheet, error) {
 	var style *xlsxStyleSheet
 	var err error
 	var rc io.ReadCloser
@@ -1010,13 +1051,14 @@ func readStylesFromZipFile(f *zip.File, theme *theme) (*xlsxStyleSheet, error) {
 	}
 	defer rc.Close()
 
-	style = newXlsxStyleSheet(theme)
+	style = newXlsxStyleSheet(theme, defaultStyle)
 	decoder = xml.NewDecoder(rc)
 	err = decoder.Decode(style)
 	if err != nil {
 		return wrap(err)
 	}
 	buildNumFmtRefTable(style)
+	style.readNamedStyles()
 	return style, nil
 }
 
@@ -1041,13 +1083,20 @@ func readThemeFromZipFile(f *zip.File) (*theme, error) {
 	}
 	defer rc.Close()
 
+	raw, err := io.ReadAll(rc)
+	if err != nil {
+		return wrap(err)
+	}
+
 	var themeXml xlsxTheme
-	err = xml.NewDecoder(rc).Decode(&themeXml)
+	err = xml.Unmarshal(raw, &themeXml)
 	if err != nil {
 		return wrap(err)
 	}
 
-	return newTheme(themeXml), nil
+	theme := newTheme(themeXml)
+	theme.raw = raw
+	return theme, nil
 }
 
 type WorkBookRels map[string]string
@@ -1153,6 +1202,7 @@ func ReadZipReader(r *zip.Reader, options ...FileOption) (*File, error) {
 	var style *xlsxStyleSheet
 	var styles *zip.File
 	var themeFile *zip.File
+	var coreProps, appProps, customProps *zip.File
 	var v *zip.File
 	var workbook *zip.File
 	var workbookRels *zip.File
@@ -1179,6 +1229,12 @@ func ReadZipReader(r *zip.Reader, options ...FileOption) (*File, error) {
 			styles = v
 		case `theme1.xml`:
 			themeFile = v
+		case `core.xml`:
+			coreProps = v
+		case `app.xml`:
+			appProps = v
+		case `custom.xml`:
+			customProps = v
 		default:
 			if len(v.Name) > 17 {
 				if v.Name[0:13] == "xl/worksheets" || v.Name[0:13] == `xl\worksheets` {
@@ -1217,13 +1273,15 @@ func ReadZipReader(r *zip.Reader, options ...FileOption) (*File, error) {
 		file.theme = theme
 	}
 	if styles != nil {
-		style, err = readStylesFromZipFile(styles, file.theme)
+		style, err = readStylesFromZipFile(styles, file.theme, file.defaultStyle)
 		if err != nil {
 			return wrap(err)
 		}
 
 		file.styles = style
 	}
+	file.Properties = readPropertiesFromZipFile(coreProps, appProps, customProps)
+	file.readModified = file.Properties.Core.Modified
 	sheetsByName, sheets, err = readSheetsFromZipFile(workbook, file, sheetXMLMap, file.rowLimit, file.colLimit, file.valueOnly)
 	if err != nil {
 		return wrap(err)
@@ -0,0 +1,256 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var errNotSlice = errors.New("argument must be a slice of structs or of pointers to structs, or a pointer to one")
+
+// MarshalOptions control Sheet.Marshal.
+type MarshalOptions struct {
+	// NoHeader leaves out the header row.
+	NoHeader bool
+	// HeaderStyle is the style of the header row's cells, if not nil.
+	HeaderStyle *Style
+	// AutoFilter turns on filtering of the written rows by the
+	// values in each column, using the header row.
+	AutoFilter bool
+	// FreezeHeader keeps the header row in view while the rest of the
+	// Sheet scrolls.
+	FreezeHeader bool
+}
+
+// marshalColumn is a field of a struct that Sheet.Marshal writes to
+// column col, numbered from zero.
+type marshalColumn struct {
+	index  []int
+	col    int
+	header string
+	tag    fieldTag
+}
+
+// Marshal appends a row to the Sheet for each element of records,
+// which must be a slice of structs or of pointers to structs, after a
+// header row naming each column.
+//
+// Each exported field is written to a column of its own, in the order
+// the fields are declared, unless its xlsx tag gives a cell index.  The
+// header of a column is the name given in the tag, or else the name of
+// the field.  The tag may also set a number format, a column width and
+// a named cell style, as in:
+//
+//	Amount float64 `xlsx:"name=Amount;format=#,##0.00;width=14;style=Currency"`
+//
+// The omitempty, layout and split options of the tag are honoured as
+// Row.WriteStruct does.
+//
+// Fields of embedded or nested structs, without a tag of their own,
+// are written as columns in their own right.  Pointers are followed,
+// and nil pointers, like the sql.Null* types when not Valid, give empty
+// cells.  Fields of types that can't be written to a cell are left
+// out.
+func (s *Sheet) Marshal(records interface{}, opts MarshalOptions) error {
+	s.mustBeOpen()
+	if records == nil {
+		return errNilInterface
+	}
+	slice := reflect.ValueOf(records)
+	if slice.Kind() == reflect.Ptr {
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return errNotSlice
+	}
+	structType := slice.Type().Elem()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return errNotSlice
+	}
+
+	var columns []marshalColumn
+	next := 0
+	used := make(map[int]string)
+	if err := marshalColumns(structType, nil, "", &next, used, &columns); err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	for _, column := range columns {
+		if column.tag.style == "" {
+			continue
+		}
+		if _, ok := s.File.NamedStyle(column.tag.style); !ok {
+			return fmt.Errorf("Marshal: field %s: no named style called %q", column.header, column.tag.style)
+		}
+	}
+
+	headerRow := s.MaxRow
+	if !opts.NoHeader {
+		row := s.AddRow()
+		for _, column := range columns {
+			cell := marshalCell(row, column.col)
+			cell.SetString(column.header)
+			if opts.HeaderStyle != nil {
+				cell.SetStyle(opts.HeaderStyle)
+			}
+		}
+	}
+
+	for i := 0; i < slice.Len(); i++ {
+		row := s.AddRow()
+		record := slice.Index(i)
+		for _, column := range columns {
+			val, ok := fieldByIndexIfSet(record, column.index)
+			if !ok {
+				continue
+			}
+			set := tagSetter(val, column.tag, true)
+			if set == nil {
+				continue
+			}
+			cell := marshalCell(row, column.col)
+			if err := set(cell); err != nil {
+				return fmt.Errorf("Marshal: cell %s: field %s: %w",
+					GetCellIDStringFromCoords(column.col, row.num), column.header, err)
+			}
+			if column.tag.style != "" {
+				if err := cell.SetNamedStyle(column.tag.style); err != nil {
+					return fmt.Errorf("Marshal: %w", err)
+				}
+			}
+			if column.tag.format != "" {
+				cell.SetFormat(column.tag.format)
+			}
+		}
+	}
+
+	for _, column := range columns {
+		if column.tag.format == "" && column.tag.width == 0 {
+			continue
+		}
+		tag := column.tag
+		s.setCol(column.col+1, column.col+1, func(col *Col) {
+			if tag.format != "" {
+				col.SetFormat(tag.format)
+			}
+			if tag.width > 0 {
+				col.SetWidth(tag.width)
+			}
+		})
+	}
+
+	if opts.NoHeader || len(columns) == 0 {
+		return nil
+	}
+	if opts.AutoFilter {
+		lastCol := 0
+		for _, column := range columns {
+			if column.col > lastCol {
+				lastCol = column.col
+			}
+		}
+		s.AutoFilter = &AutoFilter{
+			TopLeftCell:     GetCellIDStringFromCoords(0, headerRow),
+			BottomRightCell: GetCellIDStringFromCoords(lastCol, s.MaxRow-1),
+		}
+	}
+	if opts.FreezeHeader {
+		if err := s.FreezePanes("A" + strconv.Itoa(headerRow+2)); err != nil {
+			return fmt.Errorf("Marshal: %w", err)
+		}
+	}
+	return nil
+}
+
+// marshalColumns appends a column for each field of typ, and of the
+// structs within it, to columns.  next is the column for the next
+// field that doesn't give one of its own, and used maps the columns
+// taken so far to the fields that took them.
+func marshalColumns(typ reflect.Type, path []int, prefix string, next *int, used map[int]string, columns *[]marshalColumn) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag, err := parseFieldTag(field.Tag.Get("xlsx"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if tag.skip {
+			continue
+		}
+		index := append(path[:len(path):len(path)], i)
+		if !tag.hasIndex() && tag.name == "" && isNestedStruct(field.Type) {
+			elemType := field.Type
+			if elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+			name := prefix + field.Name + "."
+			if field.Anonymous {
+				name = prefix
+			}
+			if err := marshalColumns(elemType, index, name, next, used, columns); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		column := marshalColumn{index: index, col: *next, header: tag.name, tag: tag}
+		if tag.hasIndex() {
+			column.col = tag.index
+		}
+		if column.header == "" {
+			column.header = prefix + field.Name
+		}
+		if other, ok := used[column.col]; ok {
+			return fmt.Errorf("fields %s and %s both map to column %d", other, prefix+field.Name, column.col)
+		}
+		used[column.col] = prefix + field.Name
+		*next = column.col + 1
+		*columns = append(*columns, column)
+	}
+	return nil
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// isNestedStruct reports whether values of typ, a struct or a pointer
+// to one, are written field by field rather than to a single cell.
+func isNestedStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && !isCellType(typ)
+}
+
+// marshalCell returns the cell of row in column col, widening the
+// Sheet to include it as Row.AddCell does.
+func marshalCell(row *Row, col int) *Cell {
+	row.cellStoreRow.Updatable()
+	row.isCustom = true
+	cell := row.GetCell(col)
+	if col >= row.Sheet.MaxCol {
+		row.Sheet.MaxCol = col + 1
+	}
+	return cell
+}
+
+// fieldByIndexIfSet returns the field of v at index, following
+// pointers, unless there's a nil pointer on the way.
+func fieldByIndexIfSet(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, x := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
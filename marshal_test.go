@@ -0,0 +1,170 @@
+package xlsx
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type marshalAudit struct {
+	Created time.Time `xlsx:"name=Created;format=yyyy-mm-dd"`
+}
+
+type marshalSupplier struct {
+	Name string
+	City *string
+}
+
+type marshalInvoice struct {
+	marshalAudit
+	Number   int             `xlsx:"name=Invoice No"`
+	Amount   float64         `xlsx:"name=Amount;format=#,##0.00;[Red]-#,##0.00;width=14;style=Money"`
+	Paid     sql.NullBool    `xlsx:"name=Paid"`
+	Discount sql.NullFloat64 `xlsx:"name=Discount"`
+	Supplier *marshalSupplier
+	Notes    string `xlsx:"-"`
+	internal int
+}
+
+func TestParseFieldTagFormat(t *testing.T) {
+	c := qt.New(t)
+	tag, err := parseFieldTag("name=Amount;format=#,##0.00;[Red]-#,##0.00;width=14;style=Money")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.format, qt.Equals, "#,##0.00;[Red]-#,##0.00")
+	c.Assert(tag.width, qt.Equals, 14.0)
+	c.Assert(tag.style, qt.Equals, "Money")
+
+	// Commas separate options too, without splitting the values
+	// that contain them.
+	tag, err = parseFieldTag("name=Amount,format=#,##0.00,width=14,style=Currency")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.name, qt.Equals, "Amount")
+	c.Assert(tag.format, qt.Equals, "#,##0.00")
+	c.Assert(tag.width, qt.Equals, 14.0)
+	c.Assert(tag.style, qt.Equals, "Currency")
+	tag, err = parseFieldTag("name=Last, First,format=#,##0;[Red]-#,##0,omitempty")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.name, qt.Equals, "Last, First")
+	c.Assert(tag.format, qt.Equals, "#,##0;[Red]-#,##0")
+	c.Assert(tag.omitempty, qt.IsTrue)
+	tag, err = parseFieldTag("4,split=,")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.index, qt.Equals, 4)
+	c.Assert(tag.split, qt.Equals, ",")
+
+	_, err = parseFieldTag("width=wide")
+	c.Assert(err, qt.ErrorMatches, `invalid tag.*bad width "wide".*`)
+}
+
+func TestMarshal(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "Marshal", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		money := NewStyle()
+		money.Font.Bold = true
+		money.ApplyFont = true
+		c.Assert(file.AddNamedStyle("Money", money), qt.IsNil)
+		sheet, err := file.AddSheet("Invoices")
+		c.Assert(err, qt.IsNil)
+
+		city := "Leeds"
+		created := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+		invoices := []*marshalInvoice{
+			{
+				marshalAudit: marshalAudit{Created: created},
+				Number:       1,
+				Amount:       1234.5,
+				Paid:         sql.NullBool{Bool: true, Valid: true},
+				Supplier:     &marshalSupplier{Name: "Acme", City: &city},
+				Notes:        "not written",
+			},
+			{Number: 2, Amount: -3, Discount: sql.NullFloat64{Float64: 0.1, Valid: true}},
+		}
+		header := NewStyle()
+		header.Font.Bold = true
+		err = sheet.Marshal(invoices, MarshalOptions{HeaderStyle: header, AutoFilter: true, FreezeHeader: true})
+		c.Assert(err, qt.IsNil)
+
+		text := func(row, col int) string {
+			c.Helper()
+			cell, err := sheet.Cell(row, col)
+			c.Assert(err, qt.IsNil)
+			return cell.Value
+		}
+		headers := []string{"Created", "Invoice No", "Amount", "Paid", "Discount", "Supplier.Name", "Supplier.City"}
+		for i, h := range headers {
+			c.Assert(text(0, i), qt.Equals, h)
+		}
+		c.Assert(sheet.MaxCol, qt.Equals, len(headers))
+		c.Assert(text(1, 1), qt.Equals, "1")
+		c.Assert(text(1, 2), qt.Equals, "1234.5")
+		c.Assert(text(1, 3), qt.Equals, "1")
+		c.Assert(text(1, 4), qt.Equals, "")
+		c.Assert(text(1, 5), qt.Equals, "Acme")
+		c.Assert(text(1, 6), qt.Equals, "Leeds")
+		c.Assert(text(2, 4), qt.Equals, "0.1")
+		c.Assert(text(2, 5), qt.Equals, "")
+
+		amount, err := sheet.Cell(1, 2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(amount.GetNumberFormat(), qt.Equals, "#,##0.00;[Red]-#,##0.00")
+		c.Assert(amount.NamedStyle(), qt.Equals, "Money")
+		formatted, err := amount.FormattedValue()
+		c.Assert(err, qt.IsNil)
+		c.Assert(formatted, qt.Equals, "1234.50")
+		createdCell, err := sheet.Cell(1, 0)
+		c.Assert(err, qt.IsNil)
+		formatted, err = createdCell.FormattedValue()
+		c.Assert(err, qt.IsNil)
+		c.Assert(formatted, qt.Equals, "2024-05-06")
+
+		c.Assert(*sheet.Col(2).Width, qt.Equals, 14.0)
+		c.Assert(sheet.AutoFilter, qt.DeepEquals, &AutoFilter{TopLeftCell: "A1", BottomRightCell: "G3"})
+		c.Assert(sheet.FrozenAt(), qt.Equals, "A2")
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["xl/worksheets/sheet1.xml"], `<autoFilter ref="A1:G3">`), qt.IsTrue)
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+	})
+
+	csRunO(c, "Errors", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.Marshal(nil, MarshalOptions{}), qt.Equals, errNilInterface)
+		c.Assert(sheet.Marshal([]int{1}, MarshalOptions{}), qt.Equals, errNotSlice)
+		err = sheet.Marshal([]marshalInvoice{{}}, MarshalOptions{})
+		c.Assert(err, qt.ErrorMatches, `Marshal: field Amount: no named style called "Money"`)
+		c.Assert(sheet.MaxRow, qt.Equals, 0)
+
+		type clash struct {
+			A string `xlsx:"name=Alpha"`
+			B int    `xlsx:"0"`
+			C float64
+		}
+		err = sheet.Marshal([]clash{{"a", 1, 2.5}}, MarshalOptions{})
+		c.Assert(err, qt.ErrorMatches, `Marshal: fields A and B both map to column 0`)
+		c.Assert(sheet.MaxRow, qt.Equals, 0)
+	})
+
+	csRunO(c, "NoHeaderPositional", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		type record struct {
+			B string `xlsx:"1"`
+			A int    `xlsx:"0"`
+		}
+		c.Assert(sheet.Marshal(&[]record{{"b", 1}}, MarshalOptions{NoHeader: true}), qt.IsNil)
+		read, err := ReadAll[record](sheet, UnmarshalOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(read, qt.DeepEquals, []record{{"b", 1}})
+	})
+}
@@ -0,0 +1,169 @@
+package xlsx
+
+import "fmt"
+
+// normalStyleName is the name of the built in cell style that cells
+// use unless told otherwise.  It always occupies the first cell style
+// format.
+const normalStyleName = "Normal"
+
+// namedStyle is an entry in the table of named cell styles kept by an
+// xlsxStyleSheet.
+type namedStyle struct {
+	name      string
+	style     Style
+	cellStyle xlsxCellStyle // The cellStyle element it was read from, if any
+}
+
+// AddNamedStyle defines a named cell style, such as "Heading 1", that
+// cells can be based on with Cell.SetNamedStyle.  Any existing style
+// of the same name, including the built in "Normal" style, is
+// replaced.  The style's Apply flags say which parts of the
+// formatting the named style includes.
+func (f *File) AddNamedStyle(name string, style *Style) error {
+	if name == "" {
+		return fmt.Errorf("AddNamedStyle: name must not be empty")
+	}
+	if style == nil {
+		return fmt.Errorf("AddNamedStyle(%q): style must not be nil", name)
+	}
+	if f.styles == nil {
+		f.styles = newXlsxStyleSheet(f.theme, f.defaultStyle)
+	}
+	ns := namedStyle{name: name, style: *style}
+	ns.style.NamedStyle = ""
+	ns.style.NamedStyleIndex = nil
+	if i, ok := f.styles.findNamedStyle(name); ok {
+		ns.cellStyle = f.styles.namedStyles[i].cellStyle
+		f.styles.namedStyles[i] = ns
+		return nil
+	}
+	f.styles.namedStyles = append(f.styles.namedStyles, ns)
+	return nil
+}
+
+// NamedStyle returns a copy of the named cell style called name, and
+// whether it exists.
+func (f *File) NamedStyle(name string) (*Style, bool) {
+	if f.styles == nil {
+		return nil, false
+	}
+	i, ok := f.styles.findNamedStyle(name)
+	if !ok {
+		return nil, false
+	}
+	style := f.styles.namedStyles[i].style
+	return &style, true
+}
+
+// NamedStyles returns the names of the File's named cell styles, in
+// the order they were defined or read.
+func (f *File) NamedStyles() []string {
+	if f.styles == nil {
+		return nil
+	}
+	names := make([]string, len(f.styles.namedStyles))
+	for i, ns := range f.styles.namedStyles {
+		names[i] = ns.name
+	}
+	return names
+}
+
+func (styles *xlsxStyleSheet) findNamedStyle(name string) (int, bool) {
+	for i, ns := range styles.namedStyles {
+		if ns.name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// readNamedStyles fills the table of named styles from the cellStyles
+// and cellStyleXfs elements of a freshly read style sheet.
+func (styles *xlsxStyleSheet) readNamedStyles() {
+	if styles.CellStyles == nil || styles.CellStyleXfs == nil {
+		return
+	}
+	for _, cellStyle := range styles.CellStyles.CellStyle {
+		if cellStyle.XfId < 0 || cellStyle.XfId >= len(styles.CellStyleXfs.Xf) {
+			continue
+		}
+		if _, ok := styles.findNamedStyle(cellStyle.Name); ok {
+			continue
+		}
+		ns := namedStyle{name: cellStyle.Name, cellStyle: cellStyle}
+		styles.populateStyleFromXf(&ns.style, styles.CellStyleXfs.Xf[cellStyle.XfId])
+		styles.namedStyles = append(styles.namedStyles, ns)
+	}
+}
+
+// makeNamedStyles rebuilds the cellStyleXfs and cellStyles elements
+// from the table of named styles.  "Normal" is always given the first
+// cell style format, which the standard requires to exist anyway, and
+// is written whenever there are other named styles or the file has a
+// default style.
+func (styles *xlsxStyleSheet) makeNamedStyles() {
+	styles.CellStyles = nil
+	if len(styles.namedStyles) == 0 && styles.defaultStyle == nil {
+		return
+	}
+	styles.CellStyles = &xlsxCellStyles{}
+	normal := xlsxCellStyle{Name: normalStyleName}
+	if i, ok := styles.findNamedStyle(normalStyleName); ok {
+		normal = styles.namedStyles[i].cellStyle
+		normal.Name = normalStyleName
+		styles.CellStyleXfs.Xf[0] = styles.makeNamedStyleXf(&styles.namedStyles[i].style)
+	}
+	normal.XfId = 0
+	normal.BuiltInId = iPtr(0)
+	styles.CellStyles.add(normal)
+
+	for _, ns := range styles.namedStyles {
+		if ns.name == normalStyleName {
+			continue
+		}
+		cellStyle := ns.cellStyle
+		cellStyle.Name = ns.name
+		cellStyle.XfId = styles.CellStyleXfs.Count
+		styles.CellStyleXfs.addXf(styles.makeNamedStyleXf(&ns.style))
+		styles.CellStyles.add(cellStyle)
+	}
+}
+
+// makeNamedStyleXf returns the cell style format for a named style.
+func (styles *xlsxStyleSheet) makeNamedStyleXf(style *Style) xlsxXf {
+	xFont, xFill, xBorder, xf := style.makeXLSXStyleElements()
+	xf.FontId = styles.addFont(xFont)
+	xf.FillId = styles.addFill(xFill)
+	xf.BorderId = styles.addBorder(xBorder)
+	xf.XfId = nil
+	return xf
+}
+
+// namedStyleXfId returns the index of the cell style format of the
+// named style called name, or nil if there is no such style.
+func (styles *xlsxStyleSheet) namedStyleXfId(name string) *int {
+	if name == "" || styles.CellStyles == nil {
+		return nil
+	}
+	for _, cellStyle := range styles.CellStyles.CellStyle {
+		if cellStyle.Name == name {
+			return iPtr(cellStyle.XfId)
+		}
+	}
+	return nil
+}
+
+// namedStyleName returns the name of the named style that uses the
+// cell style format at xfId, if any.
+func (styles *xlsxStyleSheet) namedStyleName(xfId int) string {
+	if styles.CellStyles == nil {
+		return ""
+	}
+	for _, cellStyle := range styles.CellStyles.CellStyle {
+		if cellStyle.XfId == xfId {
+			return cellStyle.Name
+		}
+	}
+	return ""
+}
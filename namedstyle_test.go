@@ -0,0 +1,142 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNamedStyles(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("AddNamedStyle", func(c *qt.C) {
+		f := NewFile()
+		c.Assert(f.AddNamedStyle("", NewStyle()), qt.Not(qt.IsNil))
+		c.Assert(f.AddNamedStyle("Heading", nil), qt.Not(qt.IsNil))
+
+		style := NewStyle()
+		style.Font.Bold = true
+		c.Assert(f.AddNamedStyle("Heading", style), qt.IsNil)
+		style.Font.Bold = false
+		got, ok := f.NamedStyle("Heading")
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(got.Font.Bold, qt.IsTrue)
+
+		c.Assert(f.AddNamedStyle("Heading", NewStyle()), qt.IsNil)
+		c.Assert(f.NamedStyles(), qt.DeepEquals, []string{"Heading"})
+		_, ok = f.NamedStyle("Missing")
+		c.Assert(ok, qt.IsFalse)
+	})
+
+	c.Run("SetNamedStyleKeepsOverrides", func(c *qt.C) {
+		f := NewFile()
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		cell := sheet.AddRow().AddCell()
+		c.Assert(cell.SetNamedStyle("Heading"), qt.Not(qt.IsNil))
+
+		heading := NewStyle()
+		heading.Font.Size = 16
+		heading.ApplyFont = true
+		c.Assert(f.AddNamedStyle("Heading", heading), qt.IsNil)
+
+		own := NewStyle()
+		own.Fill = *NewFill(Solid_Cell_Fill, RGB_Light_Green, RGB_White)
+		own.ApplyFill = true
+		cell.SetStyle(own)
+		c.Assert(cell.SetNamedStyle("Heading"), qt.IsNil)
+
+		style := cell.GetStyle()
+		c.Assert(cell.NamedStyle(), qt.Equals, "Heading")
+		c.Assert(style.Font.Size, qt.Equals, 16.0)
+		c.Assert(style.ApplyFont, qt.IsFalse)
+		c.Assert(style.Fill.FgColor, qt.Equals, RGB_Light_Green)
+		c.Assert(style.ApplyFill, qt.IsTrue)
+	})
+
+	c.Run("MakeNamedStyles", func(c *qt.C) {
+		styles := newXlsxStyleSheet(nil, nil)
+		heading := NewStyle()
+		heading.Font.Bold = true
+		heading.ApplyFont = true
+		styles.namedStyles = []namedStyle{{name: "Heading 1", style: *heading}}
+		styles.reset()
+
+		c.Assert(styles.CellStyleXfs.Count, qt.Equals, 2)
+		c.Assert(styles.CellStyles.CellStyle, qt.HasLen, 2)
+		c.Assert(styles.CellStyles.CellStyle[0].Name, qt.Equals, "Normal")
+		c.Assert(*styles.CellStyles.CellStyle[0].BuiltInId, qt.Equals, 0)
+		c.Assert(styles.CellStyles.CellStyle[1].Name, qt.Equals, "Heading 1")
+		c.Assert(styles.CellStyles.CellStyle[1].XfId, qt.Equals, 1)
+		xf := styles.CellStyleXfs.Xf[1]
+		c.Assert(xf.XfId, qt.IsNil)
+		c.Assert(xf.ApplyFont, qt.IsTrue)
+		c.Assert(styles.Fonts.Font[xf.FontId].B, qt.Not(qt.IsNil))
+		c.Assert(*styles.namedStyleXfId("Heading 1"), qt.Equals, 1)
+		c.Assert(styles.namedStyleXfId("Missing"), qt.IsNil)
+	})
+
+	c.Run("NoNamedStyles", func(c *qt.C) {
+		styles := newXlsxStyleSheet(nil, nil)
+		styles.reset()
+		c.Assert(styles.CellStyles, qt.IsNil)
+	})
+}
+
+func TestNamedStylesRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "SaveAndRead", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+
+		input := NewStyle()
+		input.Fill = *NewFill(Solid_Cell_Fill, "FFFFCC99", "FFFFFFFF")
+		input.ApplyFill = true
+		c.Assert(file.AddNamedStyle("Currency Input", input), qt.IsNil)
+
+		row := sheet.AddRow()
+		plain := row.AddCell()
+		plain.SetString("plain")
+		styled := row.AddCell()
+		styled.SetFloat(12.5)
+		c.Assert(styled.SetNamedStyle("Currency Input"), qt.IsNil)
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["xl/styles.xml"],
+			`<cellStyles count="2"><cellStyle builtInId="0" name="Normal" xfId="0"></cellStyle><cellStyle name="Currency Input" xfId="1"></cellStyle></cellStyles>`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.NamedStyles(), qt.DeepEquals, []string{"Normal", "Currency Input"})
+		named, ok := file.NamedStyle("Currency Input")
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(named.Fill.FgColor, qt.Equals, "FFFFCC99")
+
+		sheet = file.Sheets[0]
+		cell, err := sheet.Cell(0, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.NamedStyle(), qt.Equals, "Currency Input")
+		c.Assert(cell.GetStyle().Fill.FgColor, qt.Equals, "FFFFCC99")
+		cell, err = sheet.Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.NamedStyle(), qt.Equals, "")
+
+		// Writing the file again mustn't duplicate the named styles.
+		buf.Reset()
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.NamedStyles(), qt.DeepEquals, []string{"Normal", "Currency Input"})
+		cell, err = file.Sheets[0].Cell(0, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.NamedStyle(), qt.Equals, "Currency Input")
+	})
+}
@@ -0,0 +1,131 @@
+package xlsx
+
+import "fmt"
+
+// maxOutlineLevel is the deepest that Excel can nest outline groups.
+const maxOutlineLevel = 7
+
+// OutlineSettings controls how Excel shows the outline groups of a
+// Sheet.  The zero value matches Excel's defaults: summary rows
+// below their detail and summary columns to the right of it.
+type OutlineSettings struct {
+	SummaryAbove bool // Summary rows are above their detail rows
+	SummaryLeft  bool // Summary columns are left of their detail columns
+	ApplyStyles  bool // Excel applies its outline styles to summaries
+}
+
+func (o OutlineSettings) makeXLSXOutlinePr() *xlsxOutlinePr {
+	if o == (OutlineSettings{}) {
+		return nil
+	}
+	outlinePr := &xlsxOutlinePr{ApplyStyles: o.ApplyStyles}
+	if o.SummaryAbove {
+		outlinePr.SummaryBelow = bPtr(false)
+	}
+	if o.SummaryLeft {
+		outlinePr.SummaryRight = bPtr(false)
+	}
+	return outlinePr
+}
+
+func readOutlinePr(outlinePr *xlsxOutlinePr) OutlineSettings {
+	if outlinePr == nil {
+		return OutlineSettings{}
+	}
+	return OutlineSettings{
+		SummaryAbove: outlinePr.SummaryBelow != nil && !*outlinePr.SummaryBelow,
+		SummaryLeft:  outlinePr.SummaryRight != nil && !*outlinePr.SummaryRight,
+		ApplyStyles:  outlinePr.ApplyStyles,
+	}
+}
+
+func (s *Sheet) makeSheetPr(worksheet *xlsxWorksheet) {
+	if !s.TabColor.IsZero() {
+		tabColor := s.TabColor.xlsx()
+		worksheet.SheetPr.TabColor = &tabColor
+	}
+	worksheet.SheetPr.OutlinePr = s.Outline.makeXLSXOutlinePr()
+}
+
+// GroupRows adds the rows from "from" to "to" inclusive, numbered
+// from zero, to an outline group, one level deeper than they are
+// already.  When collapsed is true the rows are hidden and the summary
+// row next to them, below or above according to the Sheet's Outline
+// settings, is marked as collapsed.
+func (s *Sheet) GroupRows(from, to int, collapsed bool) error {
+	s.mustBeOpen()
+	if from < 0 || to < from {
+		return fmt.Errorf("GroupRows(%d, %d): invalid range of rows", from, to)
+	}
+	for i := from; i <= to; i++ {
+		row, err := s.Row(i)
+		if err != nil {
+			return fmt.Errorf("GroupRows(%d, %d): %w", from, to, err)
+		}
+		level := row.GetOutlineLevel() + 1
+		if level > maxOutlineLevel {
+			return fmt.Errorf("GroupRows(%d, %d): row %d would be nested more than %d levels deep", from, to, i, maxOutlineLevel)
+		}
+		row.SetOutlineLevel(level)
+		if collapsed {
+			row.SetHidden(true)
+		}
+	}
+	if !collapsed {
+		return nil
+	}
+	summary := to + 1
+	if s.Outline.SummaryAbove {
+		summary = from - 1
+	}
+	if summary < 0 {
+		return nil
+	}
+	row, err := s.Row(summary)
+	if err != nil {
+		return fmt.Errorf("GroupRows(%d, %d): %w", from, to, err)
+	}
+	row.SetCollapsed(true)
+	return nil
+}
+
+// GroupCols adds the columns from min to max inclusive, numbered from
+// 1, to an outline group, one level deeper than they are already.
+// When collapsed is true the columns are hidden and the summary column
+// next to them, to the right or left according to the Sheet's Outline
+// settings, is marked as collapsed.
+func (s *Sheet) GroupCols(min, max int, collapsed bool) error {
+	s.mustBeOpen()
+	if min < 1 || max < min {
+		return fmt.Errorf("GroupCols(%d, %d): invalid range of columns", min, max)
+	}
+	for i := min; i <= max; i++ {
+		if col := s.Cols.FindColByIndex(i); col != nil && col.OutlineLevel != nil && *col.OutlineLevel >= maxOutlineLevel {
+			return fmt.Errorf("GroupCols(%d, %d): column %d would be nested more than %d levels deep", min, max, i, maxOutlineLevel)
+		}
+	}
+	s.setCol(min, max, func(col *Col) {
+		var level uint8
+		if col.OutlineLevel != nil {
+			level = *col.OutlineLevel
+		}
+		col.SetOutlineLevel(level + 1)
+		if collapsed {
+			col.SetHidden(true)
+		}
+	})
+	if !collapsed {
+		return nil
+	}
+	summary := max + 1
+	if s.Outline.SummaryLeft {
+		summary = min - 1
+	}
+	if summary < 1 {
+		return nil
+	}
+	s.setCol(summary, summary, func(col *Col) {
+		col.SetCollapsed(true)
+	})
+	return nil
+}
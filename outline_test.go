@@ -0,0 +1,111 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestOutline(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("OutlinePr", func(c *qt.C) {
+		c.Assert(OutlineSettings{}.makeXLSXOutlinePr(), qt.IsNil)
+		settings := OutlineSettings{SummaryAbove: true, ApplyStyles: true}
+		outlinePr := settings.makeXLSXOutlinePr()
+		c.Assert(*outlinePr.SummaryBelow, qt.IsFalse)
+		c.Assert(outlinePr.SummaryRight, qt.IsNil)
+		c.Assert(readOutlinePr(outlinePr), qt.Equals, settings)
+		c.Assert(readOutlinePr(nil), qt.Equals, OutlineSettings{})
+	})
+
+	csRunO(c, "InvalidGroups", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.GroupRows(3, 2, false), qt.ErrorMatches, `GroupRows\(3, 2\): invalid range of rows`)
+		c.Assert(sheet.GroupCols(0, 2, false), qt.ErrorMatches, `GroupCols\(0, 2\): invalid range of columns`)
+		for i := 0; i < maxOutlineLevel; i++ {
+			c.Assert(sheet.GroupRows(0, 0, false), qt.IsNil)
+			c.Assert(sheet.GroupCols(1, 1, false), qt.IsNil)
+		}
+		c.Assert(sheet.GroupRows(0, 0, false), qt.ErrorMatches, `.*nested more than 7 levels deep`)
+		c.Assert(sheet.GroupCols(1, 1, false), qt.ErrorMatches, `.*nested more than 7 levels deep`)
+	})
+
+	csRunO(c, "RoundTrip", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		for i := 0; i < 6; i++ {
+			row := sheet.AddRow()
+			for j := 0; j < 5; j++ {
+				row.AddCell().SetInt(i*10 + j)
+			}
+		}
+		// Row 1 is the summary of a collapsed group above its
+		// detail, and column E is hidden on its own.
+		sheet.Outline.SummaryAbove = true
+		c.Assert(sheet.GroupRows(1, 2, true), qt.IsNil)
+		c.Assert(sheet.GroupRows(4, 4, false), qt.IsNil)
+		c.Assert(sheet.GroupCols(2, 3, true), qt.IsNil)
+		col := NewColForRange(5, 5)
+		col.SetHidden(true)
+		sheet.SetColParameters(col)
+		// An empty hidden row must still be written.
+		empty, err := sheet.Row(7)
+		c.Assert(err, qt.IsNil)
+		empty.SetHidden(true)
+
+		// SkipEmptyRows leaves out row 7, which has nothing at all,
+		// but not the hidden row 8.
+		var visited []int
+		c.Assert(sheet.ForEachRow(func(r *Row) error {
+			visited = append(visited, r.num)
+			return nil
+		}, SkipEmptyRows), qt.IsNil)
+		c.Assert(visited, qt.DeepEquals, []int{0, 1, 2, 3, 4, 5, 7})
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		xml := parts["xl/worksheets/sheet1.xml"]
+		c.Assert(strings.Contains(xml, `<outlinePr summaryBelow="false"></outlinePr>`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<row r="1" collapsed="true">`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<row r="2" hidden="true" outlineLevel="1">`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<row r="8" hidden="true"></row>`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<col collapsed="true" max="4" min="4"`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		sheet = file.Sheets[0]
+		c.Assert(sheet.Outline, qt.Equals, OutlineSettings{SummaryAbove: true})
+
+		row := func(i int) *Row {
+			c.Helper()
+			r, err := sheet.Row(i)
+			c.Assert(err, qt.IsNil)
+			return r
+		}
+		c.Assert(row(0).GetCollapsed(), qt.IsTrue)
+		c.Assert(row(0).Hidden, qt.IsFalse)
+		c.Assert(row(1).Hidden, qt.IsTrue)
+		c.Assert(row(1).GetOutlineLevel(), qt.Equals, uint8(1))
+		c.Assert(row(2).Hidden, qt.IsTrue)
+		c.Assert(row(3).GetOutlineLevel(), qt.Equals, uint8(0))
+		c.Assert(row(4).GetOutlineLevel(), qt.Equals, uint8(1))
+		c.Assert(row(4).Hidden, qt.IsFalse)
+		c.Assert(row(7).Hidden, qt.IsTrue)
+		c.Assert(sheet.SheetFormat.OutlineLevelRow, qt.Equals, uint8(1))
+
+		c.Assert(*sheet.Col(1).OutlineLevel, qt.Equals, uint8(1))
+		c.Assert(*sheet.Col(1).Hidden, qt.IsTrue)
+		c.Assert(*sheet.Col(2).Hidden, qt.IsTrue)
+		c.Assert(*sheet.Col(3).Collapsed, qt.IsTrue)
+		c.Assert(*sheet.Col(4).Hidden, qt.IsTrue)
+		c.Assert(sheet.Col(0), qt.IsNil)
+	})
+}
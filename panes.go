@@ -0,0 +1,140 @@
+package xlsx
+
+import "fmt"
+
+// The states of a Pane.
+const (
+	PaneStateFrozen      = "frozen"
+	PaneStateFrozenSplit = "frozenSplit"
+	PaneStateSplit       = "split"
+)
+
+// firstSheetView returns the first of the Sheet's views, adding one if
+// it has none.
+func (s *Sheet) firstSheetView() *SheetView {
+	if len(s.SheetViews) == 0 {
+		s.SheetViews = []SheetView{{}}
+	}
+	return &s.SheetViews[0]
+}
+
+// FreezePanes freezes the rows above and the columns left of cell, so
+// that they stay in view while the rest of the Sheet scrolls.
+// FreezePanes("A2") freezes the first row, FreezePanes("B1") the first
+// column and FreezePanes("B2") both.  Freezing at "A1" unfreezes the
+// Sheet.
+func (s *Sheet) FreezePanes(cell string) error {
+	col, row, err := GetCoordsFromCellIDString(cell)
+	if err != nil || col < 0 || row < 0 {
+		return fmt.Errorf("FreezePanes(%q): invalid cell reference", cell)
+	}
+	if col == 0 && row == 0 {
+		s.Unfreeze()
+		return nil
+	}
+	view := s.firstSheetView()
+	view.Pane = &Pane{
+		XSplit:      float64(col),
+		YSplit:      float64(row),
+		TopLeftCell: GetCellIDStringFromCoords(col, row),
+		ActivePane:  activePane(col > 0, row > 0),
+		State:       PaneStateFrozen,
+	}
+	view.Selections = paneSelections(col, row)
+	return nil
+}
+
+// SplitPanes splits the Sheet's window into panes that scroll
+// separately, x points from the left and y points from the top.  A
+// split of zero in either direction leaves the window whole that way.
+func (s *Sheet) SplitPanes(x, y float64) error {
+	if x < 0 || y < 0 || (x == 0 && y == 0) {
+		return fmt.Errorf("SplitPanes(%g, %g): invalid split", x, y)
+	}
+	view := s.firstSheetView()
+	// The position of a split is measured in twentieths of a point.
+	view.Pane = &Pane{
+		XSplit:     x * 20,
+		YSplit:     y * 20,
+		ActivePane: activePane(x > 0, y > 0),
+		State:      PaneStateSplit,
+	}
+	// Until they're scrolled, every pane starts at the same cell.
+	view.Selections = nil
+	for _, pane := range splitPanes(x > 0, y > 0) {
+		view.Selections = append(view.Selections, Selection{Pane: pane, ActiveCell: "A1"})
+	}
+	return nil
+}
+
+// Unfreeze removes any frozen or split panes from the Sheet's first
+// view, along with the selections in them.
+func (s *Sheet) Unfreeze() {
+	if len(s.SheetViews) == 0 {
+		return
+	}
+	view := &s.SheetViews[0]
+	view.Pane = nil
+	var selections []Selection
+	for _, selection := range view.Selections {
+		if selection.Pane == "" || selection.Pane == "topLeft" {
+			selection.Pane = ""
+			selections = append(selections, selection)
+		}
+	}
+	view.Selections = selections
+}
+
+// FrozenAt returns the top left cell of the part of the Sheet that
+// scrolls, below and right of its frozen rows and columns, such as "A2"
+// for a Sheet with a frozen header row.  It returns "" if the Sheet has
+// no frozen panes.
+func (s *Sheet) FrozenAt() string {
+	if len(s.SheetViews) == 0 || s.SheetViews[0].Pane == nil {
+		return ""
+	}
+	pane := s.SheetViews[0].Pane
+	if pane.State != PaneStateFrozen && pane.State != PaneStateFrozenSplit {
+		return ""
+	}
+	return GetCellIDStringFromCoords(int(pane.XSplit), int(pane.YSplit))
+}
+
+// activePane returns the pane that has the focus when the window is
+// split vertically, horizontally or both: the one furthest from the
+// top left.
+func activePane(vertical, horizontal bool) string {
+	panes := splitPanes(vertical, horizontal)
+	return panes[len(panes)-1]
+}
+
+// splitPanes returns the panes, other than the top left one, that a
+// window split vertically, horizontally or both is divided into.
+func splitPanes(vertical, horizontal bool) []string {
+	switch {
+	case vertical && horizontal:
+		return []string{"topRight", "bottomLeft", "bottomRight"}
+	case horizontal:
+		return []string{"bottomLeft"}
+	default:
+		return []string{"topRight"}
+	}
+}
+
+// paneSelections returns the selections Excel writes for panes frozen
+// col columns from the left and row rows from the top, with the first
+// cell of each pane selected.
+func paneSelections(col, row int) []Selection {
+	var selections []Selection
+	for _, pane := range splitPanes(col > 0, row > 0) {
+		selection := Selection{Pane: pane, ActiveCell: GetCellIDStringFromCoords(col, row)}
+		switch pane {
+		case "topRight":
+			selection.ActiveCell = GetCellIDStringFromCoords(col, 0)
+		case "bottomLeft":
+			selection.ActiveCell = GetCellIDStringFromCoords(0, row)
+		}
+		selections = append(selections, selection)
+	}
+	return selections
+}
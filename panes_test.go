@@ -0,0 +1,76 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPanes(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "FreezePanes", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.FrozenAt(), qt.Equals, "")
+		c.Assert(sheet.FreezePanes("nonsense"), qt.ErrorMatches, `FreezePanes\("nonsense"\): invalid cell reference`)
+
+		c.Assert(sheet.FreezePanes("A2"), qt.IsNil)
+		c.Assert(*sheet.SheetViews[0].Pane, qt.Equals, Pane{YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", State: PaneStateFrozen})
+		c.Assert(sheet.SheetViews[0].Selections, qt.DeepEquals, []Selection{{Pane: "bottomLeft", ActiveCell: "A2"}})
+
+		c.Assert(sheet.FreezePanes("C1"), qt.IsNil)
+		c.Assert(sheet.SheetViews[0].Pane.ActivePane, qt.Equals, "topRight")
+		c.Assert(sheet.SheetViews[0].Selections, qt.DeepEquals, []Selection{{Pane: "topRight", ActiveCell: "C1"}})
+
+		c.Assert(sheet.FreezePanes("B3"), qt.IsNil)
+		c.Assert(sheet.SheetViews[0].Selections, qt.DeepEquals, []Selection{
+			{Pane: "topRight", ActiveCell: "B1"},
+			{Pane: "bottomLeft", ActiveCell: "A3"},
+			{Pane: "bottomRight", ActiveCell: "B3"},
+		})
+		sheet.AddRow().AddCell().SetString("header")
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		xml := parts["xl/worksheets/sheet1.xml"]
+		c.Assert(strings.Contains(xml, `<pane xSplit="1" ySplit="2" topLeftCell="B3" activePane="bottomRight" state="frozen"></pane>`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<selection pane="bottomRight" activeCell="B3" activeCellId="0" sqref="B3"></selection>`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		sheet = file.Sheets[0]
+		c.Assert(sheet.FrozenAt(), qt.Equals, "B3")
+
+		sheet.Unfreeze()
+		c.Assert(sheet.FrozenAt(), qt.Equals, "")
+		c.Assert(sheet.SheetViews[0].Pane, qt.IsNil)
+		c.Assert(sheet.SheetViews[0].Selections, qt.HasLen, 0)
+		c.Assert(sheet.FreezePanes("A1"), qt.IsNil)
+		c.Assert(sheet.SheetViews[0].Pane, qt.IsNil)
+	})
+
+	csRunO(c, "SplitPanes", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		c.Assert(sheet.SplitPanes(0, 0), qt.ErrorMatches, `SplitPanes\(0, 0\): invalid split`)
+		c.Assert(sheet.SplitPanes(0, 30), qt.IsNil)
+		sheet.AddRow().AddCell().SetString("value")
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		sheet = file.Sheets[0]
+		c.Assert(*sheet.SheetViews[0].Pane, qt.Equals, Pane{YSplit: 600, ActivePane: "bottomLeft", State: PaneStateSplit})
+		c.Assert(sheet.SheetViews[0].Selections, qt.DeepEquals, []Selection{{Pane: "bottomLeft", ActiveCell: "A1", SQRef: "A1"}})
+		// A split pane isn't frozen.
+		c.Assert(sheet.FrozenAt(), qt.Equals, "")
+	})
+}
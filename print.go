@@ -0,0 +1,317 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	printAreaName   = "_xlnm.Print_Area"
+	printTitlesName = "_xlnm.Print_Titles"
+
+	// The max attribute of a manual break is the last cell the
+	// break extends across, as a zero based index.
+	rowBreakMax = 16383
+	colBreakMax = 1048575
+)
+
+// PrintArea describes the rectangle of cells that is printed when a
+// Sheet is printed.
+type PrintArea struct {
+	TopLeftCell     string
+	BottomRightCell string
+}
+
+// PrintTitles describes the rows and columns that are repeated on
+// every printed page.  Rows is a range of row numbers such as "1:2",
+// and Cols a range of column letters such as "A:B".  Either may be
+// empty.
+type PrintTitles struct {
+	Rows string
+	Cols string
+}
+
+// SetPrintArea limits printing of the Sheet to the cells between
+// topLeftCell and bottomRightCell, e.g. "A1" and "D20".
+func (s *Sheet) SetPrintArea(topLeftCell, bottomRightCell string) error {
+	wrap := func(err error) error {
+		return fmt.Errorf("SetPrintArea: %w", err)
+	}
+	if _, _, err := GetCoordsFromCellIDString(topLeftCell); err != nil {
+		return wrap(err)
+	}
+	if _, _, err := GetCoordsFromCellIDString(bottomRightCell); err != nil {
+		return wrap(err)
+	}
+	s.PrintArea = &PrintArea{
+		TopLeftCell:     strings.ReplaceAll(topLeftCell, fixedCellRefChar, ""),
+		BottomRightCell: strings.ReplaceAll(bottomRightCell, fixedCellRefChar, ""),
+	}
+	return nil
+}
+
+// SetPrintTitles sets the rows and columns that are repeated at the
+// top and left of every printed page.  rows is a row number or range
+// of row numbers ("1" or "1:2"), cols a column or range of columns
+// ("A" or "A:B").  Pass an empty string for either to leave it
+// unset, or both to remove the print titles altogether.
+func (s *Sheet) SetPrintTitles(rows, cols string) error {
+	wrap := func(err error) error {
+		return fmt.Errorf("SetPrintTitles: %w", err)
+	}
+	titles := &PrintTitles{}
+	if rows != "" {
+		first, last, err := parseTitleRange(rows, true)
+		if err != nil {
+			return wrap(err)
+		}
+		titles.Rows = first + cellRangeChar + last
+	}
+	if cols != "" {
+		first, last, err := parseTitleRange(cols, false)
+		if err != nil {
+			return wrap(err)
+		}
+		titles.Cols = first + cellRangeChar + last
+	}
+	if titles.Rows == "" && titles.Cols == "" {
+		titles = nil
+	}
+	s.PrintTitles = titles
+	return nil
+}
+
+// parseTitleRange splits a range of whole rows or whole columns into
+// its bounds, normalising away any "$" markers and converting column
+// letters to upper case.
+func parseTitleRange(ref string, rows bool) (string, string, error) {
+	parts := strings.SplitN(strings.ReplaceAll(ref, fixedCellRefChar, ""), cellRangeChar, 2)
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	for i, part := range parts {
+		if rows {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 1 {
+				return "", "", fmt.Errorf("invalid row range %q", ref)
+			}
+			continue
+		}
+		letters := strings.Map(letterOnlyMapF, part)
+		if letters == "" || len(letters) != len(part) {
+			return "", "", fmt.Errorf("invalid column range %q", ref)
+		}
+		parts[i] = letters
+	}
+	return parts[0], parts[1], nil
+}
+
+// InsertPageBreak adds a manual page break to the Sheet.  The
+// reference works the same way as selecting cells in Excel and
+// choosing "Insert Page Break": a row number such as "5" starts a new
+// page at that row, column letters such as "C" start a new page at
+// that column, and a cell such as "C5" does both.  A break is never
+// inserted before the first row or column, so "A5" only breaks the
+// rows and "C1" only breaks the columns.
+func (s *Sheet) InsertPageBreak(ref string) error {
+	wrap := func(err error) error {
+		return fmt.Errorf("InsertPageBreak(%q): %w", ref, err)
+	}
+	ref = strings.ReplaceAll(ref, fixedCellRefChar, "")
+	letters := strings.Map(letterOnlyMapF, ref)
+	digits := strings.Map(intOnlyMapF, ref)
+	if len(letters)+len(digits) != len(ref) || ref == "" {
+		return wrap(fmt.Errorf("not a row, column or cell reference"))
+	}
+	inserted := false
+	if digits != "" {
+		row, err := strconv.Atoi(digits)
+		if err != nil || row < 1 {
+			return wrap(fmt.Errorf("invalid row number %q", digits))
+		}
+		if row > 1 {
+			s.RowBreaks = insertBreak(s.RowBreaks, row-1)
+			inserted = true
+		}
+	}
+	if letters != "" {
+		if col := ColLettersToIndex(letters); col > 0 {
+			s.ColBreaks = insertBreak(s.ColBreaks, col)
+			inserted = true
+		}
+	}
+	if !inserted {
+		return wrap(fmt.Errorf("cannot break before the first row or column"))
+	}
+	return nil
+}
+
+// insertBreak adds index to the sorted slice breaks, unless it is
+// already present.
+func insertBreak(breaks []int, index int) []int {
+	i := sort.SearchInts(breaks, index)
+	if i < len(breaks) && breaks[i] == index {
+		return breaks
+	}
+	breaks = append(breaks, 0)
+	copy(breaks[i+1:], breaks[i:])
+	breaks[i] = index
+	return breaks
+}
+
+func makeXLSXBreaks(breaks []int, max int) *xlsxBreaks {
+	if len(breaks) == 0 {
+		return nil
+	}
+	xBreaks := &xlsxBreaks{
+		Count:            len(breaks),
+		ManualBreakCount: len(breaks),
+	}
+	for _, id := range breaks {
+		xBreaks.Brk = append(xBreaks.Brk, xlsxBreak{Id: id, Max: max, Man: true})
+	}
+	return xBreaks
+}
+
+// readXLSXBreaks returns the manual page breaks of xBreaks.  The
+// automatic ones, which some programs write as well, follow from the
+// page layout and are left out, so that they aren't written back as
+// manual breaks.
+func readXLSXBreaks(xBreaks *xlsxBreaks) []int {
+	if xBreaks == nil {
+		return nil
+	}
+	var breaks []int
+	for _, brk := range xBreaks.Brk {
+		if !brk.Man {
+			continue
+		}
+		breaks = insertBreak(breaks, brk.Id)
+	}
+	return breaks
+}
+
+func (s *Sheet) makePageBreaks(worksheet *xlsxWorksheet) {
+	worksheet.RowBreaks = makeXLSXBreaks(s.RowBreaks, rowBreakMax)
+	worksheet.ColBreaks = makeXLSXBreaks(s.ColBreaks, colBreakMax)
+}
+
+// quotedSheetName returns the name of the sheet in the quoted form
+// used by references in defined names.
+func quotedSheetName(sheet *Sheet) string {
+	return "'" + strings.ReplaceAll(sheet.Name, "'", "''") + "'"
+}
+
+// printAreaDefinedName builds the "_xlnm.Print_Area" defined name by
+// which Excel records a sheet's print area.
+func printAreaDefinedName(sheet *Sheet, sheetIndex int) *xlsxDefinedName {
+	if sheet.PrintArea == nil {
+		return nil
+	}
+	return &xlsxDefinedName{
+		Data: fmt.Sprintf(
+			"%s!%v:%v",
+			quotedSheetName(sheet),
+			cellIDStringWithFixed(sheet.PrintArea.TopLeftCell),
+			cellIDStringWithFixed(sheet.PrintArea.BottomRightCell),
+		),
+		Name:         printAreaName,
+		LocalSheetID: iPtr(sheetIndex - 1),
+	}
+}
+
+// printTitlesDefinedName builds the "_xlnm.Print_Titles" defined
+// name, listing the title columns before the title rows as Excel
+// does.
+func printTitlesDefinedName(sheet *Sheet, sheetIndex int) *xlsxDefinedName {
+	if sheet.PrintTitles == nil {
+		return nil
+	}
+	var refs []string
+	for _, r := range []string{sheet.PrintTitles.Cols, sheet.PrintTitles.Rows} {
+		if r == "" {
+			continue
+		}
+		parts := strings.SplitN(r, cellRangeChar, 2)
+		if len(parts) == 1 {
+			parts = append(parts, parts[0])
+		}
+		refs = append(refs, fmt.Sprintf("%s!%s%s:%s%s",
+			quotedSheetName(sheet), fixedCellRefChar, parts[0], fixedCellRefChar, parts[1]))
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return &xlsxDefinedName{
+		Data:         strings.Join(refs, ","),
+		Name:         printTitlesName,
+		LocalSheetID: iPtr(sheetIndex - 1),
+	}
+}
+
+// splitDefinedNameRefs splits the comma separated references in the
+// content of a defined name and strips their sheet prefixes, taking
+// care of quoted sheet names that may themselves contain commas or
+// exclamation marks.
+func splitDefinedNameRefs(data string) []string {
+	var refs []string
+	var ref strings.Builder
+	quoted := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '\'':
+			if quoted && i+1 < len(data) && data[i+1] == '\'' {
+				i++
+				continue
+			}
+			quoted = !quoted
+		case quoted:
+		case c == '!':
+			ref.Reset()
+		case c == ',':
+			refs = append(refs, ref.String())
+			ref.Reset()
+		default:
+			ref.WriteByte(c)
+		}
+	}
+	return append(refs, ref.String())
+}
+
+// readPrintDefinedName applies a "_xlnm.Print_Area" or
+// "_xlnm.Print_Titles" defined name to the sheet it belongs to.  It
+// returns false if the name isn't one of those, or can't be
+// represented by PrintArea or PrintTitles, in which case it should be
+// kept as an ordinary defined name.
+func readPrintDefinedName(dn *xlsxDefinedName, sheet *Sheet) bool {
+	refs := splitDefinedNameRefs(dn.Data)
+	switch dn.Name {
+	case printAreaName:
+		if len(refs) != 1 {
+			return false
+		}
+		parts := strings.SplitN(refs[0], cellRangeChar, 2)
+		if len(parts) == 1 {
+			parts = append(parts, parts[0])
+		}
+		return sheet.SetPrintArea(parts[0], parts[1]) == nil
+	case printTitlesName:
+		var rows, cols string
+		for _, ref := range refs {
+			bare := strings.ReplaceAll(ref, fixedCellRefChar, "")
+			switch {
+			case bare == "":
+				return false
+			case strings.Map(intOnlyMapF, bare) == "":
+				cols = ref
+			default:
+				rows = ref
+			}
+		}
+		return sheet.SetPrintTitles(rows, cols) == nil
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPrintSettings(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("SetPrintArea", func(c *qt.C) {
+		s := &Sheet{Name: "Sheet1"}
+		c.Assert(s.SetPrintArea("$A$1", "D20"), qt.IsNil)
+		c.Assert(s.PrintArea, qt.DeepEquals, &PrintArea{TopLeftCell: "A1", BottomRightCell: "D20"})
+		c.Assert(s.SetPrintArea("A1", "nonsense"), qt.Not(qt.IsNil))
+
+		dn := printAreaDefinedName(s, 2)
+		c.Assert(dn.Name, qt.Equals, "_xlnm.Print_Area")
+		c.Assert(dn.Data, qt.Equals, "'Sheet1'!$A$1:$D$20")
+		c.Assert(*dn.LocalSheetID, qt.Equals, 1)
+	})
+
+	c.Run("SetPrintTitles", func(c *qt.C) {
+		s := &Sheet{Name: "It's"}
+		c.Assert(s.SetPrintTitles("1:2", "a"), qt.IsNil)
+		c.Assert(s.PrintTitles, qt.DeepEquals, &PrintTitles{Rows: "1:2", Cols: "A:A"})
+		dn := printTitlesDefinedName(s, 1)
+		c.Assert(dn.Data, qt.Equals, "'It''s'!$A:$A,'It''s'!$1:$2")
+
+		c.Assert(s.SetPrintTitles("", ""), qt.IsNil)
+		c.Assert(s.PrintTitles, qt.IsNil)
+		c.Assert(s.SetPrintTitles("A", ""), qt.Not(qt.IsNil))
+		c.Assert(s.SetPrintTitles("", "1"), qt.Not(qt.IsNil))
+	})
+
+	c.Run("InsertPageBreak", func(c *qt.C) {
+		s := &Sheet{}
+		c.Assert(s.InsertPageBreak("10"), qt.IsNil)
+		c.Assert(s.InsertPageBreak("C5"), qt.IsNil)
+		c.Assert(s.InsertPageBreak("A5"), qt.IsNil)
+		c.Assert(s.InsertPageBreak("E"), qt.IsNil)
+		c.Assert(s.RowBreaks, qt.DeepEquals, []int{4, 9})
+		c.Assert(s.ColBreaks, qt.DeepEquals, []int{2, 4})
+		c.Assert(s.InsertPageBreak("A1"), qt.Not(qt.IsNil))
+		c.Assert(s.InsertPageBreak("A-1"), qt.Not(qt.IsNil))
+
+		// Automatic breaks aren't read as manual ones.
+		c.Assert(readXLSXBreaks(&xlsxBreaks{Brk: []xlsxBreak{
+			{Id: 20, Max: rowBreakMax},
+			{Id: 9, Max: rowBreakMax, Man: true},
+		}}), qt.DeepEquals, []int{9})
+	})
+
+	c.Run("SplitDefinedNameRefs", func(c *qt.C) {
+		c.Assert(splitDefinedNameRefs("'a!,b'!$A:$B,'a!,b'!$1:$1"), qt.DeepEquals, []string{"$A:$B", "$1:$1"})
+		c.Assert(splitDefinedNameRefs("Sheet1!$A$1:$B$2"), qt.DeepEquals, []string{"$A$1:$B$2"})
+	})
+}
+
+func TestPrintSettingsRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "SaveAndRead", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		other, err := file.AddSheet("Other")
+		c.Assert(err, qt.IsNil)
+		other.AddRow().AddCell().SetString("x")
+		sheet, err := file.AddSheet("Data, 2021")
+		c.Assert(err, qt.IsNil)
+		for i := 0; i < 30; i++ {
+			sheet.AddRow().AddCell().SetInt(i)
+		}
+		c.Assert(sheet.SetPrintArea("A1", "C30"), qt.IsNil)
+		c.Assert(sheet.SetPrintTitles("1", "A:B"), qt.IsNil)
+		c.Assert(sheet.InsertPageBreak("C15"), qt.IsNil)
+		c.Assert(file.AddDefinedName(DefinedName{Name: "Global", Data: "'Other'!$A$1"}), qt.IsNil)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["xl/workbook.xml"], `localSheetId="1">&#39;Data, 2021&#39;!$A$1:$C$30`), qt.IsTrue)
+		c.Assert(strings.Contains(parts["xl/worksheets/sheet2.xml"], `<rowBreaks count="1" manualBreakCount="1"><brk id="14" max="16383" man="true"></brk></rowBreaks>`), qt.IsTrue)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		read := file.Sheet["Data, 2021"]
+		c.Assert(read.PrintArea, qt.DeepEquals, &PrintArea{TopLeftCell: "A1", BottomRightCell: "C30"})
+		c.Assert(read.PrintTitles, qt.DeepEquals, &PrintTitles{Rows: "1:1", Cols: "A:B"})
+		c.Assert(read.RowBreaks, qt.DeepEquals, []int{14})
+		c.Assert(read.ColBreaks, qt.DeepEquals, []int{2})
+		c.Assert(file.Sheet["Other"].PrintArea, qt.IsNil)
+		c.Assert(file.DefinedNames, qt.HasLen, 1)
+		c.Assert(file.DefinedNames[0].Name, qt.Equals, "Global")
+	})
+}
@@ -0,0 +1,337 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultApplication is written as the application that created
+// the document when Properties.Extended.Application isn't set.
+const defaultApplication = "Go XLSX"
+
+// propertyTimeLayout is the W3CDTF form in which document properties
+// store dates and times.
+const propertyTimeLayout = "2006-01-02T15:04:05Z"
+
+// Properties holds the document properties of a File, as shown on the
+// "Info" page of Excel's File menu.
+type Properties struct {
+	Core     CoreProperties
+	Extended ExtendedProperties
+	Custom   []CustomProperty
+}
+
+// CoreProperties are the document properties shared by all Office
+// Open XML documents, stored in docProps/core.xml.  If Modified is
+// zero when the file is saved then the time of saving is written
+// instead, and likewise for Created.  A Modified time read from the
+// file is also replaced by the time of saving, unless it is changed.
+type CoreProperties struct {
+	Title          string
+	Subject        string
+	Creator        string
+	Keywords       string
+	Description    string
+	LastModifiedBy string
+	Revision       string
+	Category       string
+	Created        time.Time
+	Modified       time.Time
+}
+
+// ExtendedProperties are the application specific document properties
+// stored in docProps/app.xml.
+type ExtendedProperties struct {
+	Application string
+	Company     string
+	Manager     string
+}
+
+// CustomProperty is a user defined document property, stored in
+// docProps/custom.xml.  Value is one of string, bool, int, int64,
+// float64 or time.Time.
+type CustomProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// SetCustomProperty sets the custom document property called name to
+// value, replacing any existing property of that name.  The value
+// must be a string, bool, int, int64, float64 or time.Time.
+func (f *File) SetCustomProperty(name string, value interface{}) error {
+	if name == "" {
+		return fmt.Errorf("SetCustomProperty: name must not be empty")
+	}
+	switch value.(type) {
+	case string, bool, int, int64, float64, time.Time:
+	default:
+		return fmt.Errorf("SetCustomProperty(%q): unsupported type %T", name, value)
+	}
+	for i := range f.Properties.Custom {
+		if f.Properties.Custom[i].Name == name {
+			f.Properties.Custom[i].Value = value
+			return nil
+		}
+	}
+	f.Properties.Custom = append(f.Properties.Custom, CustomProperty{Name: name, Value: value})
+	return nil
+}
+
+// CustomProperty returns the value of the custom document property
+// called name, and whether it exists.
+func (f *File) CustomProperty(name string) (interface{}, bool) {
+	for _, p := range f.Properties.Custom {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+// writeXMLElement writes a simple element with escaped text content
+// to sb, skipping it altogether when the value is empty.
+func writeXMLElement(sb *strings.Builder, name, attrs, value string) {
+	if value == "" {
+		return
+	}
+	sb.WriteString("<" + name + attrs + ">")
+	xml.EscapeText(sb, []byte(value))
+	sb.WriteString("</" + name + ">")
+}
+
+// makeCorePropertiesXML renders docProps/core.xml.  It is written by
+// hand, rather than with xml.Marshal, because Excel insists on the
+// customary namespace prefixes.
+func (p *Properties) makeCorePropertiesXML(now time.Time) string {
+	modified := p.Core.Modified
+	if modified.IsZero() {
+		modified = now
+	}
+	created := p.Core.Created
+	if created.IsZero() {
+		created = modified
+	}
+	const w3cdtf = ` xsi:type="dcterms:W3CDTF"`
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="` + coreNamespace + `" xmlns:dc="` + dcNamespace + `" xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:dcterms="` + dctermsNamespace + `" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	writeXMLElement(&sb, "dc:title", "", p.Core.Title)
+	writeXMLElement(&sb, "dc:subject", "", p.Core.Subject)
+	writeXMLElement(&sb, "dc:creator", "", p.Core.Creator)
+	writeXMLElement(&sb, "cp:keywords", "", p.Core.Keywords)
+	writeXMLElement(&sb, "dc:description", "", p.Core.Description)
+	writeXMLElement(&sb, "cp:lastModifiedBy", "", p.Core.LastModifiedBy)
+	writeXMLElement(&sb, "cp:revision", "", p.Core.Revision)
+	writeXMLElement(&sb, "dcterms:created", w3cdtf, created.UTC().Format(propertyTimeLayout))
+	writeXMLElement(&sb, "dcterms:modified", w3cdtf, modified.UTC().Format(propertyTimeLayout))
+	writeXMLElement(&sb, "cp:category", "", p.Core.Category)
+	sb.WriteString(`</cp:coreProperties>`)
+	return sb.String()
+}
+
+// makeExtendedPropertiesXML renders docProps/app.xml, whose elements
+// the schema requires in this order.
+func (p *Properties) makeExtendedPropertiesXML() string {
+	application := p.Extended.Application
+	if application == "" {
+		application = defaultApplication
+	}
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="` + extendedNamespace + `" xmlns:vt="` + vtNamespace + `">
+`)
+	for _, e := range []struct{ name, value string }{
+		{"Manager", p.Extended.Manager},
+		{"Company", p.Extended.Company},
+		{"TotalTime", "0"},
+		{"Application", application},
+	} {
+		if e.value == "" {
+			continue
+		}
+		sb.WriteString("  ")
+		writeXMLElement(&sb, e.name, "", e.value)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(`</Properties>`)
+	return sb.String()
+}
+
+// makeCustomPropertiesXML renders docProps/custom.xml, or returns an
+// empty string if there are no custom properties.
+func (p *Properties) makeCustomPropertiesXML() (string, error) {
+	if len(p.Custom) == 0 {
+		return "", nil
+	}
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="` + customNamespace + `" xmlns:vt="` + vtNamespace + `">`)
+	for i, prop := range p.Custom {
+		var vType, value string
+		switch v := prop.Value.(type) {
+		case string:
+			vType, value = "lpwstr", v
+		case bool:
+			vType, value = "bool", strconv.FormatBool(v)
+		case int:
+			vType, value = "i4", strconv.Itoa(v)
+			if v > math.MaxInt32 || v < math.MinInt32 {
+				vType = "i8"
+			}
+		case int64:
+			vType, value = "i8", strconv.FormatInt(v, 10)
+		case float64:
+			vType, value = "r8", strconv.FormatFloat(v, 'g', -1, 64)
+		case time.Time:
+			vType, value = "filetime", v.UTC().Format(propertyTimeLayout)
+		default:
+			return "", fmt.Errorf("custom property %q has unsupported type %T", prop.Name, prop.Value)
+		}
+		sb.WriteString(`<property fmtid="` + customPropertyFmtID + `" pid="` + strconv.Itoa(i+2) + `" name="`)
+		xml.EscapeText(&sb, []byte(prop.Name))
+		sb.WriteString(`"><vt:` + vType + `>`)
+		xml.EscapeText(&sb, []byte(value))
+		sb.WriteString(`</vt:` + vType + `></property>`)
+	}
+	sb.WriteString(`</Properties>`)
+	return sb.String(), nil
+}
+
+// makeDocPropsParts returns the package relationships and document
+// property parts of the file, keyed by part name, adding a content
+// type for the custom properties when there are any.
+func (f *File) makeDocPropsParts(types *xlsxTypes) (map[string]string, error) {
+	custom, err := f.Properties.makeCustomPropertiesXML()
+	if err != nil {
+		return nil, fmt.Errorf("makeDocPropsParts: %w", err)
+	}
+	// A modification time read from the file is out of date once the
+	// file is saved again, unless it has been set since.
+	props := f.Properties
+	if !f.readModified.IsZero() && props.Core.Modified.Equal(f.readModified) {
+		props.Core.Modified = time.Time{}
+	}
+	parts := map[string]string{
+		"_rels/.rels":       TEMPLATE__RELS_DOT_RELS,
+		"docProps/app.xml":  f.Properties.makeExtendedPropertiesXML(),
+		"docProps/core.xml": props.makeCorePropertiesXML(time.Now()),
+	}
+	if custom != "" {
+		parts["docProps/custom.xml"] = custom
+		parts["_rels/.rels"] = strings.Replace(TEMPLATE__RELS_DOT_RELS, "</Relationships>",
+			`  <Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties" Target="docProps/custom.xml"/>
+</Relationships>`, 1)
+		types.Overrides = append(types.Overrides, xlsxOverride{
+			PartName:    "/docProps/custom.xml",
+			ContentType: "application/vnd.openxmlformats-officedocument.custom-properties+xml",
+		})
+	}
+	return parts, nil
+}
+
+// parsePropertyTime parses a date and time as stored in the document
+// properties, returning the zero time if it can't be understood.
+func parsePropertyTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// readPropertiesFromZipFile reads the docProps parts of an XLSX file
+// into a Properties.  Any of the parts may be nil, in which case the
+// corresponding properties are left empty.  The properties are only
+// descriptive, so a part that can't be decoded doesn't stop the file
+// from being read: its properties are left empty, and those of the
+// other parts are kept.
+func readPropertiesFromZipFile(core, app, custom *zip.File) Properties {
+	var props Properties
+
+	decode := func(f *zip.File, v interface{}) error {
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return xml.NewDecoder(rc).Decode(v)
+	}
+
+	if core != nil {
+		xCore := xlsxCoreProperties{}
+		if err := decode(core, &xCore); err == nil {
+			props.Core = CoreProperties{
+				Title:          xCore.Title,
+				Subject:        xCore.Subject,
+				Creator:        xCore.Creator,
+				Keywords:       xCore.Keywords,
+				Description:    xCore.Description,
+				LastModifiedBy: xCore.LastModifiedBy,
+				Revision:       xCore.Revision,
+				Category:       xCore.Category,
+				Created:        parsePropertyTime(xCore.Created),
+				Modified:       parsePropertyTime(xCore.Modified),
+			}
+		}
+	}
+
+	if app != nil {
+		xApp := xlsxExtendedProperties{}
+		if err := decode(app, &xApp); err == nil {
+			props.Extended = ExtendedProperties{
+				Application: xApp.Application,
+				Company:     xApp.Company,
+				Manager:     xApp.Manager,
+			}
+		}
+	}
+
+	if custom != nil {
+		xCustom := xlsxCustomProperties{}
+		if err := decode(custom, &xCustom); err == nil {
+			for _, p := range xCustom.Property {
+				props.Custom = append(props.Custom, CustomProperty{
+					Name:  p.Name,
+					Value: p.Value.value(),
+				})
+			}
+		}
+	}
+	return props
+}
+
+// value converts the variant to the Go type used for it in
+// CustomProperty, falling back to its text if the type is unknown or
+// the value can't be parsed.
+func (v xlsxVariant) value() interface{} {
+	s := strings.TrimSpace(v.Value)
+	switch v.XMLName.Local {
+	case "bool":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case "i1", "i2", "i4", "int", "ui1", "ui2":
+		if i, err := strconv.Atoi(s); err == nil {
+			return i
+		}
+	case "i8", "ui4", "ui8", "uint":
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	case "r4", "r8", "decimal":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "filetime", "date":
+		if t := parsePropertyTime(s); !t.IsZero() {
+			return t
+		}
+	}
+	return v.Value
+}
@@ -0,0 +1,231 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestProperties(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("SetCustomProperty", func(c *qt.C) {
+		f := NewFile()
+		c.Assert(f.SetCustomProperty("Project", "Apollo"), qt.IsNil)
+		c.Assert(f.SetCustomProperty("Project", "Gemini"), qt.IsNil)
+		c.Assert(f.SetCustomProperty("Count", uint(1)), qt.Not(qt.IsNil))
+		c.Assert(f.SetCustomProperty("", 1), qt.Not(qt.IsNil))
+		c.Assert(f.Properties.Custom, qt.HasLen, 1)
+		v, ok := f.CustomProperty("Project")
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(v, qt.Equals, "Gemini")
+		_, ok = f.CustomProperty("Missing")
+		c.Assert(ok, qt.IsFalse)
+	})
+
+	c.Run("CoreDefaultsTimestamps", func(c *qt.C) {
+		p := Properties{}
+		p.Core.Title = "Q1 & Q2"
+		now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		out := p.makeCorePropertiesXML(now)
+		c.Assert(strings.Contains(out, "<dc:title>Q1 &amp; Q2</dc:title>"), qt.IsTrue)
+		c.Assert(strings.Contains(out, `<dcterms:created xsi:type="dcterms:W3CDTF">2021-03-04T05:06:07Z</dcterms:created>`), qt.IsTrue)
+		c.Assert(strings.Contains(out, `<dcterms:modified xsi:type="dcterms:W3CDTF">2021-03-04T05:06:07Z</dcterms:modified>`), qt.IsTrue)
+	})
+
+	c.Run("ExtendedDefaultsApplication", func(c *qt.C) {
+		p := Properties{}
+		c.Assert(p.makeExtendedPropertiesXML(), qt.Equals, TEMPLATE_DOCPROPS_APP)
+	})
+}
+
+func TestPropertiesRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "SaveAndRead", func(c *qt.C, option FileOption) {
+		created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		modified := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		file.Properties.Core = CoreProperties{
+			Title:          "Quarterly report",
+			Subject:        "Sales",
+			Creator:        "Finance",
+			Keywords:       "sales; q2",
+			Description:    "Figures <draft>",
+			LastModifiedBy: "Audit",
+			Revision:       "3",
+			Category:       "Reports",
+			Created:        created,
+			Modified:       modified,
+		}
+		file.Properties.Extended = ExtendedProperties{
+			Application: "Reporter",
+			Company:     "ACME",
+			Manager:     "Boss",
+		}
+		c.Assert(file.SetCustomProperty("Name", "Widget"), qt.IsNil)
+		c.Assert(file.SetCustomProperty("Approved", true), qt.IsNil)
+		c.Assert(file.SetCustomProperty("Count", 42), qt.IsNil)
+		c.Assert(file.SetCustomProperty("Big", int64(1)<<40), qt.IsNil)
+		c.Assert(file.SetCustomProperty("Ratio", 0.25), qt.IsNil)
+		c.Assert(file.SetCustomProperty("Due", created), qt.IsNil)
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["_rels/.rels"], `Target="docProps/custom.xml"`), qt.IsTrue)
+		c.Assert(strings.Contains(parts["[Content_Types].xml"], `PartName="/docProps/custom.xml"`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Properties.Core, qt.DeepEquals, CoreProperties{
+			Title:          "Quarterly report",
+			Subject:        "Sales",
+			Creator:        "Finance",
+			Keywords:       "sales; q2",
+			Description:    "Figures <draft>",
+			LastModifiedBy: "Audit",
+			Revision:       "3",
+			Category:       "Reports",
+			Created:        created,
+			Modified:       modified,
+		})
+		c.Assert(file.Properties.Extended, qt.Equals, ExtendedProperties{
+			Application: "Reporter",
+			Company:     "ACME",
+			Manager:     "Boss",
+		})
+		c.Assert(file.Properties.Custom, qt.DeepEquals, []CustomProperty{
+			{Name: "Name", Value: "Widget"},
+			{Name: "Approved", Value: true},
+			{Name: "Count", Value: 42},
+			{Name: "Big", Value: int64(1) << 40},
+			{Name: "Ratio", Value: 0.25},
+			{Name: "Due", Value: created},
+		})
+	})
+
+	csRunO(c, "NoCustomProperties", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		_, ok := parts["docProps/custom.xml"]
+		c.Assert(ok, qt.IsFalse)
+		c.Assert(parts["_rels/.rels"], qt.Equals, TEMPLATE__RELS_DOT_RELS)
+	})
+}
+
+// replaceZipPart returns the zip archive data with the part called name
+// replaced by content.
+func replaceZipPart(c *qt.C, data []byte, name, content string) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, qt.IsNil)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		w, err := zw.Create(f.Name)
+		c.Assert(err, qt.IsNil)
+		if f.Name == name {
+			_, err = w.Write([]byte(content))
+			c.Assert(err, qt.IsNil)
+			continue
+		}
+		rc, err := f.Open()
+		c.Assert(err, qt.IsNil)
+		_, err = io.Copy(w, rc)
+		c.Assert(err, qt.IsNil)
+		rc.Close()
+	}
+	c.Assert(zw.Close(), qt.IsNil)
+	return buf.Bytes()
+}
+
+func TestReadProperties(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "MalformedPartIsIgnored", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		file.Properties.Core.Title = "Report"
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		data := replaceZipPart(c, buf.Bytes(), "docProps/core.xml", "<cp:coreProperties><dc:title>")
+		file, err = OpenBinary(data, option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Properties.Core, qt.DeepEquals, CoreProperties{})
+		cell, err := file.Sheet["Sheet1"].Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.Value, qt.Equals, "a1")
+	})
+
+	csRunO(c, "OtherPartsSurviveMalformedPart", func(c *qt.C, option FileOption) {
+		created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		file.Properties.Core.Title = "Report"
+		file.Properties.Core.Creator = "Accounts"
+		file.Properties.Core.Created = created
+		file.Properties.Custom = []CustomProperty{{Name: "Project", Value: "Apollo"}}
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		data := replaceZipPart(c, buf.Bytes(), "docProps/custom.xml", "<Properties><property>")
+		file, err = OpenBinary(data, option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Properties.Custom, qt.HasLen, 0)
+		c.Assert(file.Properties.Core.Title, qt.Equals, "Report")
+
+		// Saving again keeps the properties that were read.
+		buf.Reset()
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Properties.Core.Title, qt.Equals, "Report")
+		c.Assert(file.Properties.Core.Creator, qt.Equals, "Accounts")
+		c.Assert(file.Properties.Core.Created, qt.Equals, created)
+	})
+
+	csRunO(c, "ResaveRefreshesModified", func(c *qt.C, option FileOption) {
+		stale := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		file.Properties.Core.Modified = stale
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		c.Assert(file.Properties.Core.Modified, qt.Equals, stale)
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["docProps/core.xml"], "2021-06-07T08:09:10Z</dcterms:modified>"), qt.IsFalse)
+
+		// A time set after reading is written as it is.
+		set := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		file.Properties.Core.Modified = set
+		parts, err = file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(parts["docProps/core.xml"], "2022-01-01T00:00:00Z</dcterms:modified>"), qt.IsTrue)
+	})
+}
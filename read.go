@@ -2,6 +2,7 @@ package xlsx
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
 	"time"
@@ -10,7 +11,8 @@ import (
 var (
 	errNilInterface     = errors.New("nil pointer is not a valid argument")
 	errNotStructPointer = errors.New("argument must be a pointer to struct")
-	errInvalidTag       = errors.New(`invalid tag: must have the format xlsx:idx`)
+	errInvalidTag       = errors.New(`invalid tag: must have the format xlsx:idx or xlsx:"name=header"`)
+	errRequired         = errors.New("required cell is empty")
 )
 
 // XLSXUnmarshaler is the interface implemented for types that can unmarshal a Row
@@ -23,6 +25,8 @@ type XLSXUnmarshaler interface {
 // 此代码期望一个标签 xlsx:"N"，其中 N 是要使用的单元格索引。
 // 支持基本类型如 int、string、float64 和 bool。
 // 通过 parseValue 转换，也支持复杂类型如 map、slice、array。
+// 按列标题映射的字段（xlsx:"name=..."）会被跳过，由 HeaderDecoder 处理。
+// 标签选项 default、required、layout、split、raw 和 formatted 的含义见 fieldTag。
 func (r *Row) ReadStruct(ptr interface{}) error {
 	if ptr == nil {
 		return errNilInterface
@@ -44,15 +48,17 @@ func (r *Row) ReadStruct(ptr interface{}) error {
 	n := v.NumField()
 	for i := 0; i < n; i++ {
 		field := v.Type().Field(i)
-		idx := field.Tag.Get("xlsx")
+		tag, err := parseFieldTag(field.Tag.Get("xlsx"))
+		if err != nil {
+			return err
+		}
 		//do a recursive check for the field if it is a struct or a pointer
 		//even if it doesn't have a tag
 		//ignore if it has a - or empty tag
-		isTime := false
 		switch {
-		case idx == "-":
+		case tag.skip:
 			continue
-		case field.Type.Kind() == reflect.Ptr || field.Type.Kind() == reflect.Struct:
+		case isNestedStruct(field.Type):
 			var structPtr interface{}
 			if !v.Field(i).CanSet() {
 				continue
@@ -62,86 +68,172 @@ func (r *Row) ReadStruct(ptr interface{}) error {
 			} else {
 				structPtr = v.Field(i).Interface()
 			}
-			//check if the container is a time.Time
-			_, isTime = structPtr.(*time.Time)
-			if isTime {
-				break
-			}
 			err := r.ReadStruct(structPtr)
 			if err != nil {
 				return err
 			}
 			continue
-		case len(idx) == 0:
-			continue
 		}
-		pos, err := strconv.Atoi(idx)
-		if err != nil {
-			return errInvalidTag
-		}
-
-		cell := r.GetCell(pos)
-		if cell.Value == "" {
+		//fields mapped by the name of their column are left to
+		//the HeaderDecoder
+		if !tag.hasIndex() {
 			continue
 		}
+
 		fieldV := v.Field(i)
 		//continue if the field is not settable
 		if !fieldV.CanSet() {
 			continue
 		}
-		if isTime {
-			t, err := cell.GetTime(false)
-			if err != nil {
-				return err
-			}
-			if field.Type.Kind() == reflect.Ptr {
-				fieldV.Set(reflect.ValueOf(&t))
-			} else {
-				fieldV.Set(reflect.ValueOf(t))
+		if err := r.readField(r.GetCell(tag.index), fieldV, tag); err != nil {
+			if errors.Is(err, errRequired) {
+				return fmt.Errorf("field %s: %w", field.Name, err)
 			}
-			continue
+			return err
 		}
-		switch field.Type.Kind() {
-		case reflect.String:
-			value, err := cell.FormattedValue()
-			if err != nil {
-				return err
-			}
-			fieldV.SetString(value)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			value, err := cell.Int64()
-			if err != nil {
-				return err
-			}
-			fieldV.SetInt(value)
-		case reflect.Float64:
-			value, err := cell.Float()
-			if err != nil {
-				return err
-			}
-			fieldV.SetFloat(value)
-		case reflect.Bool:
-			value := cell.Bool()
-			fieldV.SetBool(value)
-		case reflect.Map, reflect.Slice, reflect.Array:
-			err := r.setComplexType(cell, fieldV, field.Type)
-			if err != nil {
-				return err
-			}
+	}
+	return nil
+}
+
+// readField sets fieldV, which must be settable, from cell as tag
+// directs.  An empty cell is read as the default value of the tag, if
+// it has one, and is an error if the field is required.  Otherwise the
+// field is left alone.
+func (r *Row) readField(cell *Cell, fieldV reflect.Value, tag fieldTag) error {
+	if cell.Value == "" {
+		switch {
+		case tag.hasDefault:
+			cell = defaultCell(tag.def)
+		case tag.required:
+			return errRequired
+		default:
+			return nil
 		}
 	}
+	return r.readCell(cell, fieldV, tag)
+}
+
+// defaultCell returns a cell, outside of any Row, holding the default
+// value def of a tag: a bool or a number if it reads as one, and
+// otherwise text.
+func defaultCell(def string) *Cell {
+	cell := &Cell{}
+	if b, err := strconv.ParseBool(def); err == nil && !isDigits(def) {
+		cell.SetBool(b)
+	} else if _, err := strconv.ParseFloat(def, 64); err == nil {
+		cell.SetNumeric(def)
+	} else {
+		cell.SetString(def)
+	}
+	return cell
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+)
+
+// readCell sets fieldV, which must be settable, from the value of cell,
+// using the layout, split separator and choice of raw or formatted
+// value given by tag.
+func (r *Row) readCell(cell *Cell, fieldV reflect.Value, tag fieldTag) error {
+	fieldType := fieldV.Type()
+	if fieldType == timeType || fieldType == timePtrType {
+		t, err := timeFromCell(cell, tag.layout)
+		if err != nil {
+			return err
+		}
+		if fieldType.Kind() == reflect.Ptr {
+			fieldV.Set(reflect.ValueOf(&t))
+		} else {
+			fieldV.Set(reflect.ValueOf(t))
+		}
+		return nil
+	}
+	if ok, err := unmarshalCell(cell, fieldV, tag); ok {
+		return err
+	}
+	if fieldType == durationType {
+		d, err := durationFromCell(cell)
+		if err != nil {
+			return err
+		}
+		fieldV.SetInt(int64(d))
+		return nil
+	}
+	switch fieldType.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(fieldType.Elem())
+		if err := r.readCell(cell, elem.Elem(), tag); err != nil {
+			return err
+		}
+		fieldV.Set(elem)
+	case reflect.String:
+		value, err := tag.cellText(cell, false)
+		if err != nil {
+			return err
+		}
+		fieldV.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := cell.Int64()
+		if err != nil {
+			return err
+		}
+		if fieldV.OverflowInt(value) {
+			return fmt.Errorf("%d overflows %s", value, fieldType)
+		}
+		fieldV.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := strconv.ParseUint(cell.Value, 10, 64)
+		if err != nil {
+			return err
+		}
+		if fieldV.OverflowUint(value) {
+			return fmt.Errorf("%d overflows %s", value, fieldType)
+		}
+		fieldV.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := cell.Float()
+		if err != nil {
+			return err
+		}
+		if fieldV.OverflowFloat(value) {
+			return fmt.Errorf("%g overflows %s", value, fieldType)
+		}
+		fieldV.SetFloat(value)
+	case reflect.Bool:
+		value := cell.Bool()
+		fieldV.SetBool(value)
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return r.setComplexType(cell, fieldV, fieldType, tag)
+	}
 	return nil
 }
 
+// timeFromCell 读取单元格中的时间。若给出 layout 且单元格为文本，
+// 则按 layout 用 time.Parse 解析；否则按 Excel 日期序列号读取，并遵循工作簿的 Date1904。
+func timeFromCell(cell *Cell, layout string) (time.Time, error) {
+	if layout != "" && (cell.Type() == CellTypeString || cell.Type() == CellTypeInline) {
+		return time.Parse(layout, cell.Value)
+	}
+	return cell.GetTime(cell.isDate1904())
+}
+
 // setComplexType 从单元格值设置复杂类型（map、slice、array），
-// 使用 parseValue 进行转换。
-func (r *Row) setComplexType(cell *Cell, fieldV reflect.Value, fieldType reflect.Type) error {
-	value, err := cell.FormattedValue()
+// 使用 parseValue 进行转换。若标签给出 split 分隔符，
+// slice 和 array 的元素按该分隔符拆分。
+func (r *Row) setComplexType(cell *Cell, fieldV reflect.Value, fieldType reflect.Type, tag fieldTag) error {
+	value, err := tag.cellText(cell, false)
 	if err != nil {
 		return err
 	}
 	//使用 parseValue 将字符串转换为适当的类型
-	parsedValue := parseValue(value)
+	var parsedValue interface{}
+	if tag.split != "" && fieldType.Kind() != reflect.Map {
+		parsedValue = parseListSep(value, tag.split)
+	} else {
+		parsedValue = parseValue(value)
+	}
 	if parsedValue == nil {
 		return nil
 	}
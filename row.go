@@ -12,8 +12,12 @@ type Row struct {
 	outlineLevel uint8        // OutlineLevel contains the outline level of this Row.  Used for collapsing.
 	isCustom     bool         // isCustom is a flag that is set to true when the Row has been modified
 	customHeight bool         // customHeight is a flag to let the writer know that this row has a custom height
+	autoHeight   bool         // autoHeight marks a custom height set by Sheet.AutoFitRows rather than by the user
+	collapsed    bool         // collapsed marks the Row as the summary of a collapsed outline group
 	num          int          // Num hold the positional number of the Row in the Sheet
 	cellStoreRow CellStoreRow // A reference to the underlying CellStoreRow which handles persistence of the cells
+	style        *Style       // style is the Style applied to cells in the Row that have none of their own
+	numFmt       string       // numFmt is the number format applied along with style
 }
 
 // GetCoordinate returns the y coordinate of the row (the row number). This number is zero based, i.e. the Excel CellID "A1" is in Row 0, not Row 1.
@@ -27,6 +31,7 @@ func (r *Row) setHeight(ht float64) {
 	r.cellStoreRow.Updatable()
 	r.height = ht
 	r.customHeight = true
+	r.autoHeight = false
 }
 
 // SetHeight sets the height of the Row in PostScript points
@@ -65,6 +70,80 @@ func (r *Row) GetOutlineLevel() uint8 {
 	return r.outlineLevel
 }
 
+// SetHidden hides or shows the Row.
+func (r *Row) SetHidden(hidden bool) {
+	r.cellStoreRow.Updatable()
+	r.Hidden = hidden
+	r.isCustom = true
+}
+
+// SetCollapsed marks the Row as the summary row of an outline group
+// whose detail rows are collapsed, or not.  Excel draws the outline
+// button next to the summary row as a "+" when it is set.
+func (r *Row) SetCollapsed(collapsed bool) {
+	r.cellStoreRow.Updatable()
+	r.collapsed = collapsed
+	r.isCustom = true
+}
+
+// GetCollapsed reports whether the Row is the summary row of a
+// collapsed outline group.
+func (r *Row) GetCollapsed() bool {
+	return r.collapsed
+}
+
+// GetStyle returns the Style of the Row, or nil if it has none.
+func (r *Row) GetStyle() *Style {
+	return r.style
+}
+
+// SetStyle sets the Style of the Row.  Excel shows cells in the Row
+// that have no style of their own, including empty cells, in this
+// style, ahead of any column style.
+func (r *Row) SetStyle(style *Style) {
+	r.cellStoreRow.Updatable()
+	r.style = style
+	r.isCustom = true
+}
+
+// GetNumberFormat returns the number format of the Row.
+func (r *Row) GetNumberFormat() string {
+	return r.numFmt
+}
+
+// SetNumberFormat sets the number format that is applied, along with
+// the Row's style, to cells in the Row that have no style of their
+// own.
+func (r *Row) SetNumberFormat(numFmt string) {
+	r.cellStoreRow.Updatable()
+	r.numFmt = numFmt
+	r.isCustom = true
+}
+
+// hasFormat reports whether the Row has a style or number format of
+// its own.
+func (r *Row) hasFormat() bool {
+	return r.style != nil || r.numFmt != ""
+}
+
+// isEmpty reports whether the Row has neither cells nor any settings
+// of its own, so that there's no need to write it.
+func (r *Row) isEmpty() bool {
+	return r.cellStoreRow.CellCount() == 0 && !r.customHeight && !r.Hidden &&
+		!r.collapsed && r.outlineLevel == 0 && !r.hasFormat()
+}
+
+// makeXLSXXfId adds the Row's style and number format to styles,
+// returning the index of the resulting cell format.
+func (r *Row) makeXLSXXfId(styles *xlsxStyleSheet) int {
+	style := r.style
+	if style == nil {
+		style = r.Sheet.File.NewStyle()
+	}
+	xNumFmt := styles.newNumFmt(r.numFmt)
+	return handleStyleForXLSX(style, xNumFmt.NumFmtId, styles)
+}
+
 // AddCell adds a new Cell to the end of the Row
 func (r *Row) AddCell() *Cell {
 	r.cellStoreRow.Updatable()
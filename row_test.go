@@ -1,6 +1,7 @@
 package xlsx
 
 import (
+	"bytes"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -107,4 +108,62 @@ func TestRow(t *testing.T) {
 		c.Assert(row.isCustom, qt.IsTrue)
 
 	})
+
+	csRunO(c, "Test Row Style", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, err := f.AddSheet("MySheet")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		c.Assert(row.GetStyle(), qt.IsNil)
+		style := NewStyle()
+		style.Font.Bold = true
+		style.ApplyFont = true
+		row.SetStyle(style)
+		row.SetNumberFormat("0.00")
+		c.Assert(row.isCustom, qt.IsTrue)
+		row.AddCell().SetFloat(1.5)
+
+		parts, err := f.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(parts["xl/worksheets/sheet1.xml"], qt.Contains, `<row r="1" s="1" customFormat="true">`)
+
+		var buf bytes.Buffer
+		c.Assert(f.Write(&buf), qt.IsNil)
+		f, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		row, err = f.Sheets[0].Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetStyle(), qt.Not(qt.IsNil))
+		c.Assert(row.GetStyle().Font.Bold, qt.IsTrue)
+		c.Assert(row.GetNumberFormat(), qt.Equals, "0.00")
+		cell, err := f.Sheets[0].Cell(0, 3)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.EffectiveStyle().Font.Bold, qt.IsTrue)
+		c.Assert(cell.EffectiveNumberFormat(), qt.Equals, "0.00")
+	})
+
+	// WriteStruct and WriteSlice skip pointers that can't write
+	// themselves, whether nil or not; only Sheet.Marshal follows them.
+	csRunO(c, "TestWriteSkipsPointers", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, _ := f.AddSheet("MySheet")
+		row := sheet.AddRow()
+		c.Assert(row.WriteStruct(&struct {
+			A *int
+			B string
+		}{B: "x"}, -1), qt.Equals, 1)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "x")
+
+		n := 7
+		row = sheet.AddRow()
+		c.Assert(row.WriteStruct(&struct {
+			A *int
+			B string
+		}{A: &n, B: "y"}, -1), qt.Equals, 1)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "y")
+
+		row = sheet.AddRow()
+		c.Assert(row.WriteSlice(&[]interface{}{&n, "z"}, -1), qt.Equals, 2)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "z")
+	})
 }
@@ -0,0 +1,171 @@
+package xlsx
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	errScanNotPointer = errors.New("destination must be a non-nil pointer")
+	errRowsClosed     = errors.New("rows are closed")
+	errNoRow          = errors.New("Scan called without calling Next")
+)
+
+// Scan copies the cells of the Row, starting from the first, into the
+// values pointed at by dest, in the manner of database/sql's Rows.Scan.
+// A nil dest skips its cell.  Cells are converted to the type of each
+// destination as Row.ReadStruct converts them, with dates counted from
+// 1904 if the File says so, and a *interface{} receives a bool, int64,
+// float64, time.Time or string as appropriate.  Empty cells set the
+// destination to its zero value, or call Scan(nil) on an sql.Scanner,
+// so that sql.NullString and the like are left not Valid.
+//
+// The first cell that can't be converted is returned as a *CellError.
+func (r *Row) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		if d == nil {
+			continue
+		}
+		cell := r.GetCell(i)
+		if err := r.scanCell(cell, d); err != nil {
+			cellErr := &CellError{
+				Cell:  GetCellIDStringFromCoords(i, r.num),
+				Value: cell.Value,
+				Err:   err,
+			}
+			if r.Sheet != nil {
+				cellErr.Sheet = r.Sheet.Name
+			}
+			return cellErr
+		}
+	}
+	return nil
+}
+
+// scanCell sets the value that dest points to from cell.
+func (r *Row) scanCell(cell *Cell, dest interface{}) error {
+	if p, ok := dest.(*interface{}); ok {
+		if cell.Value == "" {
+			*p = nil
+			return nil
+		}
+		value, err := cellNativeValue(cell)
+		if err != nil {
+			return err
+		}
+		*p = value
+		return nil
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errScanNotPointer
+	}
+	v = v.Elem()
+	if cell.Value == "" {
+		if scanner, ok := dest.(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	return r.readCell(cell, v, fieldTag{index: -1})
+}
+
+// Rows iterates over the Rows of a Sheet, one at a time, in the manner
+// of database/sql's Rows:
+//
+//	rows := sheet.Rows(xlsx.SkipEmptyRows)
+//	defer rows.Close()
+//	for rows.Next() {
+//		var id int
+//		var name string
+//		if err := rows.Scan(&id, &name); err != nil {
+//			return err
+//		}
+//	}
+//	return rows.Err()
+//
+// Rows are read through the Sheet's CellStore as they are reached, so
+// only the current Row need be held in memory.
+type Rows struct {
+	sheet   *Sheet
+	flags   rowVisitorFlags
+	next    int
+	row     *Row
+	err     error
+	closed  bool
+	started bool
+}
+
+// Rows returns an iterator over the Rows of the Sheet.  It accepts the
+// same options as ForEachRow, such as SkipEmptyRows.
+func (s *Sheet) Rows(options ...RowVisitorOption) *Rows {
+	s.mustBeOpen()
+	rows := &Rows{sheet: s}
+	for _, opt := range options {
+		opt(&rows.flags)
+	}
+	return rows
+}
+
+// Next moves to the next Row, returning false when there are no more
+// or an error stops the iteration, which Err then returns.
+func (rs *Rows) Next() bool {
+	rs.row = nil
+	if rs.closed || rs.err != nil {
+		return false
+	}
+	s := rs.sheet
+	if !rs.started {
+		rs.started = true
+		if s.currentRow != nil {
+			if err := s.cellStore.WriteRow(s.currentRow); err != nil {
+				rs.err = err
+				return false
+			}
+		}
+	}
+	r, next, err := s.nextRow(rs.next, &rs.flags)
+	rs.next = next
+	if err != nil {
+		rs.err = err
+		return false
+	}
+	rs.row = r
+	return r != nil
+}
+
+// Row returns the current Row, or nil if Next hasn't returned true.
+func (rs *Rows) Row() *Row {
+	return rs.row
+}
+
+// Scan copies the cells of the current Row into dest, as Row.Scan does.
+func (rs *Rows) Scan(dest ...interface{}) error {
+	if rs.closed {
+		return errRowsClosed
+	}
+	if rs.row == nil {
+		return errNoRow
+	}
+	return rs.row.Scan(dest...)
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (rs *Rows) Err() error {
+	if rs.err != nil {
+		return fmt.Errorf("Rows: %w", rs.err)
+	}
+	return nil
+}
+
+// Close ends the iteration.  Next returns false once Rows are closed.
+// Closing Rows doesn't close the Sheet, and Close may be called more
+// than once.
+func (rs *Rows) Close() error {
+	rs.closed = true
+	rs.row = nil
+	return nil
+}
@@ -0,0 +1,111 @@
+package xlsx
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestScan(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "RowScan", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Orders")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		row.AddCell().SetInt(7)
+		row.AddCell().SetString("widget")
+		row.AddCell().SetFloat(12.5)
+		when := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+		row.AddCell().SetDateTime(when)
+		row.AddCell()
+		row.AddCell().SetString("skipped")
+		row.AddCell().SetBool(true)
+
+		var (
+			id     int
+			name   string
+			amount float64
+			at     time.Time
+			note   sql.NullString
+			any    interface{}
+		)
+		c.Assert(row.Scan(&id, &name, &amount, &at, &note, nil, &any), qt.IsNil)
+		c.Assert(id, qt.Equals, 7)
+		c.Assert(name, qt.Equals, "widget")
+		c.Assert(amount, qt.Equals, 12.5)
+		c.Assert(at, qt.Equals, when)
+		c.Assert(note.Valid, qt.IsFalse)
+		c.Assert(any, qt.Equals, true)
+
+		err = row.Scan(&name, &id)
+		var cellErr *CellError
+		c.Assert(errors.As(err, &cellErr), qt.IsTrue)
+		c.Assert(cellErr.Cell, qt.Equals, "B1")
+		c.Assert(err, qt.ErrorMatches, `Orders!B1: .*invalid syntax`)
+
+		c.Assert(row.Scan(id), qt.ErrorMatches, `Orders!A1: destination must be a non-nil pointer`)
+	})
+
+	csRunO(c, "Date1904", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		file.Date1904 = true
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		cell := sheet.AddRow().AddCell()
+		cell.SetFloatWithFormat(1, "yyyy-mm-dd")
+
+		var at time.Time
+		row, err := sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.Scan(&at), qt.IsNil)
+		c.Assert(at, qt.Equals, time.Date(1904, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		var s struct {
+			At time.Time `xlsx:"0"`
+		}
+		c.Assert(row.ReadStruct(&s), qt.IsNil)
+		c.Assert(s.At, qt.Equals, at)
+	})
+
+	csRunO(c, "Rows", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		for i := 1; i <= 3; i++ {
+			row := sheet.AddRow()
+			row.AddCell().SetInt(i)
+			row.AddCell().SetString("row")
+		}
+		sheet.AddRow()
+		sheet.AddRow().AddCell().SetInt(5)
+
+		var ids []int
+		rows := sheet.Rows(SkipEmptyRows)
+		c.Assert(rows.Scan(new(int)), qt.ErrorMatches, `Scan called without calling Next`)
+		for rows.Next() {
+			var id int
+			c.Assert(rows.Scan(&id), qt.IsNil)
+			ids = append(ids, id)
+		}
+		c.Assert(rows.Err(), qt.IsNil)
+		c.Assert(rows.Close(), qt.IsNil)
+		c.Assert(ids, qt.DeepEquals, []int{1, 2, 3, 5})
+
+		rows = sheet.Rows()
+		count := 0
+		for rows.Next() {
+			count++
+			c.Assert(rows.Row().num, qt.Equals, count-1)
+			if count == 2 {
+				c.Assert(rows.Close(), qt.IsNil)
+			}
+		}
+		c.Assert(count, qt.Equals, 2)
+		c.Assert(rows.Scan(new(int)), qt.ErrorMatches, `rows are closed`)
+	})
+}
@@ -24,7 +24,14 @@ type Sheet struct {
 	Selected        bool
 	SheetViews      []SheetView
 	SheetFormat     SheetFormat
+	Outline         OutlineSettings
+	TabColor        Color // The colour of the Sheet's tab, if any
 	AutoFilter      *AutoFilter
+	HeaderFooter    *HeaderFooter
+	PrintArea       *PrintArea
+	PrintTitles     *PrintTitles
+	RowBreaks       []int // Zero based index of the first row of each new page
+	ColBreaks       []int // Zero based index of the first column of each new page
 	Relations       []Relation
 	DataValidations []*xlsxDataValidation
 	cellStore       CellStore
@@ -73,8 +80,52 @@ func (s *Sheet) getState() string {
 	return "visible"
 }
 
+// The modes that a SheetView can show a Sheet in.
+const (
+	SheetViewNormal           = "normal"
+	SheetViewPageLayout       = "pageLayout"
+	SheetViewPageBreakPreview = "pageBreakPreview"
+)
+
+// SheetView holds the settings of a window onto a Sheet.  The zero
+// value is the view Excel gives a new sheet.
 type SheetView struct {
 	Pane *Pane
+	// ZoomScale is the magnification of the view, as a percentage
+	// from 10 to 400.  Zero means 100.
+	ZoomScale         int
+	HideGridLines     bool
+	HideRowColHeaders bool
+	HideZeros         bool
+	RightToLeft       bool
+	// View is one of SheetViewNormal, SheetViewPageLayout or
+	// SheetViewPageBreakPreview.  Empty means SheetViewNormal.
+	View string
+	// TopLeftCell is the cell in the top left corner of the view,
+	// such as "C10".  Empty means the top left of the sheet.
+	TopLeftCell string
+	// Selections holds the selection in each pane of the view.
+	Selections []Selection
+}
+
+// Selection is the active cell and the selected ranges in one pane of
+// a SheetView.
+type Selection struct {
+	// Pane is the pane the selection is in: "topLeft",
+	// "topRight", "bottomLeft" or "bottomRight".  Empty means
+	// "topLeft".
+	Pane       string
+	ActiveCell string
+	// SQRef is a space separated list of the selected ranges, such
+	// as "A1:B4 D6".  Empty means just the active cell.
+	SQRef string
+}
+
+// Select makes cell the active cell of the view, selecting ranges, or
+// only the active cell if none are given.  Any other selections are
+// removed.
+func (v *SheetView) Select(cell string, ranges ...string) {
+	v.Selections = []Selection{{ActiveCell: cell, SQRef: strings.Join(ranges, " ")}}
 }
 
 type Pane struct {
@@ -82,7 +133,7 @@ type Pane struct {
 	YSplit      float64
 	TopLeftCell string
 	ActivePane  string
-	State       string // Either "split" or "frozen"
+	State       string // One of PaneStateFrozen, PaneStateFrozenSplit or PaneStateSplit
 }
 
 type SheetFormat struct {
@@ -147,7 +198,9 @@ type rowVisitorFlags struct {
 type RowVisitorOption func(flags *rowVisitorFlags)
 
 // SkipEmptyRows can be passed to the Sheet.ForEachRow function to
-// cause it to skip over empty Rows.
+// cause it to skip over empty Rows.  A Row without cells still counts
+// as non-empty when it is hidden, has a custom height, outline level,
+// style or number format, or is a collapsed summary row.
 func SkipEmptyRows(flags *rowVisitorFlags) {
 	flags.skipEmptyRows = true
 }
@@ -174,12 +227,31 @@ func (s *Sheet) ForEachRow(rv RowVisitor, options ...RowVisitorOption) error {
 			return err
 		}
 	}
-	for i := 0; i < s.MaxRow; i++ {
+	for i := 0; ; {
+		r, next, err := s.nextRow(i, flags)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return nil
+		}
+		err = rv(r)
+		if err != nil {
+			return err
+		}
+		i = next
+	}
+}
+
+// nextRow returns the first Row from index i onwards that flags don't
+// skip, making it the current Row, together with the index to carry on
+// from.  The Row is nil once the end of the Sheet is reached.
+func (s *Sheet) nextRow(i int, flags *rowVisitorFlags) (*Row, int, error) {
+	for ; i < s.MaxRow; i++ {
 		r, err := s.cellStore.ReadRow(makeRowKey(s, i), s)
 		if err != nil {
 			if _, ok := err.(*RowNotFoundError); !ok {
-				return err
-
+				return nil, i, err
 			}
 			if flags.skipEmptyRows {
 				continue
@@ -187,17 +259,14 @@ func (s *Sheet) ForEachRow(rv RowVisitor, options ...RowVisitorOption) error {
 			r = s.cellStore.MakeRow(s)
 			r.num = i
 		}
-		if r.cellStoreRow.CellCount() == 0 && flags.skipEmptyRows {
+		if flags.skipEmptyRows && r.isEmpty() {
 			continue
 		}
 		r.Sheet = s
 		s.setCurrentRow(r)
-		err = rv(r)
-		if err != nil {
-			return err
-		}
+		return r, i + 1, nil
 	}
-	return nil
+	return nil, i, nil
 }
 
 // Add a new Row to a Sheet
@@ -512,9 +581,14 @@ func (s *Sheet) handleMerged() {
 }
 
 func (s *Sheet) makeSheetView(worksheet *xlsxWorksheet) {
+	template := worksheet.SheetViews.SheetView[0]
 	for index, sheetView := range s.SheetViews {
+		if index >= len(worksheet.SheetViews.SheetView) {
+			worksheet.SheetViews.SheetView = append(worksheet.SheetViews.SheetView, template)
+		}
+		xSheetView := &worksheet.SheetViews.SheetView[index]
 		if sheetView.Pane != nil {
-			worksheet.SheetViews.SheetView[index].Pane = &xlsxPane{
+			xSheetView.Pane = &xlsxPane{
 				XSplit:      sheetView.Pane.XSplit,
 				YSplit:      sheetView.Pane.YSplit,
 				TopLeftCell: sheetView.Pane.TopLeftCell,
@@ -523,6 +597,7 @@ func (s *Sheet) makeSheetView(worksheet *xlsxWorksheet) {
 			}
 
 		}
+		sheetView.makeXLSXSheetView(xSheetView)
 	}
 	if s.Selected {
 		worksheet.SheetViews.SheetView[0].TabSelected = true
@@ -530,6 +605,58 @@ func (s *Sheet) makeSheetView(worksheet *xlsxWorksheet) {
 
 }
 
+// makeXLSXSheetView copies the settings of the view, other than its
+// Pane, into xSheetView.
+func (v SheetView) makeXLSXSheetView(xSheetView *xlsxSheetView) {
+	xSheetView.ShowGridLines = !v.HideGridLines
+	xSheetView.ShowRowColHeaders = !v.HideRowColHeaders
+	xSheetView.ShowZeros = !v.HideZeros
+	xSheetView.RightToLeft = v.RightToLeft
+	xSheetView.View = v.View
+	if xSheetView.View == "" {
+		xSheetView.View = SheetViewNormal
+	}
+	if v.TopLeftCell != "" {
+		xSheetView.TopLeftCell = v.TopLeftCell
+	}
+	zoom := float64(v.ZoomScale)
+	if zoom == 0 {
+		zoom = 100
+	}
+	xSheetView.ZoomScale = zoom
+	// Excel remembers the zoom of each kind of view separately.
+	switch xSheetView.View {
+	case SheetViewPageLayout:
+		xSheetView.ZoomScalePageLayoutView = zoom
+	case SheetViewPageBreakPreview:
+		xSheetView.ZoomScaleSheetLayoutView = zoom
+	default:
+		xSheetView.ZoomScaleNormal = zoom
+	}
+	if len(v.Selections) == 0 {
+		return
+	}
+	xSheetView.Selection = make([]xlsxSelection, len(v.Selections))
+	for i, selection := range v.Selections {
+		xSelection := xlsxSelection{
+			Pane:       selection.Pane,
+			ActiveCell: selection.ActiveCell,
+			SQRef:      selection.SQRef,
+		}
+		if xSelection.Pane == "" {
+			xSelection.Pane = "topLeft"
+		}
+		if xSelection.SQRef == "" {
+			xSelection.SQRef = xSelection.ActiveCell
+		}
+		xSheetView.Selection[i] = xSelection
+	}
+}
+
+func (s *Sheet) makeHeaderFooter(worksheet *xlsxWorksheet) {
+	worksheet.HeaderFooter = s.HeaderFooter.makeXLSXHeaderFooter()
+}
+
 func (s *Sheet) makeSheetFormatPr(worksheet *xlsxWorksheet) {
 	if s.SheetFormat.DefaultRowHeight != 0 {
 		worksheet.SheetFormatPr.DefaultRowHeight = s.SheetFormat.DefaultRowHeight
@@ -551,7 +678,7 @@ func (s *Sheet) makeCols(worksheet *xlsxWorksheet, styles *xlsxStyleSheet) (maxL
 			hasNumFmt := len(col.numFmt) > 0
 			if hasNumFmt {
 				if style == nil {
-					style = NewStyle()
+					style = s.File.NewStyle()
 				}
 
 				xNumFmt := styles.newNumFmt(col.numFmt)
@@ -710,17 +837,29 @@ func (s *Sheet) makeRows(worksheet *xlsxWorksheet, styles *xlsxStyleSheet, refTa
 			xRow.CustomHeight = true
 			xRow.Ht = fmt.Sprintf("%g", row.GetHeight())
 		}
+		xRow.Hidden = row.Hidden
 		xRow.OutlineLevel = row.GetOutlineLevel()
+		xRow.Collapsed = row.collapsed
 		if xRow.OutlineLevel > maxLevelRow {
 			maxLevelRow = xRow.OutlineLevel
 		}
+		if row.hasFormat() {
+			xRow.S = row.makeXLSXXfId(styles)
+			xRow.CustomFormat = true
+		}
 		makeC := func(cell *Cell) error {
 			var XfId int
 
 			c := cell.num
 			col := s.Col(c)
-			if col != nil {
+			inheritedNumFmt, inherited := "", false
+			switch {
+			case xRow.CustomFormat:
+				XfId = xRow.S
+				inheritedNumFmt, inherited = row.numFmt, true
+			case col != nil:
 				XfId = col.outXfID
+				inheritedNumFmt, inherited = col.numFmt, true
 			}
 
 			// generate NumFmtId and add new NumFmt
@@ -728,13 +867,15 @@ func (s *Sheet) makeRows(worksheet *xlsxWorksheet, styles *xlsxStyleSheet, refTa
 
 			style := cell.style
 			switch {
+			case cell.styleID != 0:
+				XfId = handleStyleIDForXLSX(cell.styleID, style, xNumFmt.NumFmtId, styles)
 			case style != nil:
 				XfId = handleStyleForXLSX(style, xNumFmt.NumFmtId, styles)
 			case len(cell.NumFmt) == 0:
 				// Do nothing
-			case col == nil:
+			case !inherited:
 				XfId = handleNumFmtIdForXLSX(xNumFmt.NumFmtId, styles)
-			case !compareFormatString(col.numFmt, cell.NumFmt):
+			case !compareFormatString(inheritedNumFmt, cell.NumFmt):
 				XfId = handleNumFmtIdForXLSX(xNumFmt.NumFmtId, styles)
 			}
 
@@ -889,10 +1030,13 @@ func (s *Sheet) MarshalSheet(w io.Writer, refTable *RefTable, styles *xlsxStyleS
 	worksheet := newXlsxWorksheet()
 
 	s.handleMerged()
+	s.makeSheetPr(worksheet)
 	s.makeSheetView(worksheet)
 	s.makeSheetFormatPr(worksheet)
 	maxLevelCol := s.makeCols(worksheet, styles)
 	s.makeDataValidations(worksheet)
+	s.makeHeaderFooter(worksheet)
+	s.makePageBreaks(worksheet)
 	s.prepSheetForMarshalling(maxLevelCol)
 	err := s.prepWorksheetFromRows(worksheet, relations)
 	if err != nil {
@@ -922,10 +1066,13 @@ func (s *Sheet) makeXLSXSheet(refTable *RefTable, styles *xlsxStyleSheet, relati
 	// phantom cells underlying the area covered by the merged cell
 	s.handleMerged()
 
+	s.makeSheetPr(worksheet)
 	s.makeSheetView(worksheet)
 	s.makeSheetFormatPr(worksheet)
 	maxLevelCol := s.makeCols(worksheet, styles)
 	s.makeDataValidations(worksheet)
+	s.makeHeaderFooter(worksheet)
+	s.makePageBreaks(worksheet)
 	s.makeRows(worksheet, styles, refTable, relations, maxLevelCol)
 
 	return worksheet
@@ -946,18 +1093,14 @@ func handleStyleForXLSX(style *Style, NumFmtId int, styles *xlsxStyleSheet) (XfI
 	xCellXf.FillId = fillId
 	xCellXf.BorderId = borderId
 	xCellXf.NumFmtId = NumFmtId
+	if xfId := styles.namedStyleXfId(style.NamedStyle); xfId != nil {
+		xCellXf.XfId = xfId
+	}
 	// apply the numFmtId when it is not the default cellxf
 	if xCellXf.NumFmtId > 0 {
 		xCellXf.ApplyNumberFormat = true
 	}
 
-	xCellXf.Alignment.Horizontal = style.Alignment.Horizontal
-	xCellXf.Alignment.Indent = style.Alignment.Indent
-	xCellXf.Alignment.ShrinkToFit = style.Alignment.ShrinkToFit
-	xCellXf.Alignment.TextRotation = style.Alignment.TextRotation
-	xCellXf.Alignment.Vertical = style.Alignment.Vertical
-	xCellXf.Alignment.WrapText = style.Alignment.WrapText
-
 	XfId = styles.addCellXf(xCellXf)
 	return
 }
@@ -185,7 +185,7 @@ func TestSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -219,7 +219,7 @@ func TestSheet(t *testing.T) {
 		cell := row.AddCell()
 		cell.Value = "A cell!"
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		var output bytes.Buffer
 		err := sheet.MarshalSheet(&output, refTable, styles, nil)
 		c.Assert(err, qt.IsNil)
@@ -256,7 +256,7 @@ func TestSheet(t *testing.T) {
 		cell1.Value = "A cell!"
 
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 
 		var output bytes.Buffer
 		err := sheet.MarshalSheet(&output, refTable, styles, nil)
@@ -286,7 +286,7 @@ func TestSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -304,7 +304,7 @@ func TestSheet(t *testing.T) {
 		cell := row.AddCell()
 		cell.Value = "A cell!"
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		var output strings.Builder
 		err := sheet.MarshalSheet(&output, refTable, styles, nil)
 		c.Assert(err, qt.IsNil)
@@ -326,7 +326,7 @@ func TestSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(2)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 
@@ -345,7 +345,7 @@ func TestSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(2)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.IsNil)
 
@@ -447,7 +447,7 @@ func TestSheet(t *testing.T) {
 		}
 
 		shouldbe := `<?xml version="1.0" encoding="UTF-8"?>
-<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Arial"/><family val="2"/><color theme="1" /><scheme val="minor"/></font><font><sz val="12"/><name val="Verdana"/><family val="0"/><charset val="0"/></font></fonts><fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="lightGray"/></fill></fills><borders count="2"><border><left/><right/><top/><bottom/></border><border><left style="none"></left><right style="none"></right><top style="none"></top><bottom style="none"></bottom></border></borders><cellStyleXfs count="1"><xf applyAlignment="0" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="0" fillId="0" fontId="0" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf></cellStyleXfs><cellXfs count="7"><xf applyAlignment="0" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="0" fillId="0" fontId="0" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="left" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="center" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="right" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="top" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="center" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf></cellXfs></styleSheet>`
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Arial"/><family val="2"/><color theme="1"/><scheme val="minor"/></font><font><sz val="12"/><name val="Verdana"/><family val="0"/><charset val="0"/></font></fonts><fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="lightGray"/></fill></fills><borders count="2"><border><left/><right/><top/><bottom/></border><border><left style="none"></left><right style="none"></right><top style="none"></top><bottom style="none"></bottom></border></borders><cellStyleXfs count="1"><xf applyAlignment="0" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="0" fillId="0" fontId="0" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf></cellStyleXfs><cellXfs count="7"><xf applyAlignment="0" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="0" fillId="0" fontId="0" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="left" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="center" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="right" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="top" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="center" wrapText="0"/></xf><xf applyAlignment="1" applyBorder="0" applyFont="0" applyFill="0" applyNumberFormat="0" applyProtection="0" borderId="1" fillId="0" fontId="1" numFmtId="0"><alignment horizontal="general" indent="0" shrinkToFit="0" textRotation="0" vertical="bottom" wrapText="0"/></xf></cellXfs></styleSheet>`
 
 		expected := bytes.NewBufferString(shouldbe)
 		c.Assert(string(obtained), qt.Equals, expected.String())
@@ -478,7 +478,7 @@ func TestSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(10)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -519,7 +519,7 @@ func TestMakeXLSXSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(4)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -569,7 +569,7 @@ func TestMakeXLSXSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(2)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -615,7 +615,7 @@ func TestMakeXLSXSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -689,7 +689,7 @@ func TestMakeXLSXSheet(t *testing.T) {
 		var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(1)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 		c.Assert(err, qt.Equals, nil)
 		var xSheet xlsxWorksheet
@@ -737,7 +737,7 @@ func TestMakeXLSXSheet(t *testing.T) {
 		// var buf bytes.Buffer
 
 		refTable := NewSharedStringRefTable(9)
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 
 		xSheet := sheet.makeXLSXSheet(refTable, styles, nil)
 		// err := sheet.MarshalSheet(&buf, refTable, styles, nil)
@@ -817,7 +817,7 @@ func TestTemp(t *testing.T) {
 	var buf bytes.Buffer
 
 	refTable := NewSharedStringRefTable(1)
-	styles := newXlsxStyleSheet(nil)
+	styles := newXlsxStyleSheet(nil, nil)
 	err := sheet.MarshalSheet(&buf, refTable, styles, nil)
 	c.Assert(err, qt.Equals, nil)
 	var xSheet xlsxWorksheet
@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSheetView(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("UnmarshalDefaults", func(c *qt.C) {
+		var view xlsxSheetView
+		err := xml.Unmarshal([]byte(`<sheetView workbookViewId="0"/>`), &view)
+		c.Assert(err, qt.IsNil)
+		c.Assert(view.ShowGridLines, qt.IsTrue)
+		c.Assert(view.ShowRowColHeaders, qt.IsTrue)
+		c.Assert(view.ShowZeros, qt.IsTrue)
+		c.Assert(view.ZoomScale, qt.Equals, 100.0)
+
+		err = xml.Unmarshal([]byte(`<sheetView showZeros="0" zoomScale="75" workbookViewId="0"/>`), &view)
+		c.Assert(err, qt.IsNil)
+		c.Assert(view.ShowZeros, qt.IsFalse)
+		c.Assert(view.ShowGridLines, qt.IsTrue)
+		c.Assert(view.ZoomScale, qt.Equals, 75.0)
+	})
+
+	c.Run("Select", func(c *qt.C) {
+		var view SheetView
+		view.Select("B2", "A1:C3", "E5")
+		c.Assert(view.Selections, qt.DeepEquals, []Selection{{ActiveCell: "B2", SQRef: "A1:C3 E5"}})
+		view.Select("D4")
+		c.Assert(view.Selections, qt.DeepEquals, []Selection{{ActiveCell: "D4"}})
+	})
+
+	csRunO(c, "RoundTrip", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetInt(0)
+		sheet.TabColor = NewThemeColor(ThemeColorAccent2, 0.4)
+		view := SheetView{
+			ZoomScale:         150,
+			HideGridLines:     true,
+			HideRowColHeaders: true,
+			HideZeros:         true,
+			RightToLeft:       true,
+			View:              SheetViewPageLayout,
+		}
+		view.Select("B2", "A1:C3 E5")
+		sheet.SheetViews = []SheetView{view}
+
+		parts, err := file.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		xml := parts["xl/worksheets/sheet1.xml"]
+		c.Assert(strings.Contains(xml, `<sheetPr filterMode="false"><tabColor theme="5" tint="0.4"></tabColor>`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `showGridLines="false" showRowColHeaders="false" showZeros="false" rightToLeft="true"`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `view="pageLayout"`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `zoomScale="150" zoomScaleNormal="100" zoomScalePageLayoutView="150"`), qt.IsTrue)
+		c.Assert(strings.Contains(xml, `<selection pane="topLeft" activeCell="B2" activeCellId="0" sqref="A1:C3 E5"></selection>`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		sheet = file.Sheets[0]
+		c.Assert(sheet.TabColor, qt.Equals, NewThemeColor(ThemeColorAccent2, 0.4))
+		c.Assert(sheet.SheetViews, qt.HasLen, 1)
+		got := sheet.SheetViews[0]
+		c.Assert(got.ZoomScale, qt.Equals, 150)
+		c.Assert(got.HideGridLines, qt.IsTrue)
+		c.Assert(got.HideRowColHeaders, qt.IsTrue)
+		c.Assert(got.HideZeros, qt.IsTrue)
+		c.Assert(got.RightToLeft, qt.IsTrue)
+		c.Assert(got.View, qt.Equals, SheetViewPageLayout)
+		c.Assert(got.Selections, qt.DeepEquals, []Selection{{Pane: "topLeft", ActiveCell: "B2", SQRef: "A1:C3 E5"}})
+	})
+
+	csRunO(c, "Defaults", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetInt(0)
+		sheet.SheetViews = []SheetView{{View: SheetViewPageBreakPreview, ZoomScale: 60}}
+
+		var buf bytes.Buffer
+		c.Assert(file.Write(&buf), qt.IsNil)
+		file, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		sheet = file.Sheets[0]
+		c.Assert(sheet.TabColor.IsZero(), qt.IsTrue)
+		got := sheet.SheetViews[0]
+		c.Assert(got.HideGridLines, qt.IsFalse)
+		c.Assert(got.HideZeros, qt.IsFalse)
+		c.Assert(got.View, qt.Equals, SheetViewPageBreakPreview)
+		c.Assert(got.ZoomScale, qt.Equals, 60)
+		c.Assert(got.Selections, qt.DeepEquals, []Selection{{Pane: "topLeft", ActiveCell: "A1", SQRef: "A1"}})
+	})
+}
@@ -0,0 +1,576 @@
+// Package sqldriver registers a read only database/sql driver, named
+// "xlsx", that queries the Sheets of an XLSX file as tables.  It is
+// imported for its side effect:
+//
+//	import _ "github.com/Denghaoran7617/xlsx/sqldriver"
+package sqldriver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Denghaoran7617/xlsx"
+)
+
+var errReadOnly = errors.New("xlsx: the database is read only")
+
+func init() {
+	sql.Register("xlsx", Driver{})
+}
+
+// Driver is a read only database/sql driver that treats each Sheet of
+// an XLSX file as a table, whose columns are named by its first row.
+// It is registered as "xlsx", and the data source name is the path of
+// the file:
+//
+//	db, err := sql.Open("xlsx", "/path/to/sales.xlsx")
+//	...
+//	rows, err := db.Query(`SELECT region, SUM(amount) FROM Sales
+//		WHERE year = ? GROUP BY region ORDER BY 2 DESC`, 2025)
+//
+// Queries are single SELECT statements from one table, with WHERE,
+// GROUP BY, HAVING, ORDER BY and LIMIT ... OFFSET clauses.
+// Expressions may use comparisons, AND, OR, NOT, IN, LIKE, BETWEEN, IS
+// NULL, arithmetic, || to join text, ? placeholders, the aggregates
+// COUNT, SUM, AVG, MIN and MAX, and the functions LOWER, UPPER, TRIM,
+// LENGTH, ABS, ROUND, COALESCE, YEAR, MONTH and DAY.  Names that
+// aren't plain words, such as sheets or headers with spaces in, are
+// quoted "like this", `like this` or [like this], and are matched
+// ignoring case and differences in white space.
+//
+// Values are typed by their cells: booleans are bool, numbers are
+// int64 or float64 unless their number format makes them dates, which
+// are time.Time, empty cells are NULL, and anything else is a string.
+// Text that reads as a number or as a date compares with numbers and
+// dates as such, so a year held as text still matches year = 2025.
+type Driver struct{}
+
+// Open opens the XLSX file at the path name.
+func (Driver) Open(name string) (driver.Conn, error) {
+	file, err := xlsx.OpenFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("Driver.Open: %w", err)
+	}
+	return &sqlConn{file: file, tables: make(map[string]*sqlTable)}, nil
+}
+
+// sqlConn is a connection to an XLSX file.
+type sqlConn struct {
+	file   *xlsx.File
+	tables map[string]*sqlTable
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	q, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	table, err := c.table(q.table)
+	if err != nil {
+		return nil, err
+	}
+	return newSQLStmt(q, table)
+}
+
+func (c *sqlConn) Close() error {
+	for _, sheet := range c.file.Sheets {
+		sheet.Close()
+	}
+	return nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return nil, errReadOnly
+}
+
+// table returns the table for the Sheet called name, ignoring case.
+func (c *sqlConn) table(name string) (*sqlTable, error) {
+	key := normalizeName(name)
+	if t, ok := c.tables[key]; ok {
+		return t, nil
+	}
+	for _, sheet := range c.file.Sheets {
+		if normalizeName(sheet.Name) != key {
+			continue
+		}
+		t, err := newSQLTable(sheet)
+		if err != nil {
+			return nil, err
+		}
+		c.tables[key] = t
+		return t, nil
+	}
+	return nil, fmt.Errorf("no such table: %s", name)
+}
+
+// sqlTable is a Sheet seen as a table.  Its rows are read the first
+// time they are needed.
+type sqlTable struct {
+	sheet   *xlsx.Sheet
+	columns []string
+	index   map[string]int // Column index by normalized header
+	rows    [][]driver.Value
+	loaded  bool
+}
+
+// newSQLTable returns the table for sheet, whose columns are named by
+// its first row.  Columns without a header are named by their letter.
+func newSQLTable(sheet *xlsx.Sheet) (*sqlTable, error) {
+	t := &sqlTable{sheet: sheet, index: make(map[string]int)}
+	if sheet.MaxRow == 0 {
+		return t, nil
+	}
+	t.columns = make([]string, sheet.MaxCol)
+	row, err := sheet.Row(0)
+	if err != nil {
+		return nil, err
+	}
+	err = row.ForEachCell(func(c *xlsx.Cell) error {
+		header, err := c.FormattedValue()
+		if err != nil {
+			return err
+		}
+		col, _ := c.GetCoordinates()
+		for len(t.columns) <= col {
+			t.columns = append(t.columns, "")
+		}
+		t.columns[col] = strings.TrimSpace(header)
+		return nil
+	}, xlsx.SkipEmptyCells)
+	if err != nil {
+		return nil, err
+	}
+	for col, header := range t.columns {
+		if header == "" {
+			header = xlsx.ColIndexToLetters(col)
+			t.columns[col] = header
+		}
+		key := normalizeName(header)
+		if _, exists := t.index[key]; !exists {
+			t.index[key] = col
+		}
+	}
+	return t, nil
+}
+
+// data returns the values of every row of the table after the header,
+// leaving out empty rows.
+func (t *sqlTable) data() ([][]driver.Value, error) {
+	if t.loaded {
+		return t.rows, nil
+	}
+	rows := t.sheet.Rows(xlsx.SkipEmptyRows)
+	defer rows.Close()
+	scanned := make([]interface{}, len(t.columns))
+	dest := make([]interface{}, len(t.columns))
+	for i := range dest {
+		dest[i] = &scanned[i]
+	}
+	for rows.Next() {
+		row := rows.Row()
+		if row.GetCoordinate() == 0 {
+			continue
+		}
+		if err := row.Scan(dest...); err != nil {
+			return nil, err
+		}
+		values := make([]driver.Value, len(t.columns))
+		empty := true
+		for i, v := range scanned {
+			values[i] = v
+			empty = empty && v == nil
+		}
+		if !empty {
+			t.rows = append(t.rows, values)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	t.loaded = true
+	return t.rows, nil
+}
+
+// sqlStmt is a prepared query.
+type sqlStmt struct {
+	q       *sqlSelect
+	table   *sqlTable
+	names   []string
+	exprs   []sqlExpr
+	grouped bool
+}
+
+// newSQLStmt expands * in the select list of q, resolves the names of
+// columns, and ORDER BY aliases and positions, against table.
+func newSQLStmt(q *sqlSelect, table *sqlTable) (*sqlStmt, error) {
+	s := &sqlStmt{q: q, table: table, grouped: len(q.groupBy) > 0 || q.having != nil}
+	for _, item := range q.items {
+		if item.star {
+			for i, name := range table.columns {
+				s.names = append(s.names, name)
+				s.exprs = append(s.exprs, &sqlColumnRef{name: name, index: i})
+			}
+			continue
+		}
+		s.names = append(s.names, item.name)
+		s.exprs = append(s.exprs, item.expr)
+	}
+	for i, term := range q.orderBy {
+		switch e := term.expr.(type) {
+		case *sqlLiteral:
+			n, ok := e.value.(int64)
+			if !ok || n < 1 || int(n) > len(s.exprs) {
+				return nil, fmt.Errorf("ORDER BY term %d is not a column of the result", i+1)
+			}
+			q.orderBy[i].expr = s.exprs[n-1]
+		case *sqlColumnRef:
+			for j, name := range s.names {
+				if strings.EqualFold(name, e.name) {
+					q.orderBy[i].expr = s.exprs[j]
+					break
+				}
+			}
+		}
+	}
+
+	if err := s.walk(q.where, func(e sqlExpr) error {
+		if call, ok := e.(*sqlCall); ok && sqlAggregates[call.name] {
+			return fmt.Errorf("%s can't be used in WHERE", call.name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	exprs := append(append([]sqlExpr{q.where, q.having, q.limit, q.offset}, s.exprs...), q.groupBy...)
+	for _, term := range q.orderBy {
+		exprs = append(exprs, term.expr)
+	}
+	for _, expr := range exprs {
+		err := s.walk(expr, func(e sqlExpr) error {
+			switch e := e.(type) {
+			case *sqlColumnRef:
+				col, ok := table.index[normalizeName(e.name)]
+				if !ok {
+					return fmt.Errorf("no such column: %s", e.name)
+				}
+				e.index = col
+			case *sqlCall:
+				if sqlAggregates[e.name] {
+					s.grouped = true
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// walk calls visit for e and every expression within it.
+func (s *sqlStmt) walk(e sqlExpr, visit func(sqlExpr) error) error {
+	if e == nil {
+		return nil
+	}
+	if err := visit(e); err != nil {
+		return err
+	}
+	var children []sqlExpr
+	switch e := e.(type) {
+	case *sqlUnary:
+		children = []sqlExpr{e.x}
+	case *sqlBinary:
+		children = []sqlExpr{e.left, e.right}
+	case *sqlIsNull:
+		children = []sqlExpr{e.x}
+	case *sqlIn:
+		children = append([]sqlExpr{e.x}, e.list...)
+	case *sqlLike:
+		children = []sqlExpr{e.x, e.pattern}
+	case *sqlCall:
+		// The arguments of an aggregate are evaluated row by row, so
+		// aggregates within them are caught when the query is run.
+		children = e.args
+	}
+	for _, child := range children {
+		if err := s.walk(child, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqlStmt) Close() error {
+	return nil
+}
+
+func (s *sqlStmt) NumInput() int {
+	return s.q.params
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errReadOnly
+}
+
+// Query runs the query with args for its placeholders.
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	data, err := s.table.data()
+	if err != nil {
+		return nil, err
+	}
+	q := s.q
+	ctx := &sqlContext{args: args}
+
+	var matched [][]driver.Value
+	for _, row := range data {
+		ctx.row = row
+		ok, err := s.test(q.where, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+
+	// Each result is evaluated against a context: a row, or a group
+	// of rows for grouped queries.
+	var contexts []*sqlContext
+	if s.grouped {
+		groups := make(map[string]int)
+		for _, row := range matched {
+			ctx := &sqlContext{row: row, args: args}
+			var key strings.Builder
+			for _, e := range q.groupBy {
+				v, err := e.eval(ctx)
+				if err != nil {
+					return nil, err
+				}
+				fmt.Fprintf(&key, "%s\x00", sqlGroupKey(v))
+			}
+			i, ok := groups[key.String()]
+			if !ok {
+				i = len(contexts)
+				groups[key.String()] = i
+				contexts = append(contexts, &sqlContext{row: row, grouped: true, args: args})
+			}
+			contexts[i].group = append(contexts[i].group, row)
+		}
+		// Aggregates over no rows at all still give one result.
+		if len(q.groupBy) == 0 && len(contexts) == 0 {
+			contexts = append(contexts, &sqlContext{grouped: true, args: args})
+		}
+		having := contexts[:0]
+		for _, ctx := range contexts {
+			ok, err := s.test(q.having, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				having = append(having, ctx)
+			}
+		}
+		contexts = having
+	} else {
+		for _, row := range matched {
+			contexts = append(contexts, &sqlContext{row: row, args: args})
+		}
+	}
+
+	type result struct {
+		values, keys []driver.Value
+	}
+	results := make([]result, len(contexts))
+	for i, ctx := range contexts {
+		r := result{values: make([]driver.Value, len(s.exprs)), keys: make([]driver.Value, len(q.orderBy))}
+		for j, e := range s.exprs {
+			if r.values[j], err = e.eval(ctx); err != nil {
+				return nil, err
+			}
+		}
+		for j, term := range q.orderBy {
+			if r.keys[j], err = term.expr.eval(ctx); err != nil {
+				return nil, err
+			}
+		}
+		results[i] = r
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		for k, term := range q.orderBy {
+			a, b := results[i].keys[k], results[j].keys[k]
+			c := 0
+			switch {
+			case a == nil && b == nil:
+			case a == nil:
+				c = -1
+			case b == nil:
+				c = 1
+			default:
+				c = sqlCompare(a, b)
+			}
+			if term.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	offset, err := s.count(q.offset, ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := s.count(q.limit, ctx, len(results))
+	if err != nil {
+		return nil, err
+	}
+	if offset > len(results) {
+		offset = len(results)
+	}
+	if limit > len(results)-offset {
+		limit = len(results) - offset
+	}
+	rows := &sqlRows{columns: s.names}
+	for _, r := range results[offset : offset+limit] {
+		rows.values = append(rows.values, r.values)
+	}
+	return rows, nil
+}
+
+// test reports whether the condition e, if there is one, holds.
+func (s *sqlStmt) test(e sqlExpr, ctx *sqlContext) (bool, error) {
+	if e == nil {
+		return true, nil
+	}
+	v, err := e.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	b, err := sqlBool(v)
+	return b != nil && *b, err
+}
+
+// count evaluates the LIMIT or OFFSET e, which must be a number that
+// isn't negative, or returns def if there's none.
+func (s *sqlStmt) count(e sqlExpr, ctx *sqlContext, def int) (int, error) {
+	if e == nil {
+		return def, nil
+	}
+	v, err := e.eval(&sqlContext{args: ctx.args})
+	if err != nil {
+		return 0, err
+	}
+	f, ok := sqlNumber(v)
+	if !ok || f < 0 || f != float64(int(f)) {
+		return 0, fmt.Errorf("LIMIT and OFFSET must be whole numbers, not %q", sqlString(v))
+	}
+	return int(f), nil
+}
+
+// sqlGroupKey returns the key by which v is grouped, so that equal
+// numbers group together whatever their type.
+func sqlGroupKey(v driver.Value) string {
+	switch x := v.(type) {
+	case nil:
+		return "n"
+	case float64:
+		if x == float64(int64(x)) {
+			return fmt.Sprintf("i%d", int64(x))
+		}
+	case int64:
+		return fmt.Sprintf("i%d", x)
+	}
+	return fmt.Sprintf("%T:%s", v, sqlString(v))
+}
+
+// sqlRows is the result of a query.
+type sqlRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *sqlRows) Columns() []string {
+	return r.columns
+}
+
+func (r *sqlRows) Close() error {
+	r.next = len(r.values)
+	return nil
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the type of the values in the
+// column: BOOLEAN, INTEGER, REAL, DATETIME or TEXT, or "" if they are
+// of mixed types or all NULL.
+func (r *sqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	name := ""
+	for _, row := range r.values {
+		var t string
+		switch row[index].(type) {
+		case nil:
+			continue
+		case bool:
+			t = "BOOLEAN"
+		case int64:
+			t = "INTEGER"
+		case float64:
+			t = "REAL"
+		case time.Time:
+			t = "DATETIME"
+		default:
+			t = "TEXT"
+		}
+		switch {
+		case name == "" || name == t:
+			name = t
+		case (name == "INTEGER" && t == "REAL") || (name == "REAL" && t == "INTEGER"):
+			name = "REAL"
+		default:
+			return ""
+		}
+	}
+	return name
+}
+
+// ColumnTypeScanType returns the Go type that suits the values in the
+// column.
+func (r *sqlRows) ColumnTypeScanType(index int) reflect.Type {
+	switch r.ColumnTypeDatabaseTypeName(index) {
+	case "BOOLEAN":
+		return reflect.TypeOf(false)
+	case "INTEGER":
+		return reflect.TypeOf(int64(0))
+	case "REAL":
+		return reflect.TypeOf(float64(0))
+	case "DATETIME":
+		return reflect.TypeOf(time.Time{})
+	case "TEXT":
+		return reflect.TypeOf("")
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// normalizeName returns the form of the name of a table or column that
+// is matched, ignoring case and differences in white space.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,202 @@
+package sqldriver
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/Denghaoran7617/xlsx"
+)
+
+func makeSQLTestFile(c *qt.C) string {
+	file := xlsx.NewFile()
+	sheet, err := file.AddSheet("Sales")
+	c.Assert(err, qt.IsNil)
+	header := sheet.AddRow()
+	for _, h := range []string{"Region", "Year", "Amount", "Sold On", "Paid"} {
+		header.AddCell().SetString(h)
+	}
+	records := []struct {
+		region string
+		year   interface{}
+		amount float64
+		day    int
+		paid   bool
+	}{
+		{"North", 2025, 100.5, 1, true},
+		{"South", 2025, 200, 2, false},
+		{"North", "2025", 50, 3, true},
+		{"East", 2024, 75.25, 4, true},
+		{"South", 2025, 25, 5, true},
+	}
+	for _, r := range records {
+		row := sheet.AddRow()
+		row.AddCell().SetString(r.region)
+		if s, ok := r.year.(string); ok {
+			row.AddCell().SetString(s)
+		} else {
+			row.AddCell().SetInt(r.year.(int))
+		}
+		row.AddCell().SetFloat(r.amount)
+		row.AddCell().SetDate(time.Date(2025, 1, r.day, 0, 0, 0, 0, time.UTC))
+		row.AddCell().SetBool(r.paid)
+	}
+	sheet.AddRow()
+	row := sheet.AddRow()
+	row.AddCell().SetString("West")
+	row.AddCell().SetInt(2025)
+
+	_, err = file.AddSheet("Empty")
+	c.Assert(err, qt.IsNil)
+	path := filepath.Join(c.TempDir(), "sales.xlsx")
+	c.Assert(file.Save(path), qt.IsNil)
+	return path
+}
+
+func TestSQLDriver(t *testing.T) {
+	c := qt.New(t)
+	db, err := sql.Open("xlsx", makeSQLTestFile(c))
+	c.Assert(err, qt.IsNil)
+	defer db.Close()
+
+	c.Run("GroupBy", func(c *qt.C) {
+		rows, err := db.Query(`SELECT region, SUM(amount) AS total, COUNT(*)
+			FROM sales WHERE year = ? GROUP BY Region ORDER BY total DESC`, 2025)
+		c.Assert(err, qt.IsNil)
+		defer rows.Close()
+		columns, err := rows.Columns()
+		c.Assert(err, qt.IsNil)
+		c.Assert(columns, qt.DeepEquals, []string{"region", "total", "COUNT(*)"})
+		type total struct {
+			Region string
+			Total  sql.NullFloat64
+			Count  int
+		}
+		var totals []total
+		for rows.Next() {
+			var t total
+			c.Assert(rows.Scan(&t.Region, &t.Total, &t.Count), qt.IsNil)
+			totals = append(totals, t)
+		}
+		c.Assert(rows.Err(), qt.IsNil)
+		c.Assert(totals, qt.DeepEquals, []total{
+			{"South", sql.NullFloat64{Float64: 225, Valid: true}, 2},
+			{"North", sql.NullFloat64{Float64: 150.5, Valid: true}, 2},
+			{"West", sql.NullFloat64{}, 1},
+		})
+	})
+
+	c.Run("TypedValues", func(c *qt.C) {
+		rows, err := db.Query(`SELECT * FROM Sales WHERE "Sold On" >= '2025-01-04' ORDER BY 4`)
+		c.Assert(err, qt.IsNil)
+		defer rows.Close()
+		types, err := rows.ColumnTypes()
+		c.Assert(err, qt.IsNil)
+		var names []string
+		for _, t := range types {
+			names = append(names, t.DatabaseTypeName())
+		}
+		c.Assert(names, qt.DeepEquals, []string{"TEXT", "INTEGER", "REAL", "DATETIME", "BOOLEAN"})
+		c.Assert(rows.Next(), qt.IsTrue)
+		var (
+			region string
+			year   int
+			amount float64
+			sold   time.Time
+			paid   bool
+		)
+		c.Assert(rows.Scan(&region, &year, &amount, &sold, &paid), qt.IsNil)
+		c.Assert(region, qt.Equals, "East")
+		c.Assert(year, qt.Equals, 2024)
+		c.Assert(amount, qt.Equals, 75.25)
+		c.Assert(sold, qt.Equals, time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC))
+		c.Assert(paid, qt.IsTrue)
+		c.Assert(rows.Next(), qt.IsTrue)
+		c.Assert(rows.Next(), qt.IsFalse)
+	})
+
+	c.Run("Expressions", func(c *qt.C) {
+		var regions []string
+		rows, err := db.Query(`SELECT UPPER(region) || '-' || year FROM Sales
+			WHERE (region LIKE 'n%' OR region IN ('East', 'West')) AND NOT paid = false
+			AND amount BETWEEN 50 AND 100 ORDER BY amount LIMIT 2 OFFSET 1`)
+		c.Assert(err, qt.IsNil)
+		for rows.Next() {
+			var r string
+			c.Assert(rows.Scan(&r), qt.IsNil)
+			regions = append(regions, r)
+		}
+		c.Assert(rows.Err(), qt.IsNil)
+		c.Assert(regions, qt.DeepEquals, []string{"EAST-2024"})
+
+		var n int
+		var avg float64
+		err = db.QueryRow(`SELECT COUNT(amount), ROUND(AVG(amount), 1) FROM Sales WHERE Amount IS NOT NULL`).Scan(&n, &avg)
+		c.Assert(err, qt.IsNil)
+		c.Assert(n, qt.Equals, 5)
+		c.Assert(avg, qt.Equals, 90.2)
+
+		var max, min time.Time
+		err = db.QueryRow(`SELECT MAX([Sold On]), MIN("sold  on") FROM Sales`).Scan(&max, &min)
+		c.Assert(err, qt.IsNil)
+		c.Assert(max.Day(), qt.Equals, 5)
+		c.Assert(min.Day(), qt.Equals, 1)
+
+		var count int
+		err = db.QueryRow(`SELECT COUNT(*) FROM Sales HAVING COUNT(*) > 100`).Scan(&count)
+		c.Assert(err, qt.Equals, sql.ErrNoRows)
+		err = db.QueryRow(`SELECT COUNT(*) FROM Empty`).Scan(&count)
+		c.Assert(err, qt.IsNil)
+		c.Assert(count, qt.Equals, 0)
+	})
+
+	c.Run("Errors", func(c *qt.C) {
+		_, err := db.Query(`SELECT nope FROM Sales`)
+		c.Assert(err, qt.ErrorMatches, `no such column: nope`)
+		_, err = db.Query(`SELECT * FROM Missing`)
+		c.Assert(err, qt.ErrorMatches, `no such table: Missing`)
+		_, err = db.Query(`SELECT region FROM Sales WHERE SUM(amount) > 1`)
+		c.Assert(err, qt.ErrorMatches, `SUM can't be used in WHERE`)
+		_, err = db.Query(`SELECT region FROM Sales WHERE`)
+		c.Assert(errors.Is(err, errSQLSyntax), qt.IsTrue)
+		_, err = db.Query(`SELECT NOSUCH(region) FROM Sales`)
+		c.Assert(err, qt.ErrorMatches, `syntax error: no such function NOSUCH`)
+		_, err = db.Exec(`DELETE FROM Sales`)
+		c.Assert(err, qt.Not(qt.IsNil))
+		_, err = db.Begin()
+		c.Assert(err, qt.Equals, errReadOnly)
+	})
+}
+
+func TestParseSQL(t *testing.T) {
+	c := qt.New(t)
+
+	q, err := parseSQL(`select a, b + 1 as "b plus", count(*) from [My Sheet] where a = ? and b <> ? group by a having count(*) > 1 order by 2 desc, a limit ? offset 3;`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(q.table, qt.Equals, "My Sheet")
+	c.Assert(q.params, qt.Equals, 3)
+	c.Assert(q.items, qt.HasLen, 3)
+	c.Assert(q.items[1].name, qt.Equals, "b plus")
+	c.Assert(q.items[2].name, qt.Equals, "count(*)")
+	c.Assert(q.groupBy, qt.HasLen, 1)
+	c.Assert(q.orderBy, qt.HasLen, 2)
+	c.Assert(q.orderBy[0].desc, qt.IsTrue)
+
+	for _, bad := range []string{
+		`SELECT`,
+		`SELECT a FROM`,
+		`SELECT a FROM t WHERE a NOT 1`,
+		`SELECT 'open FROM t`,
+		`SELECT a FROM t extra`,
+		`SELECT SUM(*) FROM t`,
+		`SELECT a FROM t; SELECT b FROM t`,
+		`UPDATE t SET a = 1`,
+	} {
+		_, err := parseSQL(bad)
+		c.Assert(errors.Is(err, errSQLSyntax), qt.IsTrue, qt.Commentf("%s: %v", bad, err))
+	}
+}
@@ -0,0 +1,587 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlExpr is an expression in a query.
+type sqlExpr interface {
+	eval(ctx *sqlContext) (driver.Value, error)
+}
+
+// sqlContext is what an expression is evaluated against: a row of the
+// table and, for queries with GROUP BY or aggregates, the rows of the
+// group it stands for.
+type sqlContext struct {
+	row     []driver.Value
+	group   [][]driver.Value
+	grouped bool
+	args    []driver.Value
+}
+
+type sqlLiteral struct {
+	value driver.Value
+}
+
+func (e *sqlLiteral) eval(*sqlContext) (driver.Value, error) {
+	return e.value, nil
+}
+
+// sqlParamRef is the n'th ? placeholder, counting from zero.
+type sqlParamRef struct {
+	n int
+}
+
+func (e *sqlParamRef) eval(ctx *sqlContext) (driver.Value, error) {
+	return ctx.args[e.n], nil
+}
+
+// sqlColumnRef is a column of the table, whose index is found when the
+// query is prepared.
+type sqlColumnRef struct {
+	name  string
+	index int
+}
+
+func (e *sqlColumnRef) eval(ctx *sqlContext) (driver.Value, error) {
+	if e.index >= len(ctx.row) {
+		return nil, nil
+	}
+	return ctx.row[e.index], nil
+}
+
+type sqlUnary struct {
+	op string
+	x  sqlExpr
+}
+
+func (e *sqlUnary) eval(ctx *sqlContext) (driver.Value, error) {
+	v, err := e.x.eval(ctx)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if e.op == "NOT" {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("NOT of a %T", v)
+		}
+		return !b, nil
+	}
+	switch v := v.(type) {
+	case int64:
+		return -v, nil
+	case float64:
+		return -v, nil
+	}
+	f, ok := sqlNumber(v)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %q", sqlString(v))
+	}
+	return -f, nil
+}
+
+type sqlBinary struct {
+	op          string
+	left, right sqlExpr
+}
+
+func (e *sqlBinary) eval(ctx *sqlContext) (driver.Value, error) {
+	left, err := e.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// AND and OR follow SQL's three valued logic, in which NULL is
+	// unknown, and don't look further than they need to.
+	switch e.op {
+	case "AND", "OR":
+		stop := e.op == "OR"
+		l, err := sqlBool(left)
+		if err != nil {
+			return nil, err
+		}
+		if l != nil && *l == stop {
+			return stop, nil
+		}
+		right, err := e.right.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r, err := sqlBool(right)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil && *r == stop {
+			return stop, nil
+		}
+		if l == nil || r == nil {
+			return nil, nil
+		}
+		return !stop, nil
+	}
+	right, err := e.right.eval(ctx)
+	if err != nil || left == nil || right == nil {
+		return nil, err
+	}
+	switch e.op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		c := sqlCompare(left, right)
+		switch e.op {
+		case "=":
+			return c == 0, nil
+		case "<>":
+			return c != 0, nil
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "||":
+		return sqlString(left) + sqlString(right), nil
+	}
+	return sqlArithmetic(e.op, left, right)
+}
+
+// sqlArithmetic applies the operator op, one of + - * / %, to two
+// values that aren't NULL.  Integers stay integers unless a division
+// leaves a remainder, and division by zero gives NULL.
+func sqlArithmetic(op string, left, right driver.Value) (driver.Value, error) {
+	li, lInt := left.(int64)
+	ri, rInt := right.(int64)
+	if lInt && rInt {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "/":
+			if ri == 0 {
+				return nil, nil
+			}
+			if li%ri == 0 {
+				return li / ri, nil
+			}
+		case "%":
+			if ri == 0 {
+				return nil, nil
+			}
+			return li % ri, nil
+		}
+	}
+	lf, ok := sqlNumber(left)
+	if !ok {
+		return nil, fmt.Errorf("cannot use %q in arithmetic", sqlString(left))
+	}
+	rf, ok := sqlNumber(right)
+	if !ok {
+		return nil, fmt.Errorf("cannot use %q in arithmetic", sqlString(right))
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, nil
+		}
+		return lf / rf, nil
+	}
+	if rf == 0 {
+		return nil, nil
+	}
+	return math.Mod(lf, rf), nil
+}
+
+type sqlIsNull struct {
+	x   sqlExpr
+	not bool
+}
+
+func (e *sqlIsNull) eval(ctx *sqlContext) (driver.Value, error) {
+	v, err := e.x.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (v == nil) != e.not, nil
+}
+
+type sqlIn struct {
+	x    sqlExpr
+	list []sqlExpr
+	not  bool
+}
+
+func (e *sqlIn) eval(ctx *sqlContext) (driver.Value, error) {
+	v, err := e.x.eval(ctx)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	sawNull := false
+	for _, item := range e.list {
+		w, err := item.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if w == nil {
+			sawNull = true
+			continue
+		}
+		if sqlCompare(v, w) == 0 {
+			return !e.not, nil
+		}
+	}
+	if sawNull {
+		return nil, nil
+	}
+	return e.not, nil
+}
+
+// sqlLike is a LIKE comparison, in which % matches any run of
+// characters and _ any one character, ignoring case.
+type sqlLike struct {
+	x, pattern sqlExpr
+	not        bool
+}
+
+func (e *sqlLike) eval(ctx *sqlContext) (driver.Value, error) {
+	v, err := e.x.eval(ctx)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	pattern, err := e.pattern.eval(ctx)
+	if err != nil || pattern == nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range sqlString(pattern) {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(sqlString(v)) != e.not, nil
+}
+
+// sqlCall is a call of an aggregate or scalar function.
+type sqlCall struct {
+	name string
+	args []sqlExpr
+	star bool
+}
+
+// sqlAggregates are the functions that summarise the rows of a group.
+var sqlAggregates = map[string]bool{"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true}
+
+// sqlFunctions are the scalar functions and the number of arguments
+// they take, with -1 for any number.
+var sqlFunctions = map[string]int{
+	"LOWER": 1, "UPPER": 1, "TRIM": 1, "LENGTH": 1, "ABS": 1,
+	"ROUND": -1, "COALESCE": -1, "YEAR": 1, "MONTH": 1, "DAY": 1,
+}
+
+// check reports calls of unknown functions or with the wrong number of
+// arguments.
+func (e *sqlCall) check() error {
+	if sqlAggregates[e.name] {
+		if !e.star && len(e.args) != 1 {
+			return fmt.Errorf("%w: %s takes one argument", errSQLSyntax, e.name)
+		}
+		return nil
+	}
+	n, ok := sqlFunctions[e.name]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: no such function %s", errSQLSyntax, e.name)
+	case n >= 0 && len(e.args) != n:
+		return fmt.Errorf("%w: %s takes %d argument(s)", errSQLSyntax, e.name, n)
+	case e.name == "ROUND" && (len(e.args) < 1 || len(e.args) > 2):
+		return fmt.Errorf("%w: ROUND takes one or two arguments", errSQLSyntax)
+	case e.name == "COALESCE" && len(e.args) == 0:
+		return fmt.Errorf("%w: COALESCE takes at least one argument", errSQLSyntax)
+	}
+	return nil
+}
+
+func (e *sqlCall) eval(ctx *sqlContext) (driver.Value, error) {
+	if sqlAggregates[e.name] {
+		return e.aggregate(ctx)
+	}
+	args := make([]driver.Value, len(e.args))
+	for i, arg := range e.args {
+		v, err := arg.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	if e.name == "COALESCE" {
+		for _, v := range args {
+			if v != nil {
+				return v, nil
+			}
+		}
+		return nil, nil
+	}
+	v := args[0]
+	if v == nil {
+		return nil, nil
+	}
+	switch e.name {
+	case "LOWER":
+		return strings.ToLower(sqlString(v)), nil
+	case "UPPER":
+		return strings.ToUpper(sqlString(v)), nil
+	case "TRIM":
+		return strings.TrimSpace(sqlString(v)), nil
+	case "LENGTH":
+		return int64(len([]rune(sqlString(v)))), nil
+	case "YEAR", "MONTH", "DAY":
+		t, ok := sqlTime(v)
+		if !ok {
+			return nil, fmt.Errorf("%s of %q, which isn't a date", e.name, sqlString(v))
+		}
+		switch e.name {
+		case "YEAR":
+			return int64(t.Year()), nil
+		case "MONTH":
+			return int64(t.Month()), nil
+		}
+		return int64(t.Day()), nil
+	}
+	f, ok := sqlNumber(v)
+	if !ok {
+		return nil, fmt.Errorf("%s of %q, which isn't a number", e.name, sqlString(v))
+	}
+	if e.name == "ABS" {
+		if i, ok := v.(int64); ok {
+			if i < 0 {
+				return -i, nil
+			}
+			return i, nil
+		}
+		return math.Abs(f), nil
+	}
+	places := 0.0
+	if len(args) == 2 && args[1] != nil {
+		if places, ok = sqlNumber(args[1]); !ok {
+			return nil, fmt.Errorf("ROUND to %q places", sqlString(args[1]))
+		}
+	}
+	scale := math.Pow(10, math.Trunc(places))
+	return math.Round(f*scale) / scale, nil
+}
+
+// aggregate computes an aggregate function over the rows of the group
+// being evaluated.  NULLs are ignored, and all but COUNT give NULL for
+// a group with no other values.
+func (e *sqlCall) aggregate(ctx *sqlContext) (driver.Value, error) {
+	if !ctx.grouped {
+		return nil, fmt.Errorf("%s can't be used here", e.name)
+	}
+	if e.star {
+		return int64(len(ctx.group)), nil
+	}
+	var (
+		count  int64
+		sumInt int64
+		sum    float64
+		isInt  = true
+		result driver.Value
+	)
+	for _, row := range ctx.group {
+		v, err := e.args[0].eval(&sqlContext{row: row, args: ctx.args})
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		count++
+		switch e.name {
+		case "SUM", "AVG":
+			if i, ok := v.(int64); ok && isInt {
+				sumInt += i
+				sum += float64(i)
+				continue
+			}
+			f, ok := sqlNumber(v)
+			if !ok {
+				return nil, fmt.Errorf("%s of %q, which isn't a number", e.name, sqlString(v))
+			}
+			isInt = false
+			sum += f
+		case "MIN":
+			if result == nil || sqlCompare(v, result) < 0 {
+				result = v
+			}
+		case "MAX":
+			if result == nil || sqlCompare(v, result) > 0 {
+				result = v
+			}
+		}
+	}
+	switch e.name {
+	case "COUNT":
+		return count, nil
+	case "SUM":
+		if count == 0 {
+			return nil, nil
+		}
+		if isInt {
+			return sumInt, nil
+		}
+		return sum, nil
+	case "AVG":
+		if count == 0 {
+			return nil, nil
+		}
+		return sum / float64(count), nil
+	}
+	return result, nil
+}
+
+// sqlBool returns the truth of v, which must be a bool or NULL, which
+// gives nil.
+func sqlBool(v driver.Value) (*bool, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%q isn't true or false", sqlString(v))
+	}
+	return &b, nil
+}
+
+// sqlNumber returns v as a number, if it is one or is text that reads
+// as one.  True and false are one and zero.
+func sqlNumber(v driver.Value) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// sqlTimeLayouts are the layouts in which text is compared with dates.
+var sqlTimeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339Nano,
+}
+
+// sqlTime returns v as a time, if it is one or is text in one of
+// sqlTimeLayouts.
+func sqlTime(v driver.Value) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range sqlTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// sqlString returns the text of v.
+func sqlString(v driver.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(v)
+}
+
+// sqlCompare orders two values that aren't NULL.  Numbers, and text
+// that reads as a number, compare as numbers; dates, and text that
+// reads as a date, as dates; anything else as text.
+func sqlCompare(a, b driver.Value) int {
+	if ai, ok := a.(int64); ok {
+		if bi, ok := b.(int64); ok {
+			switch {
+			case ai < bi:
+				return -1
+			case ai > bi:
+				return 1
+			}
+			return 0
+		}
+	}
+	_, aText := a.(string)
+	_, bText := b.(string)
+	if !aText || !bText {
+		if at, ok := sqlTime(a); ok {
+			if bt, ok := sqlTime(b); ok {
+				switch {
+				case at.Before(bt):
+					return -1
+				case at.After(bt):
+					return 1
+				}
+				return 0
+			}
+		}
+		if af, ok := sqlNumber(a); ok {
+			if bf, ok := sqlNumber(b); ok {
+				switch {
+				case af < bf:
+					return -1
+				case af > bf:
+					return 1
+				}
+				return 0
+			}
+		}
+	}
+	return strings.Compare(sqlString(a), sqlString(b))
+}
@@ -0,0 +1,596 @@
+package sqldriver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var errSQLSyntax = errors.New("syntax error")
+
+// sqlTokenKind is the kind of a sqlToken.
+type sqlTokenKind int
+
+const (
+	sqlTokEOF    sqlTokenKind = iota
+	sqlTokIdent               // A bare identifier or keyword
+	sqlTokQuoted              // An identifier in "quotes", `backquotes` or [brackets]
+	sqlTokNumber
+	sqlTokString
+	sqlTokParam // A ? placeholder
+	sqlTokOp    // Punctuation or an operator
+)
+
+// sqlToken is a token of a query, found at byte offset pos.
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	pos  int
+}
+
+// lexSQL splits query into tokens, ending with an sqlTokEOF token.
+func lexSQL(query string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	runes := []rune(query)
+	// offsets maps an index into runes to a byte offset in query.
+	offsets := make([]int, len(runes)+1)
+	for i, off := 0, 0; i < len(runes); i++ {
+		offsets[i] = off
+		off += len(string(runes[i]))
+		offsets[i+1] = off
+	}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlTokIdent, string(runes[start:i]), offsets[start]})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				i++
+				if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+					i++
+				}
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, sqlToken{sqlTokNumber, string(runes[start:i]), offsets[start]})
+		case r == '\'' || r == '"' || r == '`' || r == '[':
+			end := r
+			kind := sqlTokQuoted
+			if r == '\'' {
+				kind = sqlTokString
+			} else if r == '[' {
+				end = ']'
+			}
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("%w: unterminated %c at offset %d", errSQLSyntax, r, offsets[start])
+				}
+				if runes[i] == end {
+					// A doubled closing quote stands for itself.
+					if end != ']' && i+1 < len(runes) && runes[i+1] == end {
+						b.WriteRune(end)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind, b.String(), offsets[start]})
+		case r == '?':
+			i++
+			tokens = append(tokens, sqlToken{sqlTokParam, "?", offsets[start]})
+		default:
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "<=", ">=", "<>", "!=", "||":
+					i += 2
+					tokens = append(tokens, sqlToken{sqlTokOp, two, offsets[start]})
+					continue
+				}
+			}
+			if !strings.ContainsRune(",()*+-/%=<>;", r) {
+				return nil, fmt.Errorf("%w: unexpected %q at offset %d", errSQLSyntax, r, offsets[start])
+			}
+			i++
+			tokens = append(tokens, sqlToken{sqlTokOp, string(r), offsets[start]})
+		}
+	}
+	return append(tokens, sqlToken{sqlTokEOF, "", len(query)}), nil
+}
+
+// sqlReserved are the keywords that can't be used as bare column
+// names or aliases.
+var sqlReserved = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "BY": true,
+	"HAVING": true, "ORDER": true, "ASC": true, "DESC": true, "LIMIT": true,
+	"OFFSET": true, "AND": true, "OR": true, "NOT": true, "AS": true,
+	"IS": true, "NULL": true, "IN": true, "LIKE": true, "BETWEEN": true,
+	"TRUE": true, "FALSE": true, "DISTINCT": true,
+}
+
+// sqlSelect is a parsed SELECT statement.
+type sqlSelect struct {
+	items   []sqlSelectItem
+	table   string
+	where   sqlExpr
+	groupBy []sqlExpr
+	having  sqlExpr
+	orderBy []sqlOrderTerm
+	limit   sqlExpr
+	offset  sqlExpr
+	params  int // The number of ? placeholders
+}
+
+// sqlSelectItem is a column of the result of a SELECT.  star stands
+// for every column of the table.
+type sqlSelectItem struct {
+	expr sqlExpr
+	name string
+	star bool
+}
+
+// sqlOrderTerm is a term of an ORDER BY clause.
+type sqlOrderTerm struct {
+	expr sqlExpr
+	desc bool
+}
+
+// sqlParser is a recursive descent parser for the subset of SQL that
+// the driver supports.
+type sqlParser struct {
+	query  string
+	tokens []sqlToken
+	i      int
+	params int
+}
+
+// parseSQL parses query, which must be a single SELECT statement.
+func parseSQL(query string) (*sqlSelect, error) {
+	tokens, err := lexSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{query: query, tokens: tokens}
+	stmt, err := p.parseSelect()
+	if err != nil {
+		return nil, err
+	}
+	p.acceptOp(";")
+	if p.peek().kind != sqlTokEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	stmt.params = p.params
+	return stmt, nil
+}
+
+func (p *sqlParser) peek() sqlToken {
+	return p.tokens[p.i]
+}
+
+func (p *sqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w at offset %d: %s", errSQLSyntax, p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+// isKeyword reports whether the next token is the keyword kw.
+func (p *sqlParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == sqlTokIdent && strings.EqualFold(t.text, kw)
+}
+
+// acceptKeyword consumes the next token if it is the keyword kw.
+func (p *sqlParser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		return p.errorf("expected %s", kw)
+	}
+	return nil
+}
+
+// acceptOp consumes the next token if it is the operator op.
+func (p *sqlParser) acceptOp(op string) bool {
+	if t := p.peek(); t.kind == sqlTokOp && t.text == op {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) expectOp(op string) error {
+	if !p.acceptOp(op) {
+		return p.errorf("expected %q", op)
+	}
+	return nil
+}
+
+// parseName parses an identifier, bare or quoted.
+func (p *sqlParser) parseName() (string, bool) {
+	t := p.peek()
+	switch {
+	case t.kind == sqlTokQuoted:
+	case t.kind == sqlTokIdent && !sqlReserved[strings.ToUpper(t.text)]:
+	default:
+		return "", false
+	}
+	p.i++
+	return t.text, true
+}
+
+func (p *sqlParser) parseSelect() (*sqlSelect, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	if p.isKeyword("DISTINCT") {
+		return nil, p.errorf("DISTINCT is not supported")
+	}
+	stmt := &sqlSelect{}
+	for {
+		if p.acceptOp("*") {
+			stmt.items = append(stmt.items, sqlSelectItem{star: true})
+		} else {
+			start := p.peek().pos
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			item := sqlSelectItem{expr: e, name: strings.TrimSpace(p.query[start:p.peek().pos])}
+			if ref, ok := e.(*sqlColumnRef); ok {
+				item.name = ref.name
+			}
+			if p.acceptKeyword("AS") {
+				name, ok := p.parseName()
+				if !ok {
+					return nil, p.errorf("expected an alias after AS")
+				}
+				item.name = name
+			} else if name, ok := p.parseName(); ok {
+				item.name = name
+			}
+			stmt.items = append(stmt.items, item)
+		}
+		if !p.acceptOp(",") {
+			break
+		}
+	}
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	table, ok := p.parseName()
+	if !ok {
+		return nil, p.errorf("expected a table name")
+	}
+	stmt.table = table
+	var err error
+	if p.acceptKeyword("WHERE") {
+		if stmt.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("GROUP") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if stmt.groupBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("HAVING") {
+		if stmt.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			term := sqlOrderTerm{expr: e}
+			if p.acceptKeyword("DESC") {
+				term.desc = true
+			} else {
+				p.acceptKeyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, term)
+			if !p.acceptOp(",") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("LIMIT") {
+		if stmt.limit, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("OFFSET") {
+			if stmt.offset, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stmt, nil
+}
+
+func (p *sqlParser) parseExprList() ([]sqlExpr, error) {
+	var list []sqlExpr
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if !p.acceptOp(",") {
+			return list, nil
+		}
+	}
+}
+
+func (p *sqlParser) parseExpr() (sqlExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseAnd() (sqlExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseNot() (sqlExpr, error) {
+	if p.acceptKeyword("NOT") {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &sqlUnary{op: "NOT", x: e}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *sqlParser) parseComparison() (sqlExpr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == sqlTokOp {
+		switch t.text {
+		case "=", "<>", "!=", "<", "<=", ">", ">=":
+			p.i++
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := t.text
+			if op == "!=" {
+				op = "<>"
+			}
+			return &sqlBinary{op: op, left: left, right: right}, nil
+		}
+	}
+	if p.acceptKeyword("IS") {
+		not := p.acceptKeyword("NOT")
+		if err := p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return &sqlIsNull{x: left, not: not}, nil
+	}
+	not := p.acceptKeyword("NOT")
+	switch {
+	case p.acceptKeyword("IN"):
+		if err := p.expectOp("("); err != nil {
+			return nil, err
+		}
+		list, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return &sqlIn{x: left, list: list, not: not}, nil
+	case p.acceptKeyword("LIKE"):
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &sqlLike{x: left, pattern: pattern, not: not}, nil
+	case p.acceptKeyword("BETWEEN"):
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		var e sqlExpr = &sqlBinary{op: "AND",
+			left:  &sqlBinary{op: ">=", left: left, right: low},
+			right: &sqlBinary{op: "<=", left: left, right: high},
+		}
+		if not {
+			e = &sqlUnary{op: "NOT", x: e}
+		}
+		return e, nil
+	case not:
+		return nil, p.errorf("expected IN, LIKE or BETWEEN after NOT")
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseAdditive() (sqlExpr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != sqlTokOp || (t.text != "+" && t.text != "-" && t.text != "||") {
+			return left, nil
+		}
+		p.i++
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: t.text, left: left, right: right}
+	}
+}
+
+func (p *sqlParser) parseMultiplicative() (sqlExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != sqlTokOp || (t.text != "*" && t.text != "/" && t.text != "%") {
+			return left, nil
+		}
+		p.i++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: t.text, left: left, right: right}
+	}
+}
+
+func (p *sqlParser) parseUnary() (sqlExpr, error) {
+	if p.acceptOp("-") {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &sqlUnary{op: "-", x: e}, nil
+	}
+	if p.acceptOp("+") {
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *sqlParser) parsePrimary() (sqlExpr, error) {
+	t := p.peek()
+	switch t.kind {
+	case sqlTokNumber:
+		p.i++
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &sqlLiteral{value: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad number %q", errSQLSyntax, t.text)
+		}
+		return &sqlLiteral{value: f}, nil
+	case sqlTokString:
+		p.i++
+		return &sqlLiteral{value: t.text}, nil
+	case sqlTokParam:
+		p.i++
+		p.params++
+		return &sqlParamRef{n: p.params - 1}, nil
+	case sqlTokQuoted:
+		p.i++
+		return &sqlColumnRef{name: t.text}, nil
+	case sqlTokOp:
+		if p.acceptOp("(") {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expectOp(")")
+		}
+	case sqlTokIdent:
+		switch upper := strings.ToUpper(t.text); {
+		case upper == "NULL":
+			p.i++
+			return &sqlLiteral{}, nil
+		case upper == "TRUE" || upper == "FALSE":
+			p.i++
+			return &sqlLiteral{value: upper == "TRUE"}, nil
+		case sqlReserved[upper]:
+		case p.tokens[p.i+1].kind == sqlTokOp && p.tokens[p.i+1].text == "(":
+			p.i += 2
+			call := &sqlCall{name: upper}
+			if p.acceptOp("*") {
+				if upper != "COUNT" {
+					return nil, p.errorf("only COUNT takes *")
+				}
+				call.star = true
+			} else if !p.acceptOp(")") {
+				args, err := p.parseExprList()
+				if err != nil {
+					return nil, err
+				}
+				call.args = args
+			} else {
+				p.i--
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return call, call.check()
+		default:
+			p.i++
+			return &sqlColumnRef{name: t.text}, nil
+		}
+	}
+	if t.kind == sqlTokEOF {
+		return nil, p.errorf("unexpected end of query")
+	}
+	return nil, p.errorf("unexpected %q", t.text)
+}
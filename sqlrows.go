@@ -0,0 +1,278 @@
+package xlsx
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SQLRowsOptions control Sheet.WriteSQLRows.
+type SQLRowsOptions struct {
+	// NoHeader leaves out the header row of column names.
+	NoHeader bool
+	// HeaderStyle is the style of the header row's cells, if not nil.
+	HeaderStyle *Style
+	// SplitSheets carries on writing to new Sheets, named after the
+	// first with " (2)", " (3)" and so on, when a Sheet is full,
+	// rather than failing.  Each new Sheet has its own header row.
+	SplitSheets bool
+	// MaxRows is the most rows that a Sheet may hold.  It defaults to
+	// Excel2006MaxRowCount.
+	MaxRows int
+}
+
+// sqlColumnKind is how the values of a database column are written.
+type sqlColumnKind int
+
+const (
+	sqlColumnAny sqlColumnKind = iota
+	sqlColumnInt
+	sqlColumnFloat
+	sqlColumnDecimal
+	sqlColumnBool
+	sqlColumnDate
+	sqlColumnDateTime
+	sqlColumnTime
+	sqlColumnText
+)
+
+// sqlColumn is a column of a query's result, with the number format
+// its cells are given, if any.
+type sqlColumn struct {
+	name   string
+	kind   sqlColumnKind
+	format string
+}
+
+// WriteSQLRows appends a row to the Sheet for each row of rows, after
+// a header row of column names, and returns the number of rows of data
+// written.  rows are read one at a time, so results of any size can be
+// written to a Sheet with a CellStore that doesn't keep them in memory.
+// rows are not closed.
+//
+// Cells are typed by the database type of their columns: DECIMAL and
+// NUMERIC values are numbers with as many decimal places shown as the
+// column's scale, DATE, DATETIME, TIMESTAMP and TIME values are dates
+// and times with matching formats, showing the wall clock time of the
+// location the driver gives them in, BOOLEAN values are booleans, and
+// integers and floating point numbers are numbers.  Columns of other
+// types are written as the Go values that the driver gives.  NULL
+// gives an empty cell.
+//
+// A Sheet may hold no more than MaxRows rows, Excel's limit unless the
+// options give a lower one.  When the Sheet is full, WriteSQLRows
+// fails, unless SplitSheets is set.
+func (s *Sheet) WriteSQLRows(rows *sql.Rows, opts SQLRowsOptions) (int, error) {
+	s.mustBeOpen()
+	maxRows := opts.MaxRows
+	if maxRows <= 0 || maxRows > Excel2006MaxRowCount {
+		maxRows = Excel2006MaxRowCount
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, fmt.Errorf("WriteSQLRows: %w", err)
+	}
+	columns := make([]sqlColumn, len(types))
+	for i, t := range types {
+		columns[i] = sqlColumnFor(t)
+	}
+
+	sheet := s
+	writeHeader := func() {
+		if opts.NoHeader {
+			return
+		}
+		row := sheet.AddRow()
+		for _, column := range columns {
+			cell := row.AddCell()
+			cell.SetString(column.name)
+			if opts.HeaderStyle != nil {
+				cell.SetStyle(opts.HeaderStyle)
+			}
+		}
+	}
+	if sheet.MaxRow < maxRows {
+		writeHeader()
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	count := 0
+	for part := 2; rows.Next(); {
+		if sheet.MaxRow >= maxRows {
+			if !opts.SplitSheets {
+				return count, fmt.Errorf("WriteSQLRows: sheet %q is full after %d rows", sheet.Name, count)
+			}
+			next, err := s.File.AddSheet(splitSheetName(s.Name, part))
+			if err != nil {
+				return count, fmt.Errorf("WriteSQLRows: %w", err)
+			}
+			part++
+			sheet = next
+			writeHeader()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return count, fmt.Errorf("WriteSQLRows: %w", err)
+		}
+		row := sheet.AddRow()
+		for i, column := range columns {
+			cell := row.AddCell()
+			if err := column.set(cell, values[i]); err != nil {
+				return count, fmt.Errorf("WriteSQLRows: cell %s: column %s: %w",
+					GetCellIDStringFromCoords(i, row.num), column.name, err)
+			}
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("WriteSQLRows: %w", err)
+	}
+	return count, nil
+}
+
+// splitSheetName returns the name of the part'th Sheet that the rows
+// written to the Sheet called name are split across, shortening name
+// to keep within the length allowed.
+func splitSheetName(name string, part int) string {
+	suffix := " (" + strconv.Itoa(part) + ")"
+	for utf8.RuneCountInString(name)+len(suffix) > 31 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return strings.TrimRight(name, " ") + suffix
+}
+
+// sqlColumnFor returns how the values of the column described by t are
+// written, from its database type name.
+func sqlColumnFor(t *sql.ColumnType) sqlColumn {
+	column := sqlColumn{name: t.Name()}
+	typeName := strings.ToUpper(strings.TrimSpace(t.DatabaseTypeName()))
+	if i := strings.IndexByte(typeName, '('); i >= 0 {
+		typeName = strings.TrimSpace(typeName[:i])
+	}
+	switch {
+	case typeName == "DECIMAL" || typeName == "NUMERIC" || typeName == "NUMBER" || typeName == "MONEY":
+		column.kind = sqlColumnDecimal
+		// Without a scale the number is left in the General format.
+		if _, scale, ok := t.DecimalSize(); ok {
+			column.format = "0"
+			if scale > 0 {
+				column.format += "." + strings.Repeat("0", int(scale))
+			}
+		}
+	case strings.HasPrefix(typeName, "BOOL") || typeName == "BIT":
+		column.kind = sqlColumnBool
+	case typeName == "DATE":
+		column.kind = sqlColumnDate
+		column.format = "yyyy-mm-dd"
+	case strings.HasPrefix(typeName, "TIMESTAMP") || strings.HasPrefix(typeName, "DATETIME"):
+		column.kind = sqlColumnDateTime
+		column.format = "yyyy-mm-dd hh:mm:ss"
+	case typeName == "TIME" || typeName == "TIMETZ":
+		column.kind = sqlColumnTime
+		column.format = builtInNumFmt[21]
+	case strings.HasSuffix(typeName, "INT") || strings.HasSuffix(typeName, "INTEGER") || typeName == "SERIAL" || typeName == "BIGSERIAL":
+		column.kind = sqlColumnInt
+	case typeName == "FLOAT" || typeName == "REAL" || strings.HasPrefix(typeName, "DOUBLE") || typeName == "FLOAT4" || typeName == "FLOAT8":
+		column.kind = sqlColumnFloat
+	case strings.Contains(typeName, "CHAR") || strings.Contains(typeName, "TEXT") || typeName == "UUID" || typeName == "STRING":
+		column.kind = sqlColumnText
+	}
+	return column
+}
+
+// sqlTimeValueLayouts are the layouts of dates and times that drivers
+// give as text.
+var sqlTimeValueLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// set writes v, a value scanned from the column, to cell.
+func (column sqlColumn) set(cell *Cell, v interface{}) error {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+	if v == nil {
+		return nil
+	}
+	switch column.kind {
+	case sqlColumnDecimal, sqlColumnFloat, sqlColumnInt:
+		if text, ok := v.(string); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil {
+				return err
+			}
+			if column.kind == sqlColumnInt {
+				if i, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64); err == nil {
+					cell.SetInt64(i)
+					return nil
+				}
+			}
+			v = f
+		}
+		cell.SetValue(v)
+		if column.format != "" {
+			cell.SetFormat(column.format)
+		}
+		return nil
+	case sqlColumnBool:
+		switch b := v.(type) {
+		case bool:
+			cell.SetBool(b)
+		case int64:
+			cell.SetBool(b != 0)
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(b))
+			if err != nil {
+				return err
+			}
+			cell.SetBool(parsed)
+		default:
+			cell.SetValue(v)
+		}
+		return nil
+	case sqlColumnDate, sqlColumnDateTime, sqlColumnTime:
+		t, ok := v.(time.Time)
+		if text, isText := v.(string); isText {
+			for _, layout := range sqlTimeValueLayouts {
+				var err error
+				if t, err = time.Parse(layout, strings.TrimSpace(text)); err == nil {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				return fmt.Errorf("%q is not a date or time", text)
+			}
+		}
+		if !ok {
+			cell.SetValue(v)
+			return nil
+		}
+		if column.kind == sqlColumnTime {
+			h, m, sec := t.Clock()
+			day := float64(h*3600+m*60+sec) + float64(t.Nanosecond())/1e9
+			cell.SetDateTimeWithFormat(day/86400, column.format)
+			return nil
+		}
+		cell.SetDateWithOptions(t, DateTimeOptions{Location: t.Location(), ExcelTimeFormat: column.format})
+		return nil
+	case sqlColumnText:
+		if text, ok := v.(string); ok {
+			cell.SetString(text)
+			return nil
+		}
+	}
+	cell.SetValue(v)
+	return nil
+}
@@ -0,0 +1,205 @@
+package xlsx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// stubColumn is a column of the results of stubDriver.
+type stubColumn struct {
+	name, typ    string
+	scale        int64
+	hasPrecision bool
+}
+
+// stubResults are the results of a query of stubDriver, by query.
+var stubResults = map[string]struct {
+	columns []stubColumn
+	rows    [][]driver.Value
+}{
+	"typed": {
+		columns: []stubColumn{
+			{name: "id", typ: "INT"},
+			{name: "price", typ: "DECIMAL", scale: 2, hasPrecision: true},
+			{name: "sold", typ: "DATE"},
+			{name: "at", typ: "TIMESTAMP"},
+			{name: "paid", typ: "BOOLEAN"},
+			{name: "note", typ: "VARCHAR"},
+			{name: "opens", typ: "TIME"},
+			{name: "ratio", typ: "DOUBLE"},
+		},
+		rows: [][]driver.Value{
+			{int64(1), []byte("1234.5"), time.Date(2025, 3, 4, 0, 0, 0, 0, time.FixedZone("AEST", 10*3600)),
+				"2025-03-04 13:30:00", int64(1), nil, "13:30:00", 0.25},
+			{int64(2), []byte("7"), nil, time.Date(2025, 3, 5, 6, 0, 0, 0, time.FixedZone("CET", 3600)),
+				false, []byte("0042"), nil, nil},
+		},
+	},
+	"counting": {
+		columns: []stubColumn{{name: "n", typ: "BIGINT"}},
+		rows:    [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {int64(5)}},
+	},
+}
+
+// errStubReadOnly is returned by stubDriver for anything but queries.
+var errStubReadOnly = errors.New("stub: read only")
+
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(query string) (driver.Stmt, error) { return stubStmt(query), nil }
+func (stubConn) Close() error                              { return nil }
+func (stubConn) Begin() (driver.Tx, error)                 { return nil, errStubReadOnly }
+
+type stubStmt string
+
+func (stubStmt) Close() error                               { return nil }
+func (stubStmt) NumInput() int                              { return 0 }
+func (stubStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errStubReadOnly }
+func (s stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	results := stubResults[string(s)]
+	return &stubRows{columns: results.columns, rows: results.rows}, nil
+}
+
+type stubRows struct {
+	columns []stubColumn
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+func (r *stubRows) Close() error { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func (r *stubRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columns[index].typ
+}
+
+func (r *stubRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	return 10, r.columns[index].scale, r.columns[index].hasPrecision
+}
+
+func init() {
+	sql.Register("xlsxstub", stubDriver{})
+}
+
+func TestWriteSQLRows(t *testing.T) {
+	c := qt.New(t)
+	db, err := sql.Open("xlsxstub", "")
+	c.Assert(err, qt.IsNil)
+	defer db.Close()
+
+	csRunO(c, "Types", func(c *qt.C, option FileOption) {
+		rows, err := db.Query("typed")
+		c.Assert(err, qt.IsNil)
+		defer rows.Close()
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Export")
+		c.Assert(err, qt.IsNil)
+		n, err := sheet.WriteSQLRows(rows, SQLRowsOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(n, qt.Equals, 2)
+		c.Assert(sheet.MaxRow, qt.Equals, 3)
+
+		row, err := sheet.Row(0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(1).Value, qt.Equals, "price")
+
+		row, err = sheet.Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "1")
+		price := row.GetCell(1)
+		c.Assert(price.Type(), qt.Equals, CellTypeNumeric)
+		c.Assert(price.NumFmt, qt.Equals, "0.00")
+		formatted, err := price.FormattedValue()
+		c.Assert(err, qt.IsNil)
+		c.Assert(formatted, qt.Equals, "1234.50")
+		sold, err := row.GetCell(2).GetTime(false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(sold, qt.Equals, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC))
+		c.Assert(row.GetCell(2).NumFmt, qt.Equals, "yyyy-mm-dd")
+		at, err := row.GetCell(3).GetTime(false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(at, qt.Equals, time.Date(2025, 3, 4, 13, 30, 0, 0, time.UTC))
+		c.Assert(row.GetCell(4).Type(), qt.Equals, CellTypeBool)
+		c.Assert(row.GetCell(4).Bool(), qt.IsTrue)
+		c.Assert(row.GetCell(5).Value, qt.Equals, "")
+		c.Assert(row.GetCell(6).Value, qt.Equals, "0.5625")
+		c.Assert(row.GetCell(6).IsTime(), qt.IsTrue)
+		c.Assert(row.GetCell(7).Value, qt.Equals, "0.25")
+
+		row, err = sheet.Row(2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(row.GetCell(1).Value, qt.Equals, "7")
+		c.Assert(row.GetCell(2).Value, qt.Equals, "")
+		// Times keep the wall clock of their own location.
+		at, err = row.GetCell(3).GetTime(false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(at, qt.Equals, time.Date(2025, 3, 5, 6, 0, 0, 0, time.UTC))
+		c.Assert(row.GetCell(4).Bool(), qt.IsFalse)
+		c.Assert(row.GetCell(5).Type(), qt.Equals, CellTypeString)
+		c.Assert(row.GetCell(5).Value, qt.Equals, "0042")
+	})
+
+	csRunO(c, "Split", func(c *qt.C, option FileOption) {
+		rows, err := db.Query("counting")
+		c.Assert(err, qt.IsNil)
+		defer rows.Close()
+		file := NewFile(option)
+		sheet, err := file.AddSheet("A sheet with a rather long name")
+		c.Assert(err, qt.IsNil)
+		n, err := sheet.WriteSQLRows(rows, SQLRowsOptions{SplitSheets: true, MaxRows: 3})
+		c.Assert(err, qt.IsNil)
+		c.Assert(n, qt.Equals, 5)
+		var names []string
+		var sizes []int
+		for _, s := range file.Sheets {
+			names = append(names, s.Name)
+			sizes = append(sizes, s.MaxRow)
+		}
+		c.Assert(names, qt.DeepEquals, []string{
+			"A sheet with a rather long name",
+			"A sheet with a rather long (2)",
+			"A sheet with a rather long (3)",
+		})
+		c.Assert(sizes, qt.DeepEquals, []int{3, 3, 2})
+		last, err := file.Sheets[2].Row(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(last.GetCell(0).Value, qt.Equals, "5")
+	})
+
+	csRunO(c, "Full", func(c *qt.C, option FileOption) {
+		rows, err := db.Query("counting")
+		c.Assert(err, qt.IsNil)
+		defer rows.Close()
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Counting")
+		c.Assert(err, qt.IsNil)
+		n, err := sheet.WriteSQLRows(rows, SQLRowsOptions{NoHeader: true, MaxRows: 4})
+		c.Assert(err, qt.ErrorMatches, `WriteSQLRows: sheet "Counting" is full after 4 rows`)
+		c.Assert(n, qt.Equals, 4)
+	})
+}
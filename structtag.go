@@ -0,0 +1,238 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fieldTag is the parsed form of the xlsx struct tag of a field.
+//
+// A tag is a list of options separated by semicolons or commas.  An
+// option is either a bare word or a key=value pair:
+//
+//	xlsx:"3"                                 the cell at index 3
+//	xlsx:"name=Email Address;alias=E-mail|Mail;required"
+//	xlsx:"name=Amount;format=#,##0.00;width=14;style=Currency"
+//	xlsx:"name=Amount,format=#,##0.00,width=14,style=Currency"
+//	xlsx:"2;default=0;omitempty"
+//	xlsx:"name=Born;layout=02/01/2006"
+//	xlsx:"4;split=|;raw"
+//	xlsx:"-"                                 the field is ignored
+//
+// A bare number, which must come first, is the index of the cell the
+// field maps to, as ReadStruct has always used.  A name maps the field
+// to the column with that header instead.  The format, width and
+// named cell style are used when writing the field with Sheet.Marshal.
+// Since values may themselves contain commas, anything after a comma
+// that isn't another option is taken to be part of the value before
+// it, and as number formats can have several sections separated by
+// semicolons, the same goes for anything after a semicolon in a format.
+//
+// When reading, an empty cell normally leaves the field alone.  With
+// default the field is read as though the cell held the given value
+// instead, and with required the empty cell is an error.  A layout, in
+// the form taken by time.Parse, reads a time from a text cell, and a
+// split separator, which replaces the comma, divides the text of a
+// cell into the elements of a slice or array.  String, slice, array and
+// map fields are read from the formatted value of a cell, unless the
+// tag says raw, and types implementing encoding.TextUnmarshaler from
+// the raw value, unless it says formatted.
+//
+// When writing, omitempty leaves the cell of a zero value empty, a
+// layout writes a time as text, and a split separator joins the
+// elements of a slice or array into one text cell; without one, slices
+// aren't written.
+type fieldTag struct {
+	skip  bool
+	index int // -1 unless the tag gives a cell index
+	// name and aliases are the headers that the field's column may
+	// have, as written in the tag.
+	name     string
+	aliases  []string
+	required bool
+	format   string
+	width    float64
+	style    string
+	// def is the value read in place of an empty cell, if hasDefault.
+	def        string
+	hasDefault bool
+	omitempty  bool
+	layout     string // The time.Parse layout of a time held as text
+	split      string // The separator of the elements of a slice
+	raw        bool   // Read the raw value of the cell
+	formatted  bool   // Read the formatted value of the cell
+}
+
+// hasIndex reports whether the tag gives the index of a cell.
+func (t fieldTag) hasIndex() bool {
+	return t.index >= 0
+}
+
+// headers returns every header that the field's column may have,
+// normalized by normalizeHeader.
+func (t fieldTag) headers() []string {
+	if t.name == "" {
+		return nil
+	}
+	headers := []string{normalizeHeader(t.name)}
+	for _, alias := range t.aliases {
+		headers = append(headers, normalizeHeader(alias))
+	}
+	return headers
+}
+
+// parseFieldTag parses an xlsx struct tag.
+func parseFieldTag(tag string) (fieldTag, error) {
+	ft := fieldTag{index: -1}
+	if tag == "-" {
+		ft.skip = true
+		return ft, nil
+	}
+	if tag == "" {
+		return ft, nil
+	}
+	for i, option := range splitTagOptions(tag) {
+		key, value, hasValue := strings.Cut(option, "=")
+		key = strings.TrimSpace(key)
+		option = strings.TrimSpace(option)
+		switch {
+		case i == 0 && !hasValue && isDigits(key):
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return ft, fmt.Errorf("%w: %q", errInvalidTag, tag)
+			}
+			ft.index = index
+		case key == "name" && hasValue:
+			ft.name = strings.TrimSpace(value)
+		case key == "alias" && hasValue:
+			for _, alias := range strings.Split(value, "|") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					ft.aliases = append(ft.aliases, alias)
+				}
+			}
+		case key == "required" && !hasValue:
+			ft.required = true
+		case key == "format" && hasValue:
+			ft.format = value
+		case key == "width" && hasValue:
+			width, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || width < 0 {
+				return ft, fmt.Errorf("%w: bad width %q in %q", errInvalidTag, value, tag)
+			}
+			ft.width = width
+		case key == "style" && hasValue:
+			ft.style = strings.TrimSpace(value)
+		case key == "default" && hasValue:
+			ft.def = strings.TrimSpace(value)
+			ft.hasDefault = true
+		case key == "omitempty" && !hasValue:
+			ft.omitempty = true
+		case key == "layout" && hasValue:
+			ft.layout = strings.TrimSpace(value)
+			if ft.layout == "" {
+				return ft, fmt.Errorf("%w: empty layout in %q", errInvalidTag, tag)
+			}
+		case key == "split" && hasValue:
+			// The separator isn't trimmed, so that it may be a space.
+			if value == "" {
+				return ft, fmt.Errorf("%w: empty split separator in %q", errInvalidTag, tag)
+			}
+			ft.split = value
+		case key == "raw" && !hasValue:
+			ft.raw = true
+		case key == "formatted" && !hasValue:
+			ft.formatted = true
+		case option == "":
+		default:
+			return ft, fmt.Errorf("%w: unknown option %q in %q", errInvalidTag, option, tag)
+		}
+	}
+	if ft.name == "" && len(ft.aliases) > 0 {
+		return ft, fmt.Errorf("%w: alias without a name in %q", errInvalidTag, tag)
+	}
+	if ft.raw && ft.formatted {
+		return ft, fmt.Errorf("%w: both raw and formatted in %q", errInvalidTag, tag)
+	}
+	return ft, nil
+}
+
+// splitTagOptions splits an xlsx struct tag into its options.  A piece
+// that isn't an option of its own is joined to the value of the option
+// before it when a comma separates them, or when that option is a
+// format.
+func splitTagOptions(tag string) []string {
+	var options []string
+	var sep byte
+	for {
+		end := strings.IndexAny(tag, ";,")
+		piece := tag
+		if end >= 0 {
+			piece = tag[:end]
+		}
+		key, _, hasValue := strings.Cut(piece, "=")
+		if n := len(options); n > 0 && !isTagOption(strings.TrimSpace(key), hasValue) && continuesValue(options[n-1], sep) {
+			options[n-1] += string(sep) + piece
+		} else {
+			options = append(options, piece)
+		}
+		if end < 0 {
+			return options
+		}
+		sep = tag[end]
+		tag = tag[end+1:]
+	}
+}
+
+// continuesValue reports whether a piece of a tag that follows option
+// after the separator sep belongs to option's value.
+func continuesValue(option string, sep byte) bool {
+	key, _, hasValue := strings.Cut(option, "=")
+	key = strings.TrimSpace(key)
+	if !isTagOption(key, hasValue) || !hasValue {
+		return false
+	}
+	return sep == ',' || key == "format"
+}
+
+// isTagOption reports whether key, with or without a value, is one of
+// the options of an xlsx struct tag.
+func isTagOption(key string, hasValue bool) bool {
+	switch key {
+	case "name", "alias", "format", "width", "style", "default", "layout", "split":
+		return hasValue
+	case "required", "omitempty", "raw", "formatted":
+		return !hasValue
+	}
+	return false
+}
+
+// isDigits reports whether s is a non-empty string of decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeHeader returns the form of a column header used to match it
+// with a field: lower case, with runs of white space, including line
+// breaks, reduced to a single space and none at either end.
+func normalizeHeader(header string) string {
+	return strings.Join(strings.Fields(strings.ToLower(header)), " ")
+}
+
+// cellText returns the text of cell that the tag says to read: the
+// raw value if it says raw, the formatted value if it says formatted,
+// and otherwise whichever raw asks for.
+func (t fieldTag) cellText(cell *Cell, raw bool) (string, error) {
+	if t.raw || (raw && !t.formatted) {
+		return cell.Value, nil
+	}
+	return cell.FormattedValue()
+}
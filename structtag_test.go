@@ -0,0 +1,154 @@
+package xlsx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseFieldTagOptions(t *testing.T) {
+	c := qt.New(t)
+
+	tag, err := parseFieldTag("2;default= 7 ;required;omitempty;layout=02/01/2006;split= / ;raw")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.index, qt.Equals, 2)
+	c.Assert(tag.hasDefault, qt.IsTrue)
+	c.Assert(tag.def, qt.Equals, "7")
+	c.Assert(tag.required, qt.IsTrue)
+	c.Assert(tag.omitempty, qt.IsTrue)
+	c.Assert(tag.layout, qt.Equals, "02/01/2006")
+	c.Assert(tag.split, qt.Equals, " / ")
+	c.Assert(tag.raw, qt.IsTrue)
+
+	tag, err = parseFieldTag("0;default=")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.hasDefault, qt.IsTrue)
+	c.Assert(tag.def, qt.Equals, "")
+
+	tag, err = parseFieldTag("name=Amount;format=0.00;0.00;omitempty")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag.format, qt.Equals, "0.00;0.00")
+	c.Assert(tag.omitempty, qt.IsTrue)
+
+	for _, bad := range []string{"0;raw;formatted", "0;split=", "0;layout= ", "0;omitempty=yes"} {
+		_, err = parseFieldTag(bad)
+		c.Assert(errors.Is(err, errInvalidTag), qt.IsTrue, qt.Commentf("%s", bad))
+	}
+}
+
+type tagOptionsRecord struct {
+	Name    string    `xlsx:"0;required"`
+	Count   int       `xlsx:"1;default=5;omitempty"`
+	Active  bool      `xlsx:"2;default=true"`
+	Born    time.Time `xlsx:"3;layout=02/01/2006"`
+	Tags    []string  `xlsx:"4;split=|"`
+	IDs     []int     `xlsx:"5;split=, "`
+	Price   string    `xlsx:"6;raw"`
+	Shown   string    `xlsx:"7"`
+	Ignored string    `xlsx:"-"`
+	Note    *string   `xlsx:"8;omitempty"`
+}
+
+func TestTagOptions(t *testing.T) {
+	c := qt.New(t)
+
+	csRunO(c, "ReadStruct", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		row.AddCell().SetString("Ada")
+		row.AddCell()
+		row.AddCell()
+		row.AddCell().SetString("10/12/1815")
+		row.AddCell().SetString("a | b|c")
+		row.AddCell()
+		price := row.AddCell()
+		price.SetFloatWithFormat(3.14159, "0.00")
+		shown := row.AddCell()
+		shown.SetFloatWithFormat(3.14159, "0.00")
+
+		var r tagOptionsRecord
+		c.Assert(row.ReadStruct(&r), qt.IsNil)
+		c.Assert(r.Name, qt.Equals, "Ada")
+		c.Assert(r.Count, qt.Equals, 5)
+		c.Assert(r.Active, qt.IsTrue)
+		c.Assert(r.Born, qt.Equals, time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC))
+		c.Assert(r.Tags, qt.DeepEquals, []string{"a", "b", "c"})
+		c.Assert(r.Price, qt.Equals, "3.14159")
+		c.Assert(r.Shown, qt.Equals, "3.14")
+
+		row.GetCell(0).SetString("")
+		err = row.ReadStruct(&r)
+		c.Assert(errors.Is(err, errRequired), qt.IsTrue)
+		c.Assert(err, qt.ErrorMatches, `field Name: required cell is empty`)
+
+		row.GetCell(0).SetString("Ada")
+		row.GetCell(3).SetString("1815-12-10")
+		c.Assert(row.ReadStruct(&r), qt.Not(qt.IsNil))
+	})
+
+	csRunO(c, "HeaderDecoder", func(c *qt.C, option FileOption) {
+		type record struct {
+			Name  string `xlsx:"name=Name;required"`
+			Level int    `xlsx:"name=Level;default=3"`
+		}
+		file := NewFile(option)
+		sheet, err := file.AddSheet("People")
+		c.Assert(err, qt.IsNil)
+		header := sheet.AddRow()
+		header.AddCell().SetString("Name")
+		header.AddCell().SetString("Level")
+		row := sheet.AddRow()
+		row.AddCell().SetString("Ada")
+		row = sheet.AddRow()
+		row.AddCell()
+		row.AddCell().SetInt(1)
+
+		var records []record
+		err = sheet.Unmarshal(&records, UnmarshalOptions{HeaderRows: 1, MaxErrors: -1})
+		var unmarshalErr *UnmarshalError
+		c.Assert(errors.As(err, &unmarshalErr), qt.IsTrue)
+		c.Assert(err, qt.ErrorMatches, `People!A3: field Name, value "": required cell is empty`)
+		c.Assert(records, qt.DeepEquals, []record{{Name: "Ada", Level: 3}})
+	})
+
+	csRunO(c, "WriteStruct", func(c *qt.C, option FileOption) {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		row := sheet.AddRow()
+		r := tagOptionsRecord{
+			Name:    "Ada",
+			Born:    time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC),
+			Tags:    []string{"a", "b"},
+			IDs:     []int{1, 2},
+			Price:   "3.14",
+			Ignored: "written",
+		}
+		c.Assert(row.WriteStruct(&r, -1), qt.Equals, 10)
+		var values []string
+		for i := 0; i < 10; i++ {
+			values = append(values, row.GetCell(i).Value)
+		}
+		c.Assert(values, qt.DeepEquals, []string{
+			"Ada", "", "0", "10/12/1815", "a|b", "1, 2", "3.14", "", "written", "",
+		})
+
+		var back tagOptionsRecord
+		c.Assert(row.ReadStruct(&back), qt.IsNil)
+		c.Assert(back.Born, qt.Equals, r.Born)
+		c.Assert(back.Tags, qt.DeepEquals, r.Tags)
+		c.Assert(back.IDs, qt.DeepEquals, r.IDs)
+
+		// A field with a tag that can't be parsed isn't written.
+		row = sheet.AddRow()
+		c.Assert(row.WriteStruct(&struct {
+			A string `xlsx:"0;bogus"`
+			B string
+		}{A: "a", B: "b"}, -1), qt.Equals, 1)
+		c.Assert(row.GetCell(0).Value, qt.Equals, "b")
+	})
+}
@@ -25,8 +25,32 @@ const (
 	Solid_Cell_Fill = "solid"
 )
 
+// Underline styles that can be used in Font.UnderlineStyle
+const (
+	UnderlineSingle           = "single"
+	UnderlineDouble           = "double"
+	UnderlineSingleAccounting = "singleAccounting"
+	UnderlineDoubleAccounting = "doubleAccounting"
+)
+
+// Vertical alignments that can be used in Font.VertAlign
+const (
+	VertAlignBaseline    = "baseline"
+	VertAlignSuperscript = "superscript"
+	VertAlignSubscript   = "subscript"
+)
+
+// Reading orders that can be used in Alignment.ReadingOrder
+const (
+	ReadingOrderContext     = 0
+	ReadingOrderLeftToRight = 1
+	ReadingOrderRightToLeft = 2
+)
+
 // Style is a high level structure intended to provide user access to
-// the contents of Style within an XLSX file.
+// the contents of Style within an XLSX file.  NamedStyle is the name
+// of the named cell style, as created by File.AddNamedStyle, that the
+// Style is based on.
 type Style struct {
 	Border          Border
 	Fill            Fill
@@ -35,8 +59,11 @@ type Style struct {
 	ApplyFill       bool
 	ApplyFont       bool
 	ApplyAlignment  bool
+	ApplyProtection bool
 	Alignment       Alignment
+	Protection      *Protection
 	NamedStyleIndex *int
+	NamedStyle      string
 }
 
 // Return a new Style structure initialised with the default values.
@@ -49,6 +76,24 @@ func NewStyle() *Style {
 	}
 }
 
+// clone returns a deep copy of the Style.
+func (style *Style) clone() *Style {
+	c := *style
+	if style.Protection != nil {
+		protection := *style.Protection
+		c.Protection = &protection
+	}
+	if style.NamedStyleIndex != nil {
+		c.NamedStyleIndex = iPtr(*style.NamedStyleIndex)
+	}
+	if gradient := style.Fill.Gradient; gradient != nil {
+		g := *gradient
+		g.Stops = append([]GradientStop(nil), gradient.Stops...)
+		c.Fill.Gradient = &g
+	}
+	return &c
+}
+
 // Generate the underlying XLSX style elements that correspond to the Style.
 func (style *Style) makeXLSXStyleElements() (xFont xlsxFont, xFill xlsxFill, xBorder xlsxBorder, xCellXf xlsxXf) {
 	if style == nil {
@@ -63,7 +108,7 @@ func (style *Style) makeXLSXStyleElements() (xFont xlsxFont, xFill xlsxFill, xBo
 	xFont.Name.Val = style.Font.Name
 	xFont.Family.Val = strconv.Itoa(style.Font.Family)
 	xFont.Charset.Val = strconv.Itoa(style.Font.Charset)
-	xFont.Color.RGB = style.Font.Color
+	xFont.Color = makeXLSXColor(style.Font.Color, style.Font.ColorSpec)
 
 	if style.Font.Bold {
 		xFont.B = &xlsxVal{}
@@ -75,8 +120,11 @@ func (style *Style) makeXLSXStyleElements() (xFont xlsxFont, xFill xlsxFill, xBo
 	} else {
 		xFont.I = nil
 	}
-	if style.Font.Underline {
+	if style.Font.Underline || style.Font.UnderlineStyle != "" {
 		xFont.U = &xlsxVal{}
+		if style.Font.UnderlineStyle != UnderlineSingle {
+			xFont.U.Val = style.Font.UnderlineStyle
+		}
 	} else {
 		xFont.U = nil
 	}
@@ -85,32 +133,81 @@ func (style *Style) makeXLSXStyleElements() (xFont xlsxFont, xFill xlsxFill, xBo
 	} else {
 		xFont.Strike = nil
 	}
-	xPatternFill := xlsxPatternFill{}
-	xPatternFill.PatternType = style.Fill.PatternType
-	xPatternFill.FgColor.RGB = style.Fill.FgColor
-	xPatternFill.BgColor.RGB = style.Fill.BgColor
-	xFill.PatternFill = xPatternFill
+	if style.Font.VertAlign != "" {
+		xFont.VertAlign = &xlsxVal{Val: style.Font.VertAlign}
+	}
+	if style.Font.Scheme != "" {
+		xFont.Scheme = &xlsxVal{Val: style.Font.Scheme}
+	}
+	if gradient := style.Fill.Gradient; gradient != nil {
+		xGradientFill := &xlsxGradientFill{
+			Type:   gradient.Type,
+			Degree: gradient.Degree,
+			Left:   gradient.Left,
+			Right:  gradient.Right,
+			Top:    gradient.Top,
+			Bottom: gradient.Bottom,
+		}
+		for _, stop := range gradient.Stops {
+			xGradientFill.Stop = append(xGradientFill.Stop, xlsxGradientStop{
+				Position: stop.Position,
+				Color:    makeXLSXColor(stop.Color, stop.ColorSpec),
+			})
+		}
+		xFill.GradientFill = xGradientFill
+	} else {
+		xPatternFill := xlsxPatternFill{}
+		xPatternFill.PatternType = style.Fill.PatternType
+		xPatternFill.FgColor = makeXLSXColor(style.Fill.FgColor, style.Fill.FgColorSpec)
+		xPatternFill.BgColor = makeXLSXColor(style.Fill.BgColor, style.Fill.BgColorSpec)
+		xFill.PatternFill = xPatternFill
+	}
 	xBorder.Left = xlsxLine{
 		Style: style.Border.Left,
-		Color: xlsxColor{RGB: style.Border.LeftColor},
+		Color: makeXLSXColor(style.Border.LeftColor, style.Border.LeftColorSpec),
 	}
 	xBorder.Right = xlsxLine{
 		Style: style.Border.Right,
-		Color: xlsxColor{RGB: style.Border.RightColor},
+		Color: makeXLSXColor(style.Border.RightColor, style.Border.RightColorSpec),
 	}
 	xBorder.Top = xlsxLine{
 		Style: style.Border.Top,
-		Color: xlsxColor{RGB: style.Border.TopColor},
+		Color: makeXLSXColor(style.Border.TopColor, style.Border.TopColorSpec),
 	}
 	xBorder.Bottom = xlsxLine{
 		Style: style.Border.Bottom,
-		Color: xlsxColor{RGB: style.Border.BottomColor},
+		Color: makeXLSXColor(style.Border.BottomColor, style.Border.BottomColorSpec),
+	}
+	if style.Border.Diagonal != "" || style.Border.DiagonalUp || style.Border.DiagonalDown {
+		xBorder.Diagonal = xlsxLine{
+			Style: style.Border.Diagonal,
+			Color: makeXLSXColor(style.Border.DiagonalColor, style.Border.DiagonalColorSpec),
+		}
+		xBorder.DiagonalUp = style.Border.DiagonalUp
+		xBorder.DiagonalDown = style.Border.DiagonalDown
 	}
 	xCellXf = makeXLSXCellElement()
 	xCellXf.ApplyBorder = style.ApplyBorder
 	xCellXf.ApplyFill = style.ApplyFill
 	xCellXf.ApplyFont = style.ApplyFont
 	xCellXf.ApplyAlignment = style.ApplyAlignment
+	xCellXf.ApplyProtection = style.ApplyProtection
+	xCellXf.Alignment = xlsxAlignment{
+		Horizontal:     style.Alignment.Horizontal,
+		Indent:         style.Alignment.Indent,
+		RelativeIndent: style.Alignment.RelativeIndent,
+		ShrinkToFit:    style.Alignment.ShrinkToFit,
+		TextRotation:   style.Alignment.TextRotation,
+		Vertical:       style.Alignment.Vertical,
+		WrapText:       style.Alignment.WrapText,
+		ReadingOrder:   style.Alignment.ReadingOrder,
+	}
+	if style.Protection != nil {
+		xCellXf.Protection = &xlsxProtection{
+			Locked: bPtr(style.Protection.Locked),
+			Hidden: bPtr(style.Protection.Hidden),
+		}
+	}
 	if style.NamedStyleIndex != nil {
 		xCellXf.XfId = style.NamedStyleIndex
 	}
@@ -123,16 +220,29 @@ func makeXLSXCellElement() (xCellXf xlsxXf) {
 }
 
 // Border is a high level structure intended to provide user access to
-// the contents of Border Style within an Sheet.
+// the contents of Border Style within an Sheet.  The Diagonal line is
+// only drawn in the directions enabled by DiagonalUp (bottom left to
+// top right) and DiagonalDown (top left to bottom right).  Each of the
+// ColorSpec fields optionally says how the matching ARGB colour is
+// given, in the same way as Font.ColorSpec.
 type Border struct {
-	Left        string
-	LeftColor   string
-	Right       string
-	RightColor  string
-	Top         string
-	TopColor    string
-	Bottom      string
-	BottomColor string
+	Left              string
+	LeftColor         string
+	LeftColorSpec     Color
+	Right             string
+	RightColor        string
+	RightColorSpec    Color
+	Top               string
+	TopColor          string
+	TopColorSpec      Color
+	Bottom            string
+	BottomColor       string
+	BottomColorSpec   Color
+	Diagonal          string
+	DiagonalColor     string
+	DiagonalColorSpec Color
+	DiagonalUp        bool
+	DiagonalDown      bool
 }
 
 func NewBorder(left, right, top, bottom string) *Border {
@@ -146,10 +256,53 @@ func NewBorder(left, right, top, bottom string) *Border {
 
 // Fill is a high level structure intended to provide user access to
 // the contents of background and foreground color index within an Sheet.
+// When Gradient is set it takes the place of the pattern.  FgColorSpec
+// and BgColorSpec optionally say how the colours are given, in the
+// same way as Font.ColorSpec.
 type Fill struct {
 	PatternType string
 	BgColor     string
+	BgColorSpec Color
 	FgColor     string
+	FgColorSpec Color
+	Gradient    *GradientFill
+}
+
+// GradientFill describes a fill that blends between the colours of
+// its Stops.  A "linear" gradient (the default) runs at the angle
+// given by Degree, while a "path" gradient radiates out from the
+// rectangle described by Left, Right, Top and Bottom, each of which
+// is a fraction of the cell's size.
+type GradientFill struct {
+	Type   string
+	Degree float64
+	Left   float64
+	Right  float64
+	Top    float64
+	Bottom float64
+	Stops  []GradientStop
+}
+
+// GradientStop is a colour at a Position between 0 and 1 along a
+// GradientFill.
+type GradientStop struct {
+	Position  float64
+	Color     string
+	ColorSpec Color
+}
+
+// NewLinearGradientFill returns a Fill that blends evenly from the
+// first to the last of the colours at the given angle in degrees.
+func NewLinearGradientFill(degree float64, colors ...string) *Fill {
+	gradient := &GradientFill{Degree: degree}
+	for i, color := range colors {
+		position := 0.0
+		if len(colors) > 1 {
+			position = float64(i) / float64(len(colors)-1)
+		}
+		gradient.Stops = append(gradient.Stops, GradientStop{Position: position, Color: color})
+	}
+	return &Fill{Gradient: gradient}
 }
 
 func NewFill(patternType, fgColor, bgColor string) *Fill {
@@ -160,34 +313,71 @@ func NewFill(patternType, fgColor, bgColor string) *Fill {
 	}
 }
 
+// Font describes the typeface of a Style.  UnderlineStyle selects one
+// of the Underline constants; leaving it empty while Underline is set
+// gives a single underline.  VertAlign raises or lowers the text as
+// superscript or subscript, and Scheme ("major" or "minor") ties the
+// font to the workbook's Theme.
+//
+// Color is the ARGB value of the font colour.  ColorSpec, when set,
+// says how that colour is given, so that theme and indexed colours,
+// tints and the automatic colour survive a round trip.  A set ColorSpec
+// is what gets written, and Color then only reports the value it
+// resolved to when the style was read; to change such a colour, set
+// ColorSpec, or clear it and set Color.
 type Font struct {
-	Size      float64
-	Name      string
-	Family    int
-	Charset   int
-	Color     string
-	Bold      bool
-	Italic    bool
-	Underline bool
-	Strike    bool
+	Size           float64
+	Name           string
+	Family         int
+	Charset        int
+	Color          string
+	ColorSpec      Color
+	Bold           bool
+	Italic         bool
+	Underline      bool
+	UnderlineStyle string
+	Strike         bool
+	VertAlign      string
+	Scheme         string
 }
 
 func NewFont(size float64, name string) *Font {
 	return &Font{Size: size, Name: name}
 }
 
+// Alignment describes the position of text within a cell.
+// ReadingOrder is one of the ReadingOrder constants.
 type Alignment struct {
-	Horizontal   string
-	Indent       int
-	ShrinkToFit  bool
-	TextRotation int
-	Vertical     string
-	WrapText     bool
+	Horizontal     string
+	Indent         int
+	RelativeIndent int
+	ShrinkToFit    bool
+	TextRotation   int
+	Vertical       string
+	WrapText       bool
+	ReadingOrder   int
+}
+
+// Protection controls whether a cell may be edited (Locked) and
+// whether its formula is shown (Hidden) once the sheet is protected.
+// A Style with no Protection leaves Excel's default in place, in
+// which cells are locked and formulas visible.
+type Protection struct {
+	Locked bool
+	Hidden bool
+}
+
+func NewProtection(locked, hidden bool) *Protection {
+	return &Protection{Locked: locked, Hidden: hidden}
 }
 
 var defaultFontSize = 12.0
 var defaultFontName = "Verdana"
 
+// SetDefaultFont sets the package level default font, which is used
+// by every File that wasn't given a DefaultStyle.  It isn't safe to
+// call while other goroutines are building files; prefer the
+// DefaultStyle FileOption.
 func SetDefaultFont(size float64, name string) {
 	defaultFontSize = size
 	defaultFontName = name
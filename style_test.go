@@ -1,6 +1,8 @@
 package xlsx
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -74,11 +76,16 @@ func TestReadCellColorBackground(t *testing.T) {
 		cell, err = sheet.Cell(1, 1)
 		c.Assert(err, qt.Equals, nil)
 		style = cell.GetStyle()
-		c.Assert(style.Fill, qt.Equals, *NewFill("solid", "00FFCC99", ""))
+		fill := *NewFill("solid", "00FFCC99", "")
+		fill.FgColorSpec = NewIndexedColor(47)
+		fill.BgColorSpec = NewIndexedColor(64)
+		c.Assert(style.Fill, qt.Equals, fill)
 		cell, err = sheet.Cell(2, 1)
 		c.Assert(err, qt.Equals, nil)
 		style = cell.GetStyle()
-		c.Assert(style.Fill, qt.Equals, *NewFill("solid", "FF990099", ""))
+		c.Assert(style.Fill.PatternType, qt.Equals, "solid")
+		c.Assert(style.Fill.FgColor, qt.Equals, "FF990099")
+		c.Assert(style.Fill.BgColor, qt.Equals, "")
 	})
 }
 
@@ -89,3 +96,143 @@ func TestNewFont(t *testing.T) {
 	c.Assert(font.Name, qt.Equals, "Verdana")
 	c.Assert(font.Size, qt.Equals, 12.2)
 }
+
+func TestNewLinearGradientFill(t *testing.T) {
+	c := qt.New(t)
+	fill := NewLinearGradientFill(90, "FFFFFFFF", "FF808080", "FF000000")
+	c.Assert(fill.Gradient.Degree, qt.Equals, 90.0)
+	c.Assert(fill.Gradient.Stops, qt.DeepEquals, []GradientStop{
+		{Position: 0, Color: "FFFFFFFF"},
+		{Position: 0.5, Color: "FF808080"},
+		{Position: 1, Color: "FF000000"},
+	})
+}
+
+func TestFullFidelityStyle(t *testing.T) {
+	c := qt.New(t)
+
+	makeStyle := func() *Style {
+		style := NewStyle()
+		style.Font.UnderlineStyle = UnderlineDouble
+		style.Font.Underline = true
+		style.Font.VertAlign = VertAlignSubscript
+		style.Font.Scheme = "major"
+		style.Fill = *NewLinearGradientFill(45, "FFFFFFFF", "FF4F81BD")
+		style.Border.Diagonal = "thin"
+		style.Border.DiagonalColor = "FFFF0000"
+		style.Border.DiagonalDown = true
+		style.Alignment.ReadingOrder = ReadingOrderRightToLeft
+		style.Alignment.RelativeIndent = 2
+		style.Protection = NewProtection(false, true)
+		style.ApplyFont = true
+		style.ApplyFill = true
+		style.ApplyBorder = true
+		style.ApplyAlignment = true
+		style.ApplyProtection = true
+		return style
+	}
+
+	c.Run("Marshal", func(c *qt.C) {
+		styles := newXlsxStyleSheet(nil, nil)
+		styles.reset()
+		handleStyleForXLSX(makeStyle(), 0, styles)
+		out, err := styles.Marshal()
+		c.Assert(err, qt.IsNil)
+		for _, want := range []string{
+			`<u val="double"/>`,
+			`<vertAlign val="subscript"/>`,
+			`<scheme val="major"/>`,
+			`<fill><gradientFill degree="45"><stop position="0"><color rgb="FFFFFFFF"/></stop><stop position="1"><color rgb="FF4F81BD"/></stop></gradientFill></fill>`,
+			`<border diagonalDown="1">`,
+			`<diagonal style="thin"><color rgb="FFFF0000"/></diagonal>`,
+			`relativeIndent="2" readingOrder="2"/><protection locked="0" hidden="1"/></xf>`,
+		} {
+			c.Assert(strings.Contains(out, want), qt.IsTrue, qt.Commentf("missing %s in %s", want, out))
+		}
+	})
+
+	csRunO(c, "RoundTrip", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		cell := sheet.AddRow().AddCell()
+		cell.SetString("styled")
+		cell.SetStyle(makeStyle())
+		plain := sheet.AddRow().AddCell()
+		plain.SetString("plain")
+		plain.SetStyle(NewStyle())
+
+		var buf bytes.Buffer
+		c.Assert(f.Write(&buf), qt.IsNil)
+		f, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+
+		cell, err = f.Sheets[0].Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		want := makeStyle()
+		got := cell.GetStyle()
+		c.Assert(got.Font, qt.Equals, want.Font)
+		c.Assert(got.Fill.Gradient, qt.DeepEquals, want.Fill.Gradient)
+		c.Assert(got.Border, qt.Equals, want.Border)
+		c.Assert(got.Alignment, qt.Equals, want.Alignment)
+		c.Assert(got.Protection, qt.DeepEquals, want.Protection)
+		c.Assert(got.ApplyProtection, qt.IsTrue)
+
+		plain, err = f.Sheets[0].Cell(1, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(plain.GetStyle().Protection, qt.IsNil)
+		c.Assert(plain.GetStyle().Fill.Gradient, qt.IsNil)
+	})
+}
+
+func TestDefaultStyle(t *testing.T) {
+	c := qt.New(t)
+
+	corporate := NewStyle()
+	corporate.Font = *NewFont(10, "Corporate Sans")
+	corporate.Alignment.Vertical = "center"
+
+	c.Run("NewStyle", func(c *qt.C) {
+		f := NewFile(DefaultStyle(corporate))
+		style := f.NewStyle()
+		c.Assert(style.Font, qt.Equals, *NewFont(10, "Corporate Sans"))
+		c.Assert(style.Alignment.Vertical, qt.Equals, "center")
+		style.Font.Name = "Changed"
+		c.Assert(f.NewStyle().Font.Name, qt.Equals, "Corporate Sans")
+
+		// Files without a default style, and the package level
+		// constructor, are unaffected.
+		c.Assert(NewFile().NewStyle(), qt.DeepEquals, NewStyle())
+		c.Assert(NewStyle().Font, qt.Equals, *DefaultFont())
+	})
+
+	csRunO(c, "CellsUseDefaultStyle", func(c *qt.C, option FileOption) {
+		f := NewFile(option, DefaultStyle(corporate))
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		cell := sheet.AddRow().AddCell()
+		c.Assert(cell.EffectiveStyle().Font.Name, qt.Equals, "Corporate Sans")
+		c.Assert(cell.GetStyle().Font.Name, qt.Equals, "Corporate Sans")
+	})
+
+	c.Run("NormalStyle", func(c *qt.C) {
+		f := NewFile(DefaultStyle(corporate))
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		parts, err := f.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		styles := parts["xl/styles.xml"]
+		c.Assert(styles, qt.Contains, `<fonts count="1"><font><sz val="10"/><name val="Corporate Sans"/>`)
+		c.Assert(styles, qt.Contains, `<cellStyles count="1"><cellStyle builtInId="0" name="Normal" xfId="0"></cellStyle></cellStyles>`)
+		c.Assert(f.styles.CellStyleXfs.Xf[0].Alignment.Vertical, qt.Equals, "center")
+		c.Assert(f.styles.CellXfs.Xf[0].Alignment.Vertical, qt.Equals, "center")
+
+		plain := NewFile()
+		_, err = plain.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		parts, err = plain.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(parts["xl/styles.xml"], qt.Not(qt.Contains), "<cellStyles")
+	})
+}
@@ -0,0 +1,128 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// StyleID identifies a Style interned in a File's StyleRegistry.  IDs
+// are only meaningful to the File that issued them, and the zero
+// StyleID never refers to a style.
+type StyleID int
+
+// StyleRegistry interns the styles used by a File, so that many cells
+// can share one immutable copy of each distinct style.  Identical
+// styles are given the same StyleID, so applying a style by ID is
+// cheap, and it's written to styles.xml only once.  A StyleRegistry
+// is safe for concurrent use.
+type StyleRegistry struct {
+	mu     sync.RWMutex
+	styles []*Style
+	ids    map[string]StyleID
+}
+
+func newStyleRegistry() *StyleRegistry {
+	return &StyleRegistry{ids: make(map[string]StyleID)}
+}
+
+// styleKey returns a string that is the same for two styles exactly
+// when they describe the same formatting.
+func styleKey(style *Style) (string, error) {
+	var buf bytes.Buffer
+	if err := writeStyle(&buf, style); err != nil {
+		return "", err
+	}
+	if style.NamedStyleIndex != nil {
+		buf.WriteString(strconv.Itoa(*style.NamedStyleIndex))
+	}
+	return buf.String(), nil
+}
+
+// Register interns a copy of style, returning its StyleID.  Changing
+// style afterwards doesn't affect the registered copy.
+func (r *StyleRegistry) Register(style *Style) (StyleID, error) {
+	if style == nil {
+		return 0, fmt.Errorf("StyleRegistry.Register: style must not be nil")
+	}
+	key, err := styleKey(style)
+	if err != nil {
+		return 0, fmt.Errorf("StyleRegistry.Register: %w", err)
+	}
+	r.mu.RLock()
+	id, ok := r.ids[key]
+	r.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[key]; ok {
+		return id, nil
+	}
+	r.styles = append(r.styles, style.clone())
+	id = StyleID(len(r.styles))
+	r.ids[key] = id
+	return id, nil
+}
+
+// Style returns a copy of the style registered as id, and whether
+// there is one.
+func (r *StyleRegistry) Style(id StyleID) (*Style, bool) {
+	style := r.lookup(id)
+	if style == nil {
+		return nil, false
+	}
+	return style.clone(), true
+}
+
+// Len returns the number of distinct styles in the registry.
+func (r *StyleRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.styles)
+}
+
+// lookup returns the shared copy of the style registered as id, which
+// must not be modified, or nil if there is none.
+func (r *StyleRegistry) lookup(id StyleID) *Style {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id < 1 || int(id) > len(r.styles) {
+		return nil
+	}
+	return r.styles[id-1]
+}
+
+// Styles returns the File's StyleRegistry.
+func (f *File) Styles() *StyleRegistry {
+	f.styleRegistryOnce.Do(func() {
+		f.styleRegistry = newStyleRegistry()
+	})
+	return f.styleRegistry
+}
+
+// styleIDXf identifies a cell format made from a registered style and
+// a number format.
+type styleIDXf struct {
+	id       StyleID
+	numFmtId int
+}
+
+// handleStyleIDForXLSX returns the cell format for a style from the
+// registry, only working it out the first time each combination of
+// style and number format is seen.
+func handleStyleIDForXLSX(id StyleID, style *Style, NumFmtId int, styles *xlsxStyleSheet) int {
+	key := styleIDXf{id: id, numFmtId: NumFmtId}
+	if XfId, ok := styles.styleIDXfs[key]; ok {
+		return XfId
+	}
+	if styles.styleIDXfs == nil {
+		styles.styleIDXfs = make(map[styleIDXf]int)
+	}
+	XfId := handleStyleForXLSX(style, NumFmtId, styles)
+	styles.styleIDXfs[key] = XfId
+	return XfId
+}
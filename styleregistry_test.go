@@ -0,0 +1,122 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestStyleRegistry(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Register", func(c *qt.C) {
+		r := newStyleRegistry()
+		bold := NewStyle()
+		bold.Font.Bold = true
+		id, err := r.Register(bold)
+		c.Assert(err, qt.IsNil)
+		c.Assert(id, qt.Equals, StyleID(1))
+
+		same := NewStyle()
+		same.Font.Bold = true
+		sameID, err := r.Register(same)
+		c.Assert(err, qt.IsNil)
+		c.Assert(sameID, qt.Equals, id)
+
+		// The registry keeps its own copy.
+		bold.Font.Bold = false
+		got, ok := r.Style(id)
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(got.Font.Bold, qt.IsTrue)
+		otherID, err := r.Register(bold)
+		c.Assert(err, qt.IsNil)
+		c.Assert(otherID, qt.Equals, StyleID(2))
+		c.Assert(r.Len(), qt.Equals, 2)
+
+		_, ok = r.Style(0)
+		c.Assert(ok, qt.IsFalse)
+		_, ok = r.Style(3)
+		c.Assert(ok, qt.IsFalse)
+		_, err = r.Register(nil)
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+
+	c.Run("DistinguishesPointerFields", func(c *qt.C) {
+		r := newStyleRegistry()
+		locked := NewStyle()
+		locked.Protection = NewProtection(true, false)
+		unlocked := NewStyle()
+		unlocked.Protection = NewProtection(false, false)
+		id1, err := r.Register(locked)
+		c.Assert(err, qt.IsNil)
+		id2, err := r.Register(unlocked)
+		c.Assert(err, qt.IsNil)
+		c.Assert(id1, qt.Not(qt.Equals), id2)
+	})
+
+	c.Run("Concurrent", func(c *qt.C) {
+		r := newStyleRegistry()
+		var wg sync.WaitGroup
+		ids := make([]StyleID, 20)
+		for i := range ids {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				style := NewStyle()
+				style.Font.Size = float64(10 + i%2)
+				ids[i], _ = r.Register(style)
+			}(i)
+		}
+		wg.Wait()
+		c.Assert(r.Len(), qt.Equals, 2)
+	})
+
+	csRunO(c, "SetStyleID", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		header := NewStyle()
+		header.Font.Bold = true
+		header.ApplyFont = true
+		id, err := f.Styles().Register(header)
+		c.Assert(err, qt.IsNil)
+
+		for i := 0; i < 3; i++ {
+			row := sheet.AddRow()
+			for j := 0; j < 3; j++ {
+				cell := row.AddCell()
+				cell.SetInt(i * j)
+				c.Assert(cell.SetStyleID(id), qt.IsNil)
+				c.Assert(cell.StyleID(), qt.Equals, id)
+			}
+		}
+		cell, err := sheet.Cell(0, 0)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.SetStyleID(99), qt.Not(qt.IsNil))
+		c.Assert(cell.StyleID(), qt.Equals, id)
+
+		// Changing one cell's style detaches it from the registry.
+		cell.GetStyle().Font.Italic = true
+		c.Assert(cell.StyleID(), qt.Equals, StyleID(0))
+		shared, _ := f.Styles().Style(id)
+		c.Assert(shared.Font.Italic, qt.IsFalse)
+
+		parts, err := f.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		sheetXML := parts["xl/worksheets/sheet1.xml"]
+		c.Assert(strings.Count(sheetXML, `s="1"`), qt.Equals, 1)
+		c.Assert(strings.Count(sheetXML, `s="2"`), qt.Equals, 8)
+		c.Assert(parts["xl/styles.xml"], qt.Contains, `<cellXfs count="3">`)
+
+		var buf bytes.Buffer
+		c.Assert(f.Write(&buf), qt.IsNil)
+		f, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		cell, err = f.Sheets[0].Cell(2, 2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cell.GetStyle().Font.Bold, qt.IsTrue)
+	})
+}
@@ -1,12 +1,29 @@
 package xlsx
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
+// themeColorIndex maps the children of the clrScheme element to
+// their position in theme.colors.  Note that Excel's theme colour
+// indices swap the light and dark colours around.
+var themeColorIndex = map[string]int{
+	"lt1": 0, "dk1": 1, "lt2": 2, "dk2": 3, "accent1": 4, "accent2": 5,
+	"accent3": 6, "accent4": 7, "accent5": 8, "accent6": 9, "hlink": 10,
+	"folHlink": 11,
+}
+
 type theme struct {
-	colors []string
+	colors    []string
+	majorFont string
+	minorFont string
+	raw       []byte // The XML the theme was read from, if any
 }
 
 func newTheme(themeXml xlsxTheme) *theme {
@@ -16,7 +33,7 @@ func newTheme(themeXml xlsxTheme) *theme {
 		var rgbColor string
 		if scheme.SysClr != nil {
 			rgbColor = scheme.SysClr.LastClr
-		} else {
+		} else if scheme.SrgbClr != nil {
 			rgbColor = scheme.SrgbClr.Val
 		}
 		clrMap[scheme.XMLName.Local] = rgbColor
@@ -24,24 +41,236 @@ func newTheme(themeXml xlsxTheme) *theme {
 	colors := []string{clrMap["lt1"], clrMap["dk1"], clrMap["lt2"], clrMap["dk2"], clrMap["accent1"],
 		clrMap["accent2"], clrMap["accent3"], clrMap["accent4"], clrMap["accent5"],
 		clrMap["accent6"], clrMap["hlink"], clrMap["folHlink"]}
-	return &theme{colors}
+	fonts := themeXml.ThemeElements.FontScheme
+	return &theme{
+		colors:    colors,
+		majorFont: fonts.MajorFont.Latin.Typeface,
+		minorFont: fonts.MinorFont.Latin.Typeface,
+	}
+}
+
+// officeTheme returns the theme written to files that don't have
+// one of their own.
+func officeTheme() *theme {
+	var themeXml xlsxTheme
+	err := xml.Unmarshal([]byte(TEMPLATE_XL_THEME_THEME), &themeXml)
+	if err != nil {
+		panic(err)
+	}
+	return newTheme(themeXml)
 }
 
 func (t *theme) themeColor(index int64, tint float64) string {
+	if index < 0 || index >= int64(len(t.colors)) {
+		return ""
+	}
 	baseColor := t.colors[index]
 	if tint == 0 {
 		return "FF" + baseColor
-	} else {
-		r, _ := strconv.ParseInt(baseColor[0:2], 16, 64)
-		g, _ := strconv.ParseInt(baseColor[2:4], 16, 64)
-		b, _ := strconv.ParseInt(baseColor[4:6], 16, 64)
-		h, s, l := RGBToHSL(uint8(r), uint8(g), uint8(b))
-		if tint < 0 {
-			l *= (1 + tint)
-		} else {
-			l = l*(1-tint) + (1 - (1 - tint))
+	}
+	return applyTint(baseColor, tint)
+}
+
+// themeEdit replaces the bytes between start and end of a theme's
+// XML with text.
+type themeEdit struct {
+	start, end int64
+	text       string
+}
+
+var typefaceAttrRegexp = regexp.MustCompile(`typeface="[^"]*"`)
+
+// marshal renders the theme as XML.  Rather than generating the whole
+// document, which holds far more than we model, the colour scheme and
+// fonts are spliced into the XML the theme was read from, so that
+// everything else survives a round trip untouched.
+func (t *theme) marshal() (string, error) {
+	raw := t.raw
+	if raw == nil {
+		raw = []byte(TEMPLATE_XL_THEME_THEME)
+	}
+
+	wrap := func(err error) (string, error) {
+		return "", fmt.Errorf("theme.marshal: %w", err)
+	}
+
+	var edits []themeEdit
+	var stack []string
+	var colorName, colorValue string
+	var colorStart int64 = -1
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	for {
+		start := decoder.InputOffset()
+		tok, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return wrap(err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			stack = append(stack, tok.Name.Local)
+			switch {
+			case parent == "clrScheme":
+				colorName = tok.Name.Local
+			case colorName != "" && parent == colorName && colorStart < 0:
+				colorStart = start
+				for _, attr := range tok.Attr {
+					if (tok.Name.Local == "srgbClr" && attr.Name.Local == "val") ||
+						(tok.Name.Local == "sysClr" && attr.Name.Local == "lastClr") {
+						colorValue = attr.Value
+					}
+				}
+			case tok.Name.Local == "latin" && (parent == "majorFont" || parent == "minorFont"):
+				typeface := t.majorFont
+				if parent == "minorFont" {
+					typeface = t.minorFont
+				}
+				end := decoder.InputOffset()
+				tag := string(raw[start:end])
+				edits = append(edits, themeEdit{start, end,
+					typefaceAttrRegexp.ReplaceAllLiteralString(tag, `typeface="`+xmlEscapeAttr(typeface)+`"`)})
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return wrap(fmt.Errorf("unexpected end element %q", tok.Name.Local))
+			}
+			stack = stack[:len(stack)-1]
+			switch {
+			case colorStart >= 0 && len(stack) > 0 && stack[len(stack)-1] == colorName:
+				index, ok := themeColorIndex[colorName]
+				if ok && !strings.EqualFold(t.colors[index], colorValue) {
+					edits = append(edits, themeEdit{colorStart, decoder.InputOffset(),
+						"<" + prefixed(tok.Name.Space, "srgbClr") + ` val="` + t.colors[index] + `"/>`})
+				}
+				colorStart = -1
+				colorValue = ""
+			case tok.Name.Local == colorName && len(stack) > 0 && stack[len(stack)-1] == "clrScheme":
+				colorName = ""
+			}
 		}
-		br, bg, bb := HSLToRGB(h, s, l)
-		return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 	}
+
+	var out bytes.Buffer
+	var pos int64
+	for _, e := range edits {
+		out.Write(raw[pos:e.start])
+		out.WriteString(e.text)
+		pos = e.end
+	}
+	out.Write(raw[pos:])
+	return out.String(), nil
+}
+
+// prefixed returns name qualified with the namespace prefix, if any.
+func prefixed(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + ":" + name
+}
+
+func xmlEscapeAttr(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// Theme describes the parts of a workbook's theme that can be
+// changed: the colour scheme and the fonts used for headings (the
+// major font) and body text (the minor font).  Cells that use theme
+// colours or fonts follow the theme, so changing it restyles the
+// whole workbook.
+type Theme struct {
+	Colors    ThemeColors
+	MajorFont string
+	MinorFont string
+}
+
+// ThemeColors holds the colour scheme of a Theme.  Each colour is
+// given as six hexadecimal digits, "RRGGBB".
+type ThemeColors struct {
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
+
+// fields returns pointers to the colours, in the same order as
+// theme.colors.
+func (c *ThemeColors) fields() []*string {
+	return []*string{&c.Light1, &c.Dark1, &c.Light2, &c.Dark2, &c.Accent1,
+		&c.Accent2, &c.Accent3, &c.Accent4, &c.Accent5, &c.Accent6,
+		&c.Hyperlink, &c.FollowedHyperlink}
+}
+
+// Theme returns the theme of the File.  A File that hasn't been read
+// from disk, or that had no theme, uses the default Office theme.
+func (f *File) Theme() Theme {
+	t := f.theme
+	if t == nil {
+		t = officeTheme()
+	}
+	result := Theme{MajorFont: t.majorFont, MinorFont: t.minorFont}
+	for i, c := range result.Colors.fields() {
+		if i < len(t.colors) {
+			*c = t.colors[i]
+		}
+	}
+	return result
+}
+
+// SetTheme replaces the colour scheme and fonts of the File's theme.
+// Colours may be written with a leading "#" and in either case.  Any
+// colour or font left empty keeps its current value.
+func (f *File) SetTheme(newTheme Theme) error {
+	t := f.theme
+	if t == nil {
+		t = officeTheme()
+	}
+	colors := make([]string, len(t.colors))
+	copy(colors, t.colors)
+	for i, c := range newTheme.Colors.fields() {
+		if *c == "" {
+			continue
+		}
+		rgb := strings.ToUpper(strings.TrimPrefix(*c, "#"))
+		if _, err := strconv.ParseUint(rgb, 16, 32); err != nil || len(rgb) != 6 {
+			return fmt.Errorf("SetTheme: invalid colour %q", *c)
+		}
+		colors[i] = rgb
+	}
+	t.colors = colors
+	if newTheme.MajorFont != "" {
+		t.majorFont = newTheme.MajorFont
+	}
+	if newTheme.MinorFont != "" {
+		t.minorFont = newTheme.MinorFont
+	}
+	f.theme = t
+	if f.styles != nil {
+		f.styles.theme = t
+	}
+	return nil
+}
+
+// makeThemeXML returns the content of xl/theme/theme1.xml.
+func (f *File) makeThemeXML() (string, error) {
+	if f.theme == nil {
+		return TEMPLATE_XL_THEME_THEME, nil
+	}
+	return f.theme.marshal()
 }
@@ -3,6 +3,7 @@ package xlsx
 import (
 	"bytes"
 	"encoding/xml"
+	"strings"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -79,3 +80,76 @@ func TestThemeColors(t *testing.T) {
 	c.Assert(theme.themeColor(0, 0), qt.Equals, "FFFFFFFF")
 	c.Assert(theme.themeColor(2, 0), qt.Equals, "FFEEECE1")
 }
+
+func TestThemeAPI(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("DefaultTheme", func(c *qt.C) {
+		f := NewFile()
+		th := f.Theme()
+		c.Assert(th.Colors.Dark1, qt.Equals, "000000")
+		c.Assert(th.Colors.Light1, qt.Equals, "FFFFFF")
+		c.Assert(th.Colors.Accent1, qt.Equals, "4F81BD")
+		c.Assert(th.Colors.FollowedHyperlink, qt.Equals, "800080")
+		c.Assert(th.MajorFont, qt.Equals, "Cambria")
+		c.Assert(th.MinorFont, qt.Equals, "Arial")
+	})
+
+	c.Run("SetThemeValidates", func(c *qt.C) {
+		f := NewFile()
+		c.Assert(f.SetTheme(Theme{Colors: ThemeColors{Accent1: "12345"}}), qt.Not(qt.IsNil))
+		c.Assert(f.SetTheme(Theme{Colors: ThemeColors{Accent1: "GGGGGG"}}), qt.Not(qt.IsNil))
+		c.Assert(f.Theme().Colors.Accent1, qt.Equals, "4F81BD")
+	})
+
+	c.Run("UnchangedThemeIsWrittenVerbatim", func(c *qt.C) {
+		th := officeTheme()
+		out, err := th.marshal()
+		c.Assert(err, qt.IsNil)
+		c.Assert(out, qt.Equals, TEMPLATE_XL_THEME_THEME)
+	})
+
+	csRunO(c, "SetThemeRoundTrip", func(c *qt.C, option FileOption) {
+		f := NewFile(option)
+		sheet, err := f.AddSheet("Sheet1")
+		c.Assert(err, qt.IsNil)
+		sheet.AddRow().AddCell().SetString("a1")
+		err = f.SetTheme(Theme{
+			Colors: ThemeColors{
+				Dark1:   "#102030",
+				Accent1: "aabbcc",
+			},
+			MinorFont: "Segoe UI",
+		})
+		c.Assert(err, qt.IsNil)
+
+		parts, err := f.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		themeXML := parts["xl/theme/theme1.xml"]
+		c.Assert(strings.Contains(themeXML, `<a:dk1>
+        <a:srgbClr val="102030"/>
+      </a:dk1>`), qt.IsTrue)
+		c.Assert(strings.Contains(themeXML, `<a:lt1>
+        <a:sysClr val="window" lastClr="FFFFFF"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(themeXML, `<a:latin typeface="Segoe UI"/>`), qt.IsTrue)
+		c.Assert(strings.Contains(themeXML, `<a:latin typeface="Cambria"/>`), qt.IsTrue)
+
+		var buf bytes.Buffer
+		c.Assert(f.Write(&buf), qt.IsNil)
+		f, err = OpenBinary(buf.Bytes(), option)
+		c.Assert(err, qt.IsNil)
+		th := f.Theme()
+		c.Assert(th.Colors.Dark1, qt.Equals, "102030")
+		c.Assert(th.Colors.Accent1, qt.Equals, "AABBCC")
+		c.Assert(th.Colors.Accent2, qt.Equals, "C0504D")
+		c.Assert(th.MajorFont, qt.Equals, "Cambria")
+		c.Assert(th.MinorFont, qt.Equals, "Segoe UI")
+
+		// A theme that was read is written back as it was found.
+		var buf2 bytes.Buffer
+		c.Assert(f.Write(&buf2), qt.IsNil)
+		parts, err = f.MakeStreamParts()
+		c.Assert(err, qt.IsNil)
+		c.Assert(parts["xl/theme/theme1.xml"], qt.Equals, themeXML)
+	})
+}
@@ -0,0 +1,199 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	errNotSlicePointer = errors.New("argument must be a pointer to a slice of structs or of pointers to structs")
+	unmarshalerType    = reflect.TypeOf((*XLSXUnmarshaler)(nil)).Elem()
+)
+
+// UnmarshalOptions control Sheet.Unmarshal and ReadAll.
+type UnmarshalOptions struct {
+	// HeaderRows is the number of rows at the top of the Sheet that
+	// hold headers rather than data.  They are skipped, and fields
+	// tagged with the name of their column are matched against the
+	// last of them.
+	HeaderRows int
+	// DisallowUnknownColumns makes a header that no field maps to an
+	// error.
+	DisallowUnknownColumns bool
+	// MaxErrors is the most cells that can fail to unmarshal before
+	// giving up.  Zero, like one, stops at the first failure; a
+	// negative number reads the whole Sheet whatever happens.
+	MaxErrors int
+}
+
+// CellError describes a cell whose value couldn't be unmarshalled into
+// a field.
+type CellError struct {
+	Sheet string // The name of the Sheet
+	Cell  string // The cell reference, such as "B7", or the row, such as "7:7"
+	Field string // The field, such as "Address.City", if known
+	Value string // The raw value of the cell
+	Err   error  // Why it couldn't be unmarshalled
+}
+
+func (e *CellError) Error() string {
+	var b strings.Builder
+	if e.Sheet != "" {
+		b.WriteString(e.Sheet)
+		b.WriteString("!")
+	}
+	b.WriteString(e.Cell)
+	if e.Field != "" {
+		fmt.Fprintf(&b, ": field %s, value %q", e.Field, e.Value)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalError lists every cell that Sheet.Unmarshal failed to read.
+type UnmarshalError struct {
+	Errors []*CellError
+	// Truncated is true if Unmarshal gave up, after reaching
+	// MaxErrors, before reading the whole Sheet.
+	Truncated bool
+}
+
+func (e *UnmarshalError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	more := ""
+	if e.Truncated {
+		more = " (and possibly more)"
+	}
+	return fmt.Sprintf("%d errors%s: %s", len(e.Errors), more, strings.Join(messages, "; "))
+}
+
+// Unmarshal reads every row of the Sheet, after any header rows, into
+// a value appended to the slice that dst points to.  The slice may be
+// of structs or of pointers to structs, or of any type whose pointer
+// implements XLSXUnmarshaler.  Fields are mapped to
+// columns by position or by header, as described for HeaderDecoder,
+// and types that implement XLSXUnmarshaler read each row themselves.
+// Empty rows are skipped.
+//
+// If the header row lacks a required column, a *HeaderError is
+// returned and nothing is read.  Otherwise rows with cells that can't
+// be read are left out of the slice and reported, together, in an
+// *UnmarshalError.
+func (s *Sheet) Unmarshal(dst interface{}, opts UnmarshalOptions) error {
+	s.mustBeOpen()
+	if dst == nil {
+		return errNilInterface
+	}
+	slice := reflect.ValueOf(dst)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return errNotSlicePointer
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	isUnmarshaler := reflect.PtrTo(structType).Implements(unmarshalerType)
+	if structType.Kind() != reflect.Struct && !isUnmarshaler {
+		return errNotSlicePointer
+	}
+
+	d := &HeaderDecoder{sheet: s, headerRow: -1, columns: map[string]int{}}
+	if opts.HeaderRows > 0 && opts.HeaderRows <= s.MaxRow {
+		var err error
+		d, err = s.NewHeaderDecoder(opts.HeaderRows - 1)
+		if err != nil {
+			return fmt.Errorf("Unmarshal: %w", err)
+		}
+	}
+	d.DisallowUnknownColumns = opts.DisallowUnknownColumns
+	var m *headerMapping
+	if !isUnmarshaler {
+		var err error
+		m, err = d.mappingFor(structType)
+		if err != nil {
+			return fmt.Errorf("Unmarshal: %w", err)
+		}
+		if err := d.check(m); err != nil {
+			return fmt.Errorf("Unmarshal: %w", err)
+		}
+	}
+
+	maxErrors := opts.MaxErrors
+	if maxErrors == 0 {
+		maxErrors = 1
+	}
+	result := &UnmarshalError{}
+	report := func(err *CellError) bool {
+		result.Errors = append(result.Errors, err)
+		if maxErrors > 0 && len(result.Errors) >= maxErrors {
+			result.Truncated = true
+			return false
+		}
+		return true
+	}
+
+	errStop := errors.New("stop")
+	err := s.ForEachRow(func(row *Row) error {
+		if row.num < opts.HeaderRows || row.cellStoreRow.CellCount() == 0 {
+			return nil
+		}
+		ptr := reflect.New(structType)
+		failed := false
+		keepGoing := true
+		if isUnmarshaler {
+			if err := ptr.Interface().(XLSXUnmarshaler).Unmarshal(row); err != nil {
+				failed = true
+				ref := strconv.Itoa(row.num + 1)
+				keepGoing = report(&CellError{Sheet: s.Name, Cell: ref + ":" + ref, Err: err})
+			}
+		} else {
+			d.decode(row, ptr.Elem(), m, func(err *CellError) bool {
+				failed = true
+				keepGoing = report(err)
+				return keepGoing
+			})
+		}
+		if !failed {
+			if elemType.Kind() == reflect.Ptr {
+				slice.Set(reflect.Append(slice, ptr))
+			} else {
+				slice.Set(reflect.Append(slice, ptr.Elem()))
+			}
+		}
+		if !keepGoing {
+			return errStop
+		}
+		return nil
+	}, SkipEmptyRows)
+	if err != nil && err != errStop {
+		return fmt.Errorf("Unmarshal: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return result
+	}
+	return nil
+}
+
+// ReadAll reads every row of the Sheet, after any header rows, into a
+// slice of T, which must be a struct type or a pointer to one.  See
+// Sheet.Unmarshal for the details.
+func ReadAll[T any](s *Sheet, opts UnmarshalOptions) ([]T, error) {
+	var records []T
+	err := s.Unmarshal(&records, opts)
+	return records, err
+}
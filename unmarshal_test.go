@@ -0,0 +1,124 @@
+package xlsx
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type unmarshalRecord struct {
+	ID    int     `xlsx:"name=ID;required"`
+	Name  string  `xlsx:"name=Name"`
+	Price float64 `xlsx:"name=Price"`
+}
+
+type positionalRecord struct {
+	ID   int    `xlsx:"0"`
+	Name string `xlsx:"1"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := qt.New(t)
+
+	makeSheet := func(c *qt.C, option FileOption, rows ...[]string) *Sheet {
+		file := NewFile(option)
+		sheet, err := file.AddSheet("Products")
+		c.Assert(err, qt.IsNil)
+		for _, values := range rows {
+			row := sheet.AddRow()
+			for _, value := range values {
+				row.AddCell().SetString(value)
+			}
+		}
+		return sheet
+	}
+
+	csRunO(c, "ByHeader", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option,
+			[]string{"Product list"},
+			[]string{"Name", "Price", "ID"},
+			[]string{"Widget", "2.5", "1"},
+			[]string{},
+			[]string{"Gadget", "10", "2"},
+		)
+		var records []unmarshalRecord
+		c.Assert(sheet.Unmarshal(&records, UnmarshalOptions{HeaderRows: 2}), qt.IsNil)
+		c.Assert(records, qt.DeepEquals, []unmarshalRecord{
+			{ID: 1, Name: "Widget", Price: 2.5},
+			{ID: 2, Name: "Gadget", Price: 10},
+		})
+
+		pointers, err := ReadAll[*unmarshalRecord](sheet, UnmarshalOptions{HeaderRows: 2})
+		c.Assert(err, qt.IsNil)
+		c.Assert(pointers, qt.HasLen, 2)
+		c.Assert(*pointers[1], qt.Equals, unmarshalRecord{ID: 2, Name: "Gadget", Price: 10})
+	})
+
+	csRunO(c, "Positional", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, []string{"7", "seven"}, []string{"8", "eight"})
+		records, err := ReadAll[positionalRecord](sheet, UnmarshalOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(records, qt.DeepEquals, []positionalRecord{{7, "seven"}, {8, "eight"}})
+	})
+
+	csRunO(c, "Errors", func(c *qt.C, option FileOption) {
+		rows := [][]string{{"ID", "Name", "Price"}}
+		for i := 0; i < 5; i++ {
+			rows = append(rows, []string{strconv.Itoa(i), "item", "cheap"})
+		}
+		rows = append(rows, []string{"x", "item", "1"})
+		sheet := makeSheet(c, option, rows...)
+
+		records, err := ReadAll[unmarshalRecord](sheet, UnmarshalOptions{HeaderRows: 1})
+		c.Assert(records, qt.HasLen, 0)
+		var unmarshalErr *UnmarshalError
+		c.Assert(errors.As(err, &unmarshalErr), qt.IsTrue)
+		c.Assert(unmarshalErr.Errors, qt.HasLen, 1)
+		c.Assert(unmarshalErr.Truncated, qt.IsTrue)
+		first := unmarshalErr.Errors[0]
+		c.Assert(first.Sheet, qt.Equals, "Products")
+		c.Assert(first.Cell, qt.Equals, "C2")
+		c.Assert(first.Field, qt.Equals, "Price")
+		c.Assert(first.Value, qt.Equals, "cheap")
+		c.Assert(first.Err, qt.Not(qt.IsNil))
+		c.Assert(err, qt.ErrorMatches, `Products!C2: field Price, value "cheap": .*`)
+
+		_, err = ReadAll[unmarshalRecord](sheet, UnmarshalOptions{HeaderRows: 1, MaxErrors: 3})
+		c.Assert(errors.As(err, &unmarshalErr), qt.IsTrue)
+		c.Assert(unmarshalErr.Errors, qt.HasLen, 3)
+		c.Assert(unmarshalErr.Truncated, qt.IsTrue)
+		c.Assert(err, qt.ErrorMatches, `3 errors \(and possibly more\): Products!C2: .*; Products!C3: .*; Products!C4: .*`)
+
+		_, err = ReadAll[unmarshalRecord](sheet, UnmarshalOptions{HeaderRows: 1, MaxErrors: -1})
+		c.Assert(errors.As(err, &unmarshalErr), qt.IsTrue)
+		c.Assert(unmarshalErr.Errors, qt.HasLen, 6)
+		c.Assert(unmarshalErr.Truncated, qt.IsFalse)
+		c.Assert(unmarshalErr.Errors[5].Cell, qt.Equals, "A7")
+		c.Assert(unmarshalErr.Errors[5].Field, qt.Equals, "ID")
+	})
+
+	csRunO(c, "Unmarshaler", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, []string{"2"}, []string{"3"}, []string{"4"})
+		values, err := ReadAll[pairUnmarshaler](sheet, UnmarshalOptions{MaxErrors: -1})
+		c.Assert(values, qt.DeepEquals, []pairUnmarshaler{2, 4})
+		var unmarshalErr *UnmarshalError
+		c.Assert(errors.As(err, &unmarshalErr), qt.IsTrue)
+		c.Assert(unmarshalErr.Errors[0].Cell, qt.Equals, "2:2")
+		c.Assert(errors.Is(err, errorNoPair), qt.IsFalse)
+		c.Assert(errors.Is(unmarshalErr.Errors[0], errorNoPair), qt.IsTrue)
+	})
+
+	csRunO(c, "BadArguments", func(c *qt.C, option FileOption) {
+		sheet := makeSheet(c, option, []string{"Name"})
+		c.Assert(sheet.Unmarshal(nil, UnmarshalOptions{}), qt.Equals, errNilInterface)
+		c.Assert(sheet.Unmarshal([]unmarshalRecord{}, UnmarshalOptions{}), qt.Equals, errNotSlicePointer)
+		c.Assert(sheet.Unmarshal(&[]int{}, UnmarshalOptions{}), qt.Equals, errNotSlicePointer)
+		var records []unmarshalRecord
+		err := sheet.Unmarshal(&records, UnmarshalOptions{HeaderRows: 1})
+		var headerErr *HeaderError
+		c.Assert(errors.As(err, &headerErr), qt.IsTrue)
+		c.Assert(headerErr.Missing, qt.DeepEquals, []string{"ID"})
+	})
+}
@@ -20,3 +20,19 @@ func u8Ptr(u uint8) *uint8 {
 func iPtr(i int) *int {
 	return &i
 }
+
+// equalIntPtr compares optional ints, for which nil and non-nil are
+// always different.
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalBoolPtr(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -1,9 +1,9 @@
 package xlsx
 
 import (
-	"database/sql"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -30,65 +30,13 @@ func (r *Row) WriteSlice(e interface{}, cols int) int {
 	if cols < n && cols > 0 {
 		n = cols
 	}
-	var setCell func(reflect.Value)
-	setCell = func(val reflect.Value) {
-		switch t := val.Interface().(type) {
-		case time.Time:
-			cell := r.AddCell()
-			cell.SetValue(t)
-		case fmt.Stringer: // check Stringer first
-			cell := r.AddCell()
-			cell.SetString(t.String())
-		case sql.NullString: // check null sql types nulls = ''
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.String)
-			}
-		case sql.NullTime:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Time)
-			}
-		case sql.NullBool:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetBool(t.Bool)
-			}
-		case sql.NullInt64:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Int64)
-			}
-		case sql.NullInt32:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Int32)
-			}
-		case sql.NullFloat64:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Float64)
-			}
-		default:
-			switch val.Kind() { // underlying type of slice
-			case reflect.String,
-				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-				reflect.Float64, reflect.Float32:
-				cell := r.AddCell()
-				cell.SetValue(val.Interface())
-			case reflect.Bool:
-				cell := r.AddCell()
-				cell.SetBool(t.(bool))
-			case reflect.Interface:
-				setCell(reflect.ValueOf(t))
-			}
-		}
-	}
-
 	var i int
 	for i = 0; i < n; i++ {
-		setCell(v.Index(i))
+		if set := cellSetter(v.Index(i), false); set != nil {
+			// There's no way to report an error, so the cell is
+			// left as the value's setter left it.
+			_ = set(r.AddCell())
+		}
 	}
 	return i
 }
@@ -96,7 +44,11 @@ func (r *Row) WriteSlice(e interface{}, cols int) int {
 // Writes a struct to row r. Accepts a pointer to struct type 'e',
 // and the number of columns to write, `cols`. If 'cols' is < 0,
 // the entire struct will be written if possible. Returns -1 if the 'e'
-// doesn't point to a struct, otherwise the number of columns written
+// doesn't point to a struct, otherwise the number of columns written.
+// The omitempty, layout and split options of each field's xlsx tag
+// are honoured, and a field whose tag can't be parsed is skipped.
+// Pointer fields are skipped unless the pointer is a fmt.Stringer or
+// marshals itself; Sheet.Marshal follows them.
 func (r *Row) WriteStruct(e interface{}, cols int) int {
 	if cols == 0 {
 		return cols
@@ -114,61 +66,129 @@ func (r *Row) WriteStruct(e interface{}, cols int) int {
 
 	var k int
 	for i := 0; i < n; i, k = i+1, k+1 {
-		f := v.Field(i)
+		tag, err := parseFieldTag(v.Type().Field(i).Tag.Get("xlsx"))
+		var set func(*Cell) error
+		if err == nil {
+			set = tagSetter(v.Field(i), tag, false)
+		}
+		if set == nil {
+			k-- // nothing set so reset to previous
+			continue
+		}
+		_ = set(r.AddCell())
+	}
+
+	return k
+}
 
-		switch t := f.Interface().(type) {
-		case time.Time:
-			cell := r.AddCell()
+// tagSetter is cellSetter for a field with the tag tag: zero values are
+// left empty if it says omitempty, times are written as text in its
+// layout, and slices and arrays are joined by its split separator.
+func tagSetter(val reflect.Value, tag fieldTag, deref bool) func(*Cell) error {
+	if !val.IsValid() {
+		return nil
+	}
+	if tag.omitempty && val.IsZero() {
+		return func(*Cell) error { return nil }
+	}
+	elem := val
+	for deref && elem.Kind() == reflect.Ptr && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if tag.layout != "" && elem.Type() == timeType && elem.CanInterface() {
+		t := elem.Interface().(time.Time)
+		return func(cell *Cell) error {
+			cell.SetString(t.Format(tag.layout))
+			return nil
+		}
+	}
+	if tag.split != "" && (elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array) && elem.CanInterface() {
+		parts := make([]string, elem.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(elem.Index(i).Interface())
+		}
+		return func(cell *Cell) error {
+			cell.SetString(strings.Join(parts, tag.split))
+			return nil
+		}
+	}
+	return cellSetter(val, deref)
+}
+
+// cellSetter returns a function that sets a cell to val, or nil if
+// val is of a type that can't be written to a cell.  The sql.Null*
+// types give an empty cell when not Valid.  With deref, pointers are
+// followed and nil ones give an empty cell.  Without it, as Row.WriteStruct
+// has always done, a pointer is only written if it is a fmt.Stringer or
+// can marshal itself, and is otherwise skipped.
+func cellSetter(val reflect.Value, deref bool) func(*Cell) error {
+	if !val.IsValid() {
+		return nil
+	}
+	if val.Kind() == reflect.Ptr && !deref {
+		return pointerSetter(val)
+	}
+	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return func(*Cell) error { return nil }
+		}
+		if val.Kind() == reflect.Interface || val.Type() == timePtrType {
+			return cellSetter(val.Elem(), deref)
+		}
+	}
+	if !val.CanInterface() {
+		return nil
+	}
+	if t, ok := val.Interface().(time.Time); ok {
+		return func(cell *Cell) error {
 			cell.SetValue(t)
-		case fmt.Stringer: // check Stringer first
-			cell := r.AddCell()
+			return nil
+		}
+	}
+	if set := marshalSetter(val); set != nil {
+		return set
+	}
+	if t, ok := val.Interface().(fmt.Stringer); ok {
+		return func(cell *Cell) error {
 			cell.SetString(t.String())
-		case sql.NullString: // check null sql types nulls = ''
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.String)
-			}
-		case sql.NullTime:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Time)
-			}
-		case sql.NullBool:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetBool(t.Bool)
-			}
-		case sql.NullInt64:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Int64)
-			}
-		case sql.NullInt32:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Int32)
-			}
-		case sql.NullFloat64:
-			cell := r.AddCell()
-			if cell.SetString(``); t.Valid {
-				cell.SetValue(t.Float64)
-			}
-		default:
-			switch f.Kind() {
-			case reflect.String,
-				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-				reflect.Float64, reflect.Float32:
-				cell := r.AddCell()
-				cell.SetValue(f.Interface())
-			case reflect.Bool:
-				cell := r.AddCell()
-				cell.SetBool(t.(bool))
-			default:
-				k-- // nothing set so reset to previous
-			}
+			return nil
 		}
 	}
+	var set func(*Cell)
+	switch val.Kind() {
+	case reflect.Ptr:
+		return cellSetter(val.Elem(), deref)
+	case reflect.String:
+		set = func(cell *Cell) { cell.SetString(val.String()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		set = func(cell *Cell) { cell.SetInt64(val.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		set = func(cell *Cell) { cell.SetValue(val.Uint()) }
+	case reflect.Float32:
+		set = func(cell *Cell) { cell.SetValue(float32(val.Float())) }
+	case reflect.Float64:
+		set = func(cell *Cell) { cell.SetValue(val.Float()) }
+	case reflect.Bool:
+		set = func(cell *Cell) { cell.SetBool(val.Bool()) }
+	default:
+		return nil
+	}
+	return func(cell *Cell) error {
+		set(cell)
+		return nil
+	}
+}
 
-	return k
+// pointerSetter is cellSetter for a pointer that isn't to be followed.
+func pointerSetter(val reflect.Value) func(*Cell) error {
+	if val.IsNil() || !val.CanInterface() {
+		return nil
+	}
+	if t, ok := val.Interface().(fmt.Stringer); ok {
+		return func(cell *Cell) error {
+			cell.SetString(t.String())
+			return nil
+		}
+	}
+	return marshalSetter(val)
 }
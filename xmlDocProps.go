@@ -0,0 +1,74 @@
+package xlsx
+
+import "encoding/xml"
+
+const (
+	coreNamespace     = "http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
+	dcNamespace       = "http://purl.org/dc/elements/1.1/"
+	dctermsNamespace  = "http://purl.org/dc/terms/"
+	extendedNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+	customNamespace   = "http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"
+	vtNamespace       = "http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"
+
+	// customPropertyFmtID is the format identifier Office uses for
+	// every user defined custom property.
+	customPropertyFmtID = "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}"
+)
+
+// xlsxCoreProperties directly maps the coreProperties element in the
+// namespace http://schemas.openxmlformats.org/package/2006/metadata/core-properties
+// - currently I have not checked it for completeness - it does as
+// much as I need.
+type xlsxCoreProperties struct {
+	XMLName        xml.Name `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties coreProperties"`
+	Title          string   `xml:"http://purl.org/dc/elements/1.1/ title"`
+	Subject        string   `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	Creator        string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Keywords       string   `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties keywords"`
+	Description    string   `xml:"http://purl.org/dc/elements/1.1/ description"`
+	LastModifiedBy string   `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties lastModifiedBy"`
+	Revision       string   `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties revision"`
+	Created        string   `xml:"http://purl.org/dc/terms/ created"`
+	Modified       string   `xml:"http://purl.org/dc/terms/ modified"`
+	Category       string   `xml:"http://schemas.openxmlformats.org/package/2006/metadata/core-properties category"`
+}
+
+// xlsxExtendedProperties directly maps the Properties element in the
+// namespace http://schemas.openxmlformats.org/officeDocument/2006/extended-properties
+// - currently I have not checked it for completeness - it does as
+// much as I need.
+type xlsxExtendedProperties struct {
+	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/officeDocument/2006/extended-properties Properties"`
+	Manager     string   `xml:"Manager"`
+	Company     string   `xml:"Company"`
+	Application string   `xml:"Application"`
+}
+
+// xlsxCustomProperties directly maps the Properties element in the
+// namespace http://schemas.openxmlformats.org/officeDocument/2006/custom-properties
+// - currently I have not checked it for completeness - it does as
+// much as I need.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty directly maps the property element in the
+// namespace http://schemas.openxmlformats.org/officeDocument/2006/custom-properties
+// - currently I have not checked it for completeness - it does as
+// much as I need.
+type xlsxCustomProperty struct {
+	FmtID string      `xml:"fmtid,attr"`
+	PID   int         `xml:"pid,attr"`
+	Name  string      `xml:"name,attr"`
+	Value xlsxVariant `xml:",any"`
+}
+
+// xlsxVariant maps any of the elements in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes
+// that hold a single value, such as vt:lpwstr or vt:i4.  The type is
+// given by the local name of the element.
+type xlsxVariant struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
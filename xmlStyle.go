@@ -161,7 +161,10 @@ type xlsxStyleSheet struct {
 	NumFmts      *xlsxNumFmts      `xml:"numFmts,omitempty"`
 	DXfs         xlsxDXFs          `xml:"dxfs"`
 
-	theme *theme
+	theme        *theme
+	defaultStyle *Style
+	namedStyles  []namedStyle
+	styleIDXfs   map[styleIDXf]int
 
 	styleCacheMU        sync.RWMutex
 	styleCache          map[int]*Style
@@ -171,10 +174,14 @@ type xlsxStyleSheet struct {
 	parsedNumFmtTable   map[string]*parsedNumberFormat
 }
 
-func newXlsxStyleSheet(t *theme) *xlsxStyleSheet {
+// newXlsxStyleSheet returns an empty style sheet.  When defaultStyle
+// isn't nil it supplies the default font, fill, border and alignment
+// written for the "Normal" style.
+func newXlsxStyleSheet(t *theme, defaultStyle *Style) *xlsxStyleSheet {
 	return &xlsxStyleSheet{
-		theme:      t,
-		styleCache: make(map[int]*Style),
+		theme:        t,
+		defaultStyle: defaultStyle,
+		styleCache:   make(map[int]*Style),
 	}
 }
 
@@ -182,39 +189,62 @@ func (styles *xlsxStyleSheet) reset() {
 	styles.Fonts = xlsxFonts{}
 	styles.Fills = xlsxFills{}
 	styles.Borders = xlsxBorders{}
-
-	// Microsoft seems to want Arial 11 defined by default.
-	styles.addFont(
-		xlsxFont{
-			Sz:     xlsxVal{"11"},
-			Family: xlsxVal{"2"},
-			Color:  xlsxColor{Theme: &defaultTheme},
-			Name:   xlsxVal{"Arial"},
-			Scheme: &xlsxVal{"minor"},
-		},
-	)
-
-	styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "none"}})
-	styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "gray125"}})
-
-	// Microsoft seems to want an emtpy border to start with
-	styles.addBorder(
-		xlsxBorder{
-			Left:   xlsxLine{},
-			Right:  xlsxLine{},
-			Top:    xlsxLine{},
-			Bottom: xlsxLine{},
-		})
+	styles.styleIDXfs = nil
+
+	var normalXf xlsxXf
+	if styles.defaultStyle != nil {
+		// The first font is the one Excel measures column
+		// widths with, so it must be the default font.
+		xFont, xFill, xBorder, xf := styles.defaultStyle.makeXLSXStyleElements()
+		styles.addFont(xFont)
+		styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "none"}})
+		styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "gray125"}})
+		styles.addBorder(xBorder)
+		xf.FillId = styles.addFill(xFill)
+		xf.XfId = nil
+		normalXf = xf
+	} else {
+		// Microsoft seems to want Arial 11 defined by default.
+		styles.addFont(
+			xlsxFont{
+				Sz:     xlsxVal{"11"},
+				Family: xlsxVal{"2"},
+				Color:  xlsxColor{Theme: &defaultTheme},
+				Name:   xlsxVal{"Arial"},
+				Scheme: &xlsxVal{"minor"},
+			},
+		)
+
+		styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "none"}})
+		styles.addFill(xlsxFill{PatternFill: xlsxPatternFill{PatternType: "gray125"}})
+
+		// Microsoft seems to want an emtpy border to start with
+		styles.addBorder(
+			xlsxBorder{
+				Left:   xlsxLine{},
+				Right:  xlsxLine{},
+				Top:    xlsxLine{},
+				Bottom: xlsxLine{},
+			})
+	}
 
 	// add 0th CellStyleXf by default, as required by the standard
-	styles.CellStyleXfs = &xlsxCellStyleXfs{Count: 1, Xf: []xlsxXf{{}}}
+	styles.CellStyleXfs = &xlsxCellStyleXfs{Count: 1, Xf: []xlsxXf{normalXf}}
 
 	// add 0th CellXf by default, as required by the standard
-	styles.CellXfs = xlsxCellXfs{Count: 1, Xf: []xlsxXf{{}}}
+	styles.CellXfs = xlsxCellXfs{Count: 1, Xf: []xlsxXf{normalXf}}
 	styles.NumFmts = &xlsxNumFmts{}
 	styles.numFmtRefTableMU.Lock()
 	styles.numFmtRefTable = nil
 	styles.numFmtRefTableMU.Unlock()
+	styles.makeNamedStyles()
+}
+
+// normalStyle resets the style sheet and returns the style of its
+// cell format 0.
+func (styles *xlsxStyleSheet) normalStyle() *Style {
+	styles.reset()
+	return styles.getStyle(0)
 }
 
 func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
@@ -227,12 +257,21 @@ func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
 		border := styles.Borders.Border[xf.BorderId]
 		style.Border.Left = border.Left.Style
 		style.Border.LeftColor = styles.argbValue(border.Left.Color)
+		style.Border.LeftColorSpec = colorSpec(border.Left.Color)
 		style.Border.Right = border.Right.Style
 		style.Border.RightColor = styles.argbValue(border.Right.Color)
+		style.Border.RightColorSpec = colorSpec(border.Right.Color)
 		style.Border.Top = border.Top.Style
 		style.Border.TopColor = styles.argbValue(border.Top.Color)
+		style.Border.TopColorSpec = colorSpec(border.Top.Color)
 		style.Border.Bottom = border.Bottom.Style
 		style.Border.BottomColor = styles.argbValue(border.Bottom.Color)
+		style.Border.BottomColorSpec = colorSpec(border.Bottom.Color)
+		style.Border.Diagonal = border.Diagonal.Style
+		style.Border.DiagonalColor = styles.argbValue(border.Diagonal.Color)
+		style.Border.DiagonalColorSpec = colorSpec(border.Diagonal.Color)
+		style.Border.DiagonalUp = border.DiagonalUp
+		style.Border.DiagonalDown = border.DiagonalDown
 	}
 
 	if xf.FillId > -1 && xf.FillId < styles.Fills.Count {
@@ -240,6 +279,25 @@ func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
 		style.Fill.PatternType = xFill.PatternFill.PatternType
 		style.Fill.FgColor = styles.argbValue(xFill.PatternFill.FgColor)
 		style.Fill.BgColor = styles.argbValue(xFill.PatternFill.BgColor)
+		style.Fill.FgColorSpec = colorSpec(xFill.PatternFill.FgColor)
+		style.Fill.BgColorSpec = colorSpec(xFill.PatternFill.BgColor)
+		if gradient := xFill.GradientFill; gradient != nil {
+			style.Fill.Gradient = &GradientFill{
+				Type:   gradient.Type,
+				Degree: gradient.Degree,
+				Left:   gradient.Left,
+				Right:  gradient.Right,
+				Top:    gradient.Top,
+				Bottom: gradient.Bottom,
+			}
+			for _, stop := range gradient.Stop {
+				style.Fill.Gradient.Stops = append(style.Fill.Gradient.Stops, GradientStop{
+					Position:  stop.Position,
+					Color:     styles.argbValue(stop.Color),
+					ColorSpec: colorSpec(stop.Color),
+				})
+			}
+		}
 	}
 
 	if xf.FontId > -1 && xf.FontId < styles.Fonts.Count {
@@ -249,6 +307,7 @@ func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
 		style.Font.Family, _ = strconv.Atoi(xfont.Family.Val)
 		style.Font.Charset, _ = strconv.Atoi(xfont.Charset.Val)
 		style.Font.Color = styles.argbValue(xfont.Color)
+		style.Font.ColorSpec = colorSpec(xfont.Color)
 
 		if bold := xfont.B; bold != nil && bold.Val != "0" {
 			style.Font.Bold = true
@@ -256,12 +315,21 @@ func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
 		if italic := xfont.I; italic != nil && italic.Val != "0" {
 			style.Font.Italic = true
 		}
-		if underline := xfont.U; underline != nil && underline.Val != "0" {
+		if underline := xfont.U; underline != nil && underline.Val != "0" && underline.Val != "none" {
 			style.Font.Underline = true
+			if underline.Val != UnderlineSingle {
+				style.Font.UnderlineStyle = underline.Val
+			}
 		}
 		if strike := xfont.Strike; strike != nil && strike.Val != "0" {
 			style.Font.Strike = true
 		}
+		if vertAlign := xfont.VertAlign; vertAlign != nil {
+			style.Font.VertAlign = vertAlign.Val
+		}
+		if scheme := xfont.Scheme; scheme != nil {
+			style.Font.Scheme = scheme.Val
+		}
 	}
 	if xf.Alignment.Horizontal != "" {
 		style.Alignment.Horizontal = xf.Alignment.Horizontal
@@ -278,6 +346,13 @@ func (styles *xlsxStyleSheet) populateStyleFromXf(style *Style, xf xlsxXf) {
 	if xf.Alignment.Indent != 0 {
 		style.Alignment.Indent = xf.Alignment.Indent
 	}
+	style.Alignment.RelativeIndent = xf.Alignment.RelativeIndent
+	style.Alignment.ReadingOrder = xf.Alignment.ReadingOrder
+
+	style.ApplyProtection = xf.ApplyProtection
+	if xf.Protection != nil {
+		style.Protection = NewProtection(xf.Protection.locked(), xf.Protection.hidden())
+	}
 
 }
 
@@ -297,11 +372,13 @@ func (styles *xlsxStyleSheet) getStyle(styleIndex int) *Style {
 		styles.populateStyleFromXf(style, xf)
 		if xf.XfId != nil && styles.CellStyleXfs != nil && *xf.XfId < len(styles.CellStyleXfs.Xf) {
 			style.NamedStyleIndex = xf.XfId
+			style.NamedStyle = styles.namedStyleName(*xf.XfId)
 			namedStyleXf := styles.CellStyleXfs.Xf[*xf.XfId]
 			style.ApplyBorder = style.ApplyBorder || namedStyleXf.ApplyBorder
 			style.ApplyFill = style.ApplyFill || namedStyleXf.ApplyFill
 			style.ApplyFont = style.ApplyFont || namedStyleXf.ApplyFont
 			style.ApplyAlignment = style.ApplyAlignment || namedStyleXf.ApplyAlignment
+			style.ApplyProtection = style.ApplyProtection || namedStyleXf.ApplyProtection
 		}
 
 		if xf.Alignment.Vertical != "" {
@@ -317,14 +394,25 @@ func (styles *xlsxStyleSheet) getStyle(styleIndex int) *Style {
 	return style
 }
 
+// argbValue returns the ARGB value of a colour, with its tint applied.
+// The automatic colour, and theme colours of a style sheet without a
+// theme, have no value of their own.
 func (styles *xlsxStyleSheet) argbValue(color xlsxColor) string {
-	if color.Theme != nil && styles.theme != nil {
+	if color.Theme != nil && styles != nil && styles.theme != nil {
 		return styles.theme.themeColor(int64(*color.Theme), color.Tint)
 	}
-	if color.Indexed != nil && styles.Colors != nil {
-		return styles.Colors.indexedColor(*color.Indexed)
+	argb := color.RGB
+	if color.Indexed != nil {
+		var colors *xlsxColors
+		if styles != nil {
+			colors = styles.Colors
+		}
+		argb = colors.indexedColor(*color.Indexed)
+	}
+	if argb == "" || color.Tint == 0 {
+		return argb
 	}
-	return color.RGB
+	return applyTint(argb, color.Tint)
 }
 
 // Excel styles can reference number formats that are built-in, all of which
@@ -546,6 +634,9 @@ func (styles *xlsxStyleSheet) Marshal() (string, error) {
 		}
 		result += xcellStyles
 	}
+	if styles.Colors != nil {
+		result += styles.Colors.Marshal()
+	}
 
 	return result + "</styleSheet>", nil
 }
@@ -642,16 +733,17 @@ func (fonts *xlsxFonts) Marshal(outputFontMap map[int]int) (result string, err e
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxFont struct {
-	Sz      xlsxVal   `xml:"sz,omitempty"`
-	Name    xlsxVal   `xml:"name,omitempty"`
-	Family  xlsxVal   `xml:"family,omitempty"`
-	Charset xlsxVal   `xml:"charset,omitempty"`
-	Color   xlsxColor `xml:"color,omitempty"`
-	B       *xlsxVal  `xml:"b,omitempty"`
-	I       *xlsxVal  `xml:"i,omitempty"`
-	U       *xlsxVal  `xml:"u,omitempty"`
-	Scheme  *xlsxVal  `xml:"scheme,omitempty"`
-	Strike  *xlsxVal  `xml:"strike,omitempty"`
+	Sz        xlsxVal   `xml:"sz,omitempty"`
+	Name      xlsxVal   `xml:"name,omitempty"`
+	Family    xlsxVal   `xml:"family,omitempty"`
+	Charset   xlsxVal   `xml:"charset,omitempty"`
+	Color     xlsxColor `xml:"color,omitempty"`
+	B         *xlsxVal  `xml:"b,omitempty"`
+	I         *xlsxVal  `xml:"i,omitempty"`
+	U         *xlsxVal  `xml:"u,omitempty"`
+	Scheme    *xlsxVal  `xml:"scheme,omitempty"`
+	Strike    *xlsxVal  `xml:"strike,omitempty"`
+	VertAlign *xlsxVal  `xml:"vertAlign,omitempty"`
 }
 
 func (font *xlsxFont) Equals(other xlsxFont) bool {
@@ -661,7 +753,8 @@ func (font *xlsxFont) Equals(other xlsxFont) bool {
 	if (font.I == nil && other.I != nil) || (font.I != nil && other.I == nil) {
 		return false
 	}
-	if (font.U == nil && other.U != nil) || (font.U != nil && other.U == nil) {
+	if !font.U.equalsPtr(other.U) || !font.Strike.equalsPtr(other.Strike) ||
+		!font.VertAlign.equalsPtr(other.VertAlign) || !font.Scheme.equalsPtr(other.Scheme) {
 		return false
 	}
 	return font.Sz.Equals(other.Sz) && font.Name.Equals(other.Name) && font.Family.Equals(other.Family) && font.Charset.Equals(other.Charset) && font.Color.Equals(other.Color)
@@ -681,12 +774,7 @@ func (font *xlsxFont) Marshal() (result string, err error) {
 	if font.Charset.Val != "" {
 		result += fmt.Sprintf(`<charset val="%s"/>`, font.Charset.Val)
 	}
-	if font.Color.RGB != "" {
-		result += fmt.Sprintf(`<color rgb="%s"/>`, font.Color.RGB)
-	}
-	if font.Color.Theme != nil {
-		result += fmt.Sprintf(`<color theme="%d" />`, *font.Color.Theme)
-	}
+	result += font.Color.marshal("color")
 	if font.Scheme != nil && font.Scheme.Val != "" {
 		result += fmt.Sprintf(`<scheme val="%s"/>`, font.Scheme.Val)
 	}
@@ -697,11 +785,18 @@ func (font *xlsxFont) Marshal() (result string, err error) {
 		result += "<i/>"
 	}
 	if font.U != nil {
-		result += "<u/>"
+		if font.U.Val != "" {
+			result += fmt.Sprintf(`<u val="%s"/>`, font.U.Val)
+		} else {
+			result += "<u/>"
+		}
 	}
 	if font.Strike != nil {
 		result += "<strike/>"
 	}
+	if font.VertAlign != nil && font.VertAlign.Val != "" {
+		result += fmt.Sprintf(`<vertAlign val="%s"/>`, font.VertAlign.Val)
+	}
 	return result + "</font>", nil
 }
 
@@ -717,6 +812,15 @@ func (val *xlsxVal) Equals(other xlsxVal) bool {
 	return val.Val == other.Val
 }
 
+// equalsPtr compares optional values, for which nil and non-nil are
+// always different.
+func (val *xlsxVal) equalsPtr(other *xlsxVal) bool {
+	if val == nil || other == nil {
+		return val == nil && other == nil
+	}
+	return val.Val == other.Val
+}
+
 // xlsxFills directly maps the fills element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main -
 // currently I have not checked it for completeness - it does as much
@@ -759,14 +863,27 @@ func (fills *xlsxFills) Marshal(outputFillMap map[int]int) (string, error) {
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxFill struct {
-	PatternFill xlsxPatternFill `xml:"patternFill,omitempty"`
+	PatternFill  xlsxPatternFill   `xml:"patternFill,omitempty"`
+	GradientFill *xlsxGradientFill `xml:"gradientFill,omitempty"`
 }
 
 func (fill *xlsxFill) Equals(other xlsxFill) bool {
+	if fill.GradientFill != nil || other.GradientFill != nil {
+		return fill.GradientFill != nil && other.GradientFill != nil &&
+			fill.GradientFill.Equals(*other.GradientFill)
+	}
 	return fill.PatternFill.Equals(other.PatternFill)
 }
 
 func (fill *xlsxFill) Marshal() (result string, err error) {
+	if fill.GradientFill != nil {
+		var xgradientFill string
+		xgradientFill, err = fill.GradientFill.Marshal()
+		if err != nil {
+			return
+		}
+		return `<fill>` + xgradientFill + `</fill>`, nil
+	}
 	if fill.PatternFill.PatternType != "" {
 		var xpatternFill string
 		result = `<fill>`
@@ -800,15 +917,11 @@ func (patternFill *xlsxPatternFill) Marshal() (result string, err error) {
 	ending := `/>`
 	terminator := ""
 	subparts := ""
-	if patternFill.FgColor.RGB != "" {
+	subparts += patternFill.FgColor.marshal("fgColor")
+	subparts += patternFill.BgColor.marshal("bgColor")
+	if subparts != "" {
 		ending = `>`
 		terminator = "</patternFill>"
-		subparts += fmt.Sprintf(`<fgColor rgb="%s"/>`, patternFill.FgColor.RGB)
-	}
-	if patternFill.BgColor.RGB != "" {
-		ending = `>`
-		terminator = "</patternFill>"
-		subparts += fmt.Sprintf(`<bgColor rgb="%s"/>`, patternFill.BgColor.RGB)
 	}
 	result += ending
 	result += subparts
@@ -816,6 +929,71 @@ func (patternFill *xlsxPatternFill) Marshal() (result string, err error) {
 	return
 }
 
+// xlsxGradientFill directly maps the gradientFill element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxGradientFill struct {
+	Type   string             `xml:"type,attr,omitempty"`
+	Degree float64            `xml:"degree,attr,omitempty"`
+	Left   float64            `xml:"left,attr,omitempty"`
+	Right  float64            `xml:"right,attr,omitempty"`
+	Top    float64            `xml:"top,attr,omitempty"`
+	Bottom float64            `xml:"bottom,attr,omitempty"`
+	Stop   []xlsxGradientStop `xml:"stop"`
+}
+
+func (gradientFill *xlsxGradientFill) Equals(other xlsxGradientFill) bool {
+	if gradientFill.Type != other.Type || gradientFill.Degree != other.Degree ||
+		gradientFill.Left != other.Left || gradientFill.Right != other.Right ||
+		gradientFill.Top != other.Top || gradientFill.Bottom != other.Bottom ||
+		len(gradientFill.Stop) != len(other.Stop) {
+		return false
+	}
+	for i, stop := range gradientFill.Stop {
+		if stop.Position != other.Stop[i].Position || !stop.Color.Equals(other.Stop[i].Color) {
+			return false
+		}
+	}
+	return true
+}
+
+func (gradientFill *xlsxGradientFill) Marshal() (result string, err error) {
+	result = `<gradientFill`
+	if gradientFill.Type != "" {
+		result += fmt.Sprintf(` type="%s"`, gradientFill.Type)
+	}
+	for _, attr := range []struct {
+		name  string
+		value float64
+	}{
+		{"degree", gradientFill.Degree},
+		{"left", gradientFill.Left},
+		{"right", gradientFill.Right},
+		{"top", gradientFill.Top},
+		{"bottom", gradientFill.Bottom},
+	} {
+		if attr.value != 0 {
+			result += fmt.Sprintf(` %s="%s"`, attr.name, strconv.FormatFloat(attr.value, 'f', -1, 64))
+		}
+	}
+	result += `>`
+	for _, stop := range gradientFill.Stop {
+		result += fmt.Sprintf(`<stop position="%s">%s</stop>`,
+			strconv.FormatFloat(stop.Position, 'f', -1, 64), stop.Color.marshal("color"))
+	}
+	return result + `</gradientFill>`, nil
+}
+
+// xlsxGradientStop directly maps the stop element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxGradientStop struct {
+	Position float64   `xml:"position,attr"`
+	Color    xlsxColor `xml:"color"`
+}
+
 // xlsxColor is a common mapping used for both the fgColor and bgColor
 // elements in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main -
@@ -826,10 +1004,43 @@ type xlsxColor struct {
 	Theme   *int    `xml:"theme,attr,omitempty"`
 	Tint    float64 `xml:"tint,attr,omitempty"`
 	Indexed *int    `xml:"indexed,attr,omitempty"`
+	Auto    *bool   `xml:"auto,attr,omitempty"`
 }
 
 func (color *xlsxColor) Equals(other xlsxColor) bool {
-	return color.RGB == other.RGB
+	return color.RGB == other.RGB && color.Tint == other.Tint &&
+		equalIntPtr(color.Theme, other.Theme) && equalIntPtr(color.Indexed, other.Indexed) &&
+		equalBoolPtr(color.Auto, other.Auto)
+}
+
+// isEmpty reports whether the colour has none of its attributes set.
+func (color *xlsxColor) isEmpty() bool {
+	return color.RGB == "" && color.Theme == nil && color.Indexed == nil && color.Auto == nil && color.Tint == 0
+}
+
+// marshal returns the colour as an element called name, or an empty
+// string if it has no attributes at all.
+func (color *xlsxColor) marshal(name string) string {
+	if color.isEmpty() {
+		return ""
+	}
+	result := "<" + name
+	if color.Auto != nil {
+		result += fmt.Sprintf(` auto="%s"`, strconv.FormatBool(*color.Auto))
+	}
+	if color.Indexed != nil {
+		result += fmt.Sprintf(` indexed="%d"`, *color.Indexed)
+	}
+	if color.RGB != "" {
+		result += fmt.Sprintf(` rgb="%s"`, color.RGB)
+	}
+	if color.Theme != nil {
+		result += fmt.Sprintf(` theme="%d"`, *color.Theme)
+	}
+	if color.Tint != 0 {
+		result += fmt.Sprintf(` tint="%s"`, strconv.FormatFloat(color.Tint, 'f', -1, 64))
+	}
+	return result + "/>"
 }
 
 // xlsxBorders directly maps the borders element in the namespace
@@ -875,14 +1086,18 @@ func (borders *xlsxBorders) Marshal(outputBorderMap map[int]int) (result string,
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxBorder struct {
-	Left   xlsxLine `xml:"left,omitempty"`
-	Right  xlsxLine `xml:"right,omitempty"`
-	Top    xlsxLine `xml:"top,omitempty"`
-	Bottom xlsxLine `xml:"bottom,omitempty"`
+	DiagonalUp   bool     `xml:"diagonalUp,attr,omitempty"`
+	DiagonalDown bool     `xml:"diagonalDown,attr,omitempty"`
+	Left         xlsxLine `xml:"left,omitempty"`
+	Right        xlsxLine `xml:"right,omitempty"`
+	Top          xlsxLine `xml:"top,omitempty"`
+	Bottom       xlsxLine `xml:"bottom,omitempty"`
+	Diagonal     xlsxLine `xml:"diagonal,omitempty"`
 }
 
 func (border *xlsxBorder) Equals(other xlsxBorder) bool {
-	return border.Left.Equals(other.Left) && border.Right.Equals(other.Right) && border.Top.Equals(other.Top) && border.Bottom.Equals(other.Bottom)
+	return border.Left.Equals(other.Left) && border.Right.Equals(other.Right) && border.Top.Equals(other.Top) && border.Bottom.Equals(other.Bottom) &&
+		border.Diagonal.Equals(other.Diagonal) && border.DiagonalUp == other.DiagonalUp && border.DiagonalDown == other.DiagonalDown
 }
 
 func (border *xlsxBorder) marshalBorderLine(line xlsxLine, name string) string {
@@ -891,9 +1106,7 @@ func (border *xlsxBorder) marshalBorderLine(line xlsxLine, name string) string {
 	}
 	subparts := ""
 	subparts += fmt.Sprintf(`<%s style="%s">`, name, line.Style)
-	if line.Color.RGB != "" {
-		subparts += fmt.Sprintf(`<color rgb="%s"/>`, line.Color.RGB)
-	}
+	subparts += line.Color.marshal("color")
 	subparts += fmt.Sprintf(`</%s>`, name)
 	return subparts
 }
@@ -906,7 +1119,17 @@ func (border *xlsxBorder) Marshal() (result string, err error) {
 	subparts += border.marshalBorderLine(border.Right, "right")
 	subparts += border.marshalBorderLine(border.Top, "top")
 	subparts += border.marshalBorderLine(border.Bottom, "bottom")
-	result += `<border>`
+	if border.Diagonal.Style != "" {
+		subparts += border.marshalBorderLine(border.Diagonal, "diagonal")
+	}
+	result += `<border`
+	if border.DiagonalUp {
+		result += ` diagonalUp="1"`
+	}
+	if border.DiagonalDown {
+		result += ` diagonalDown="1"`
+	}
+	result += `>`
 	result += subparts
 	result += `</border>`
 	return
@@ -931,6 +1154,11 @@ type xlsxCellStyles struct {
 	CellStyle []xlsxCellStyle `xml:"cellStyle,omitempty"`
 }
 
+func (cellStyles *xlsxCellStyles) add(cellStyle xlsxCellStyle) {
+	cellStyles.CellStyle = append(cellStyles.CellStyle, cellStyle)
+	cellStyles.Count++
+}
+
 func (cellStyles *xlsxCellStyles) Marshal(maxXfId int) (result string, err error) {
 	stylesCount := 0
 	for _, cellStyle := range cellStyles.CellStyle {
@@ -1033,18 +1261,19 @@ func (cellXfs *xlsxCellXfs) Marshal(outputBorderMap, outputFillMap, outputFontMa
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxXf struct {
-	ApplyAlignment    bool          `xml:"applyAlignment,attr"`
-	ApplyBorder       bool          `xml:"applyBorder,attr"`
-	ApplyFont         bool          `xml:"applyFont,attr"`
-	ApplyFill         bool          `xml:"applyFill,attr"`
-	ApplyNumberFormat bool          `xml:"applyNumberFormat,attr"`
-	ApplyProtection   bool          `xml:"applyProtection,attr"`
-	BorderId          int           `xml:"borderId,attr"`
-	FillId            int           `xml:"fillId,attr"`
-	FontId            int           `xml:"fontId,attr"`
-	NumFmtId          int           `xml:"numFmtId,attr"`
-	XfId              *int          `xml:"xfId,attr,omitempty"`
-	Alignment         xlsxAlignment `xml:"alignment"`
+	ApplyAlignment    bool            `xml:"applyAlignment,attr"`
+	ApplyBorder       bool            `xml:"applyBorder,attr"`
+	ApplyFont         bool            `xml:"applyFont,attr"`
+	ApplyFill         bool            `xml:"applyFill,attr"`
+	ApplyNumberFormat bool            `xml:"applyNumberFormat,attr"`
+	ApplyProtection   bool            `xml:"applyProtection,attr"`
+	BorderId          int             `xml:"borderId,attr"`
+	FillId            int             `xml:"fillId,attr"`
+	FontId            int             `xml:"fontId,attr"`
+	NumFmtId          int             `xml:"numFmtId,attr"`
+	XfId              *int            `xml:"xfId,attr,omitempty"`
+	Alignment         xlsxAlignment   `xml:"alignment"`
+	Protection        *xlsxProtection `xml:"protection,omitempty"`
 }
 
 func (xf *xlsxXf) Equals(other xlsxXf) bool {
@@ -1060,7 +1289,8 @@ func (xf *xlsxXf) Equals(other xlsxXf) bool {
 		(xf.XfId == other.XfId ||
 			((xf.XfId != nil && other.XfId != nil) &&
 				*xf.XfId == *other.XfId)) &&
-		xf.Alignment.Equals(other.Alignment)
+		xf.Alignment.Equals(other.Alignment) &&
+		xf.Protection.Equals(other.Protection)
 }
 
 func (xf *xlsxXf) Marshal(outputBorderMap, outputFillMap, outputFontMap map[int]int) (result string, err error) {
@@ -1073,16 +1303,52 @@ func (xf *xlsxXf) Marshal(outputBorderMap, outputFillMap, outputFontMap map[int]
 	if err != nil {
 		return result, err
 	}
-	return result + xAlignment + "</xf>", nil
+	result += xAlignment
+	if xf.Protection != nil {
+		result += xf.Protection.Marshal()
+	}
+	return result + "</xf>", nil
+}
+
+// xlsxProtection directly maps the protection element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxProtection struct {
+	Locked *bool `xml:"locked,attr"`
+	Hidden *bool `xml:"hidden,attr"`
+}
+
+// locked reports whether the cell is locked, which it is unless the
+// protection element says otherwise.
+func (protection *xlsxProtection) locked() bool {
+	return protection == nil || protection.Locked == nil || *protection.Locked
+}
+
+func (protection *xlsxProtection) hidden() bool {
+	return protection != nil && protection.Hidden != nil && *protection.Hidden
+}
+
+func (protection *xlsxProtection) Equals(other *xlsxProtection) bool {
+	if protection == nil || other == nil {
+		return protection == nil && other == nil
+	}
+	return protection.locked() == other.locked() && protection.hidden() == other.hidden()
+}
+
+func (protection *xlsxProtection) Marshal() string {
+	return fmt.Sprintf(`<protection locked="%d" hidden="%d"/>`, bool2Int(protection.locked()), bool2Int(protection.hidden()))
 }
 
 type xlsxAlignment struct {
-	Horizontal   string `xml:"horizontal,attr"`
-	Indent       int    `xml:"indent,attr"`
-	ShrinkToFit  bool   `xml:"shrinkToFit,attr"`
-	TextRotation int    `xml:"textRotation,attr"`
-	Vertical     string `xml:"vertical,attr"`
-	WrapText     bool   `xml:"wrapText,attr"`
+	Horizontal     string `xml:"horizontal,attr"`
+	Indent         int    `xml:"indent,attr"`
+	RelativeIndent int    `xml:"relativeIndent,attr,omitempty"`
+	ShrinkToFit    bool   `xml:"shrinkToFit,attr"`
+	TextRotation   int    `xml:"textRotation,attr"`
+	Vertical       string `xml:"vertical,attr"`
+	WrapText       bool   `xml:"wrapText,attr"`
+	ReadingOrder   int    `xml:"readingOrder,attr,omitempty"`
 }
 
 func (alignment *xlsxAlignment) Equals(other xlsxAlignment) bool {
@@ -1091,7 +1357,9 @@ func (alignment *xlsxAlignment) Equals(other xlsxAlignment) bool {
 		alignment.ShrinkToFit == other.ShrinkToFit &&
 		alignment.TextRotation == other.TextRotation &&
 		alignment.Vertical == other.Vertical &&
-		alignment.WrapText == other.WrapText
+		alignment.WrapText == other.WrapText &&
+		alignment.RelativeIndent == other.RelativeIndent &&
+		alignment.ReadingOrder == other.ReadingOrder
 }
 
 func (alignment *xlsxAlignment) Marshal() (result string, err error) {
@@ -1101,7 +1369,14 @@ func (alignment *xlsxAlignment) Marshal() (result string, err error) {
 	if alignment.Vertical == "" {
 		alignment.Vertical = "bottom"
 	}
-	return fmt.Sprintf(`<alignment horizontal="%s" indent="%d" shrinkToFit="%b" textRotation="%d" vertical="%s" wrapText="%b"/>`, alignment.Horizontal, alignment.Indent, bool2Int(alignment.ShrinkToFit), alignment.TextRotation, alignment.Vertical, bool2Int(alignment.WrapText)), nil
+	result = fmt.Sprintf(`<alignment horizontal="%s" indent="%d" shrinkToFit="%b" textRotation="%d" vertical="%s" wrapText="%b"`, alignment.Horizontal, alignment.Indent, bool2Int(alignment.ShrinkToFit), alignment.TextRotation, alignment.Vertical, bool2Int(alignment.WrapText))
+	if alignment.RelativeIndent != 0 {
+		result += fmt.Sprintf(` relativeIndent="%d"`, alignment.RelativeIndent)
+	}
+	if alignment.ReadingOrder != 0 {
+		result += fmt.Sprintf(` readingOrder="%d"`, alignment.ReadingOrder)
+	}
+	return result + "/>", nil
 }
 
 func bool2Int(b bool) int {
@@ -1120,6 +1395,28 @@ type xlsxColors struct {
 	MruColors     []xlsxColor    `xml:"mruColors>color,omitempty"`
 }
 
+func (c *xlsxColors) Marshal() string {
+	if len(c.IndexedColors) == 0 && len(c.MruColors) == 0 {
+		return ""
+	}
+	result := "<colors>"
+	if len(c.IndexedColors) > 0 {
+		result += "<indexedColors>"
+		for _, color := range c.IndexedColors {
+			result += fmt.Sprintf(`<rgbColor rgb="%s"/>`, color.Rgb)
+		}
+		result += "</indexedColors>"
+	}
+	if len(c.MruColors) > 0 {
+		result += "<mruColors>"
+		for _, color := range c.MruColors {
+			result += color.marshal("color")
+		}
+		result += "</mruColors>"
+	}
+	return result + "</colors>"
+}
+
 // indexerdColor returns ARGB color string for the given index of the IndexedColors.
 // Indexes start from 0, see section 18.8.27 of ECMA-376 (part 1, 4th edition).
 func (c *xlsxColors) indexedColor(index int) string {
@@ -1127,7 +1424,7 @@ func (c *xlsxColors) indexedColor(index int) string {
 		return ""
 	}
 
-	if c.IndexedColors != nil && index < len(c.IndexedColors) {
+	if c != nil && c.IndexedColors != nil && index < len(c.IndexedColors) {
 		return c.IndexedColors[index].Rgb
 	}
 
@@ -26,7 +26,7 @@ func TestXMLStyle(t *testing.T) {
 
 	// Test we produce valid output for an empty style file.
 	c.Run("MarshalEmptyXlsxStyleSheet", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		result, err := styles.Marshal()
 		c.Assert(err, qt.IsNil)
 		c.Assert(string(result), qt.Equals, `<?xml version="1.0" encoding="UTF-8"?>
@@ -35,7 +35,7 @@ func TestXMLStyle(t *testing.T) {
 
 	// Test we produce valid output for a style file with one font definition.
 	c.Run("MarshalXlsxStyleSheetWithAFont", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.Fonts = xlsxFonts{}
 		styles.Fonts.Count = 1
 		styles.Fonts.Font = make([]xlsxFont, 1)
@@ -57,7 +57,7 @@ func TestXMLStyle(t *testing.T) {
 
 	// Test we produce valid output for a style file with one fill definition.
 	c.Run("MarshalXlsxStyleSheetWithAFill", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.Fills = xlsxFills{}
 		styles.Fills.Count = 1
 		styles.Fills.Fill = make([]xlsxFill, 1)
@@ -79,7 +79,7 @@ func TestXMLStyle(t *testing.T) {
 	// Test we produce valid output for a style file with one border definition.
 	// Empty elements are required to accommodate for Excel quirks.
 	c.Run("MarshalXlsxStyleSheetWithABorder", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.Borders = xlsxBorders{}
 		styles.Borders.Count = 1
 		styles.Borders.Border = make([]xlsxBorder, 1)
@@ -97,7 +97,7 @@ func TestXMLStyle(t *testing.T) {
 
 	// Test we produce valid output for a style file with one cellStyleXf definition.
 	c.Run("MarshalXlsxStyleSheetWithACellStyleXf", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.CellStyleXfs = &xlsxCellStyleXfs{}
 		styles.CellStyleXfs.Count = 1
 		styles.CellStyleXfs.Xf = make([]xlsxXf, 1)
@@ -130,7 +130,7 @@ func TestXMLStyle(t *testing.T) {
 	// Test we produce valid output for a style file with one cellStyle definition.
 	c.Run("MarshalXlsxStyleSheetWithACellStyle", func(c *qt.C) {
 		var builtInId int
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.CellStyles = &xlsxCellStyles{Count: 2}
 		styles.CellStyles.CellStyle = make([]xlsxCellStyle, 2)
 
@@ -158,7 +158,7 @@ func TestXMLStyle(t *testing.T) {
 	// Test we produce valid output for a style file with one cellXf
 	// definition.
 	c.Run("MarshalXlsxStyleSheetWithACellXf", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.CellXfs = xlsxCellXfs{}
 		styles.CellXfs.Count = 1
 		styles.CellXfs.Xf = make([]xlsxXf, 1)
@@ -370,7 +370,7 @@ func TestStyleSheet(t *testing.T) {
 	c := qt.New(t)
 
 	c.Run("NewNumFmt", func(c *qt.C) {
-		styles := newXlsxStyleSheet(nil)
+		styles := newXlsxStyleSheet(nil, nil)
 		styles.NumFmts = &xlsxNumFmts{}
 		styles.NumFmts.NumFmt = make([]xlsxNumFmt, 0)
 
@@ -400,12 +400,12 @@ func TestStyleSheet(t *testing.T) {
 
 	c.Run("GetStyle", func(c *qt.C) {
 		c.Run("NoNamedStyleIndex", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			s0 := styles.getStyle(0)
 			c.Assert(s0.NamedStyleIndex, qt.Equals, (*int)(nil))
 		})
 		c.Run("NamedStyleIndex", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			namedStyleId := 20
 			csXfs := xlsxCellStyleXfs{}
 			csXfs.addXf(xlsxXf{XfId: &namedStyleId})
@@ -417,7 +417,7 @@ func TestStyleSheet(t *testing.T) {
 		})
 
 		c.Run("NamedStyleWins", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			namedStyleId := 20
 			csXfs := xlsxCellStyleXfs{}
 			csXfs.addXf(xlsxXf{XfId: &namedStyleId,
@@ -442,7 +442,7 @@ func TestStyleSheet(t *testing.T) {
 
 	c.Run("PopulateStyleFromXf", func(c *qt.C) {
 		c.Run("ApplyBorder", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			style := &Style{}
 			xf := xlsxXf{
 				ApplyBorder: true,
@@ -458,7 +458,7 @@ func TestStyleSheet(t *testing.T) {
 		})
 
 		c.Run("ApplyFill", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			style := &Style{}
 			xf := xlsxXf{
 				ApplyFill: true,
@@ -473,7 +473,7 @@ func TestStyleSheet(t *testing.T) {
 			c.Assert(style.ApplyFill, qt.Equals, false)
 		})
 		c.Run("ApplyFont", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			style := &Style{}
 			xf := xlsxXf{
 				ApplyFont: true,
@@ -488,7 +488,7 @@ func TestStyleSheet(t *testing.T) {
 			c.Assert(style.ApplyFont, qt.Equals, false)
 		})
 		c.Run("ApplyAlignment", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			style := &Style{}
 			xf := xlsxXf{
 				ApplyAlignment: true,
@@ -503,7 +503,7 @@ func TestStyleSheet(t *testing.T) {
 			c.Assert(style.ApplyAlignment, qt.Equals, false)
 		})
 		c.Run("Border", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			line := xlsxLine{Style: "fake", Color: xlsxColor{RGB: "00aaff"}}
 
 			borders := xlsxBorders{}
@@ -535,7 +535,7 @@ func TestStyleSheet(t *testing.T) {
 		})
 
 		c.Run("Fill", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 
 			fills := xlsxFills{}
 			pattern := xlsxPatternFill{
@@ -561,7 +561,7 @@ func TestStyleSheet(t *testing.T) {
 
 		})
 		c.Run("Font", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 
 			fonts := xlsxFonts{}
 
@@ -608,7 +608,7 @@ func TestStyleSheet(t *testing.T) {
 		})
 
 		c.Run("Alignment", func(c *qt.C) {
-			styles := newXlsxStyleSheet(nil)
+			styles := newXlsxStyleSheet(nil, nil)
 			style := &Style{}
 
 			alignment := xlsxAlignment{
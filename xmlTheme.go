@@ -15,7 +15,34 @@ type xlsxTheme struct {
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxThemeElements struct {
-	ClrScheme xlsxClrScheme `xml:"clrScheme"`
+	ClrScheme  xlsxClrScheme  `xml:"clrScheme"`
+	FontScheme xlsxFontScheme `xml:"fontScheme"`
+}
+
+// xlsxFontScheme directly maps the fontScheme element in the namespace
+// http://schemas.openxmlformats.org/drawingml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxFontScheme struct {
+	Name      string             `xml:"name,attr"`
+	MajorFont xlsxThemeFontGroup `xml:"majorFont"`
+	MinorFont xlsxThemeFontGroup `xml:"minorFont"`
+}
+
+// xlsxThemeFontGroup directly maps the majorFont and minorFont
+// elements in the namespace
+// http://schemas.openxmlformats.org/drawingml/2006/main - currently I
+// have not checked it for completeness - it does as much as I need.
+type xlsxThemeFontGroup struct {
+	Latin xlsxThemeFont `xml:"latin"`
+}
+
+// xlsxThemeFont directly maps the latin element in the namespace
+// http://schemas.openxmlformats.org/drawingml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxThemeFont struct {
+	Typeface string `xml:"typeface,attr"`
 }
 
 // xlsxClrScheme directly maps the clrScheme element in the namespace
@@ -45,6 +45,30 @@ type xlsxWorksheet struct {
 	PageMargins     *xlsxPageMargins     `xml:"pageMargins,omitempty"`
 	PageSetUp       *xlsxPageSetUp       `xml:"pageSetup,omitempty"`
 	HeaderFooter    *xlsxHeaderFooter    `xml:"headerFooter,omitempty"`
+	RowBreaks       *xlsxBreaks          `xml:"rowBreaks,omitempty"`
+	ColBreaks       *xlsxBreaks          `xml:"colBreaks,omitempty"`
+}
+
+// xlsxBreaks directly maps the rowBreaks and colBreaks elements in
+// the namespace http://schemas.openxmlformats.org/spreadsheetml/2006/main
+// - currently I have not checked it for completeness - it does as
+// much as I need.
+type xlsxBreaks struct {
+	Count            int         `xml:"count,attr,omitempty"`
+	ManualBreakCount int         `xml:"manualBreakCount,attr,omitempty"`
+	Brk              []xlsxBreak `xml:"brk"`
+}
+
+// xlsxBreak directly maps the brk element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxBreak struct {
+	Id  int  `xml:"id,attr"`
+	Min int  `xml:"min,attr,omitempty"`
+	Max int  `xml:"max,attr,omitempty"`
+	Man bool `xml:"man,attr,omitempty"`
+	Pt  bool `xml:"pt,attr,omitempty"`
 }
 
 // xlsxHeaderFooter directly maps the headerFooter element in the namespace
@@ -52,10 +76,16 @@ type xlsxWorksheet struct {
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxHeaderFooter struct {
-	DifferentFirst   *bool           `xml:"differentFirst,attr,omitempty"`
-	DifferentOddEven *bool           `xml:"differentOddEven,attr,omitempty"`
-	OddHeader        []xlsxOddHeader `xml:"oddHeader"`
-	OddFooter        []xlsxOddFooter `xml:"oddFooter"`
+	DifferentFirst   *bool             `xml:"differentFirst,attr,omitempty"`
+	DifferentOddEven *bool             `xml:"differentOddEven,attr,omitempty"`
+	ScaleWithDoc     *bool             `xml:"scaleWithDoc,attr,omitempty"`
+	AlignWithMargins *bool             `xml:"alignWithMargins,attr,omitempty"`
+	OddHeader        []xlsxOddHeader   `xml:"oddHeader"`
+	OddFooter        []xlsxOddFooter   `xml:"oddFooter"`
+	EvenHeader       []xlsxEvenHeader  `xml:"evenHeader"`
+	EvenFooter       []xlsxEvenFooter  `xml:"evenFooter"`
+	FirstHeader      []xlsxFirstHeader `xml:"firstHeader"`
+	FirstFooter      []xlsxFirstFooter `xml:"firstFooter"`
 }
 
 // xlsxOddHeader directly maps the oddHeader element in the namespace
@@ -74,6 +104,38 @@ type xlsxOddFooter struct {
 	Content string `xml:",chardata"`
 }
 
+// xlsxEvenHeader directly maps the evenHeader element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxEvenHeader struct {
+	Content string `xml:",chardata"`
+}
+
+// xlsxEvenFooter directly maps the evenFooter element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxEvenFooter struct {
+	Content string `xml:",chardata"`
+}
+
+// xlsxFirstHeader directly maps the firstHeader element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxFirstHeader struct {
+	Content string `xml:",chardata"`
+}
+
+// xlsxFirstFooter directly maps the firstFooter element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxFirstFooter struct {
+	Content string `xml:",chardata"`
+}
+
 // xlsxPageSetUp directly maps the pageSetup element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main -
 // currently I have not checked it for completeness - it does as much
@@ -145,24 +207,47 @@ type xlsxSheetViews struct {
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxSheetView struct {
-	WindowProtection        bool            `xml:"windowProtection,attr"`
-	ShowFormulas            bool            `xml:"showFormulas,attr"`
-	ShowGridLines           bool            `xml:"showGridLines,attr"`
-	ShowRowColHeaders       bool            `xml:"showRowColHeaders,attr"`
-	ShowZeros               bool            `xml:"showZeros,attr"`
-	RightToLeft             bool            `xml:"rightToLeft,attr"`
-	TabSelected             bool            `xml:"tabSelected,attr"`
-	ShowOutlineSymbols      bool            `xml:"showOutlineSymbols,attr"`
-	DefaultGridColor        bool            `xml:"defaultGridColor,attr"`
-	View                    string          `xml:"view,attr"`
-	TopLeftCell             string          `xml:"topLeftCell,attr"`
-	ColorId                 int             `xml:"colorId,attr"`
-	ZoomScale               float64         `xml:"zoomScale,attr"`
-	ZoomScaleNormal         float64         `xml:"zoomScaleNormal,attr"`
-	ZoomScalePageLayoutView float64         `xml:"zoomScalePageLayoutView,attr"`
-	WorkbookViewId          int             `xml:"workbookViewId,attr"`
-	Pane                    *xlsxPane       `xml:"pane"`
-	Selection               []xlsxSelection `xml:"selection"`
+	WindowProtection         bool            `xml:"windowProtection,attr"`
+	ShowFormulas             bool            `xml:"showFormulas,attr"`
+	ShowGridLines            bool            `xml:"showGridLines,attr"`
+	ShowRowColHeaders        bool            `xml:"showRowColHeaders,attr"`
+	ShowZeros                bool            `xml:"showZeros,attr"`
+	RightToLeft              bool            `xml:"rightToLeft,attr"`
+	TabSelected              bool            `xml:"tabSelected,attr"`
+	ShowOutlineSymbols       bool            `xml:"showOutlineSymbols,attr"`
+	DefaultGridColor         bool            `xml:"defaultGridColor,attr"`
+	View                     string          `xml:"view,attr"`
+	TopLeftCell              string          `xml:"topLeftCell,attr"`
+	ColorId                  int             `xml:"colorId,attr"`
+	ZoomScale                float64         `xml:"zoomScale,attr"`
+	ZoomScaleNormal          float64         `xml:"zoomScaleNormal,attr"`
+	ZoomScalePageLayoutView  float64         `xml:"zoomScalePageLayoutView,attr"`
+	ZoomScaleSheetLayoutView float64         `xml:"zoomScaleSheetLayoutView,attr,omitempty"`
+	WorkbookViewId           int             `xml:"workbookViewId,attr"`
+	Pane                     *xlsxPane       `xml:"pane"`
+	Selection                []xlsxSelection `xml:"selection"`
+}
+
+// UnmarshalXML implements xml.Unmarshaler for xlsxSheetView, so that
+// the attributes that default to something other than their zero value
+// keep those defaults when they're missing.
+func (v *xlsxSheetView) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plainSheetView xlsxSheetView
+	view := plainSheetView{
+		ShowGridLines:      true,
+		ShowRowColHeaders:  true,
+		ShowZeros:          true,
+		ShowOutlineSymbols: true,
+		DefaultGridColor:   true,
+		View:               "normal",
+		ColorId:            64,
+		ZoomScale:          100,
+	}
+	if err := d.DecodeElement(&view, &start); err != nil {
+		return err
+	}
+	*v = xlsxSheetView(view)
+	return nil
 }
 
 // xlsxSelection directly maps the selection element in the namespace
@@ -183,7 +268,7 @@ type xlsxSelection struct {
 type xlsxPane struct {
 	XSplit      float64 `xml:"xSplit,attr"`
 	YSplit      float64 `xml:"ySplit,attr"`
-	TopLeftCell string  `xml:"topLeftCell,attr"`
+	TopLeftCell string  `xml:"topLeftCell,attr,omitempty"`
 	ActivePane  string  `xml:"activePane,attr"`
 	State       string  `xml:"state,attr"` // Either "split" or "frozen"
 }
@@ -194,9 +279,21 @@ type xlsxPane struct {
 // as I need.
 type xlsxSheetPr struct {
 	FilterMode  bool              `xml:"filterMode,attr"`
+	TabColor    *xlsxColor        `xml:"tabColor,omitempty"`
+	OutlinePr   *xlsxOutlinePr    `xml:"outlinePr,omitempty"`
 	PageSetUpPr []xlsxPageSetUpPr `xml:"pageSetUpPr"`
 }
 
+// xlsxOutlinePr directly maps the outlinePr element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// currently I have not checked it for completeness - it does as much
+// as I need.
+type xlsxOutlinePr struct {
+	ApplyStyles  bool  `xml:"applyStyles,attr,omitempty"`
+	SummaryBelow *bool `xml:"summaryBelow,attr,omitempty"`
+	SummaryRight *bool `xml:"summaryRight,attr,omitempty"`
+}
+
 // xlsxPageSetUpPr directly maps the pageSetupPr element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main -
 // currently I have not checked it for completeness - it does as much
@@ -309,6 +406,9 @@ type xlsxRow struct {
 	Ht           string  `xml:"ht,attr,omitempty"`
 	CustomHeight bool    `xml:"customHeight,attr,omitempty"`
 	OutlineLevel uint8   `xml:"outlineLevel,attr,omitempty"`
+	Collapsed    bool    `xml:"collapsed,attr,omitempty"`
+	S            int     `xml:"s,attr,omitempty"`
+	CustomFormat bool    `xml:"customFormat,attr,omitempty"`
 }
 
 type xlsxAutoFilter struct {
@@ -583,11 +683,15 @@ func emitStructAsXML(v reflect.Value, name, xmlNS string) (xmlwriter.Elem, error
 				Name:  "xmlns",
 				Value: xmlNS,
 			})
-		case "SheetData", "MergeCells", "DataValidations", "AutoFilter", "Hyperlinks":
+		case "SheetData", "MergeCells", "DataValidations", "AutoFilter", "Hyperlinks",
+			"PrintOptions", "PageMargins", "PageSetUp", "HeaderFooter",
+			"RowBreaks", "ColBreaks":
 			// Skip SheetData here, we explicitly generate this in writeXML below
 			// Microsoft Excel considers a mergeCells element before a sheetData element to be
 			// an error and will fail to open the document, so we'll be back with this data
-			// from writeXml later.
+			// from writeXml later.  The same is true of the
+			// page layout elements, which must follow the
+			// hyperlinks.
 
 			continue
 		default:
@@ -639,14 +743,26 @@ func (worksheet *xlsxWorksheet) makeXlsxRowFromRow(row *Row, styles *xlsxStyleSh
 		xRow.CustomHeight = true
 		xRow.Ht = fmt.Sprintf("%g", row.GetHeight())
 	}
+	xRow.Hidden = row.Hidden
 	xRow.OutlineLevel = row.GetOutlineLevel()
+	xRow.Collapsed = row.collapsed
+	if row.hasFormat() {
+		xRow.S = row.makeXLSXXfId(styles)
+		xRow.CustomFormat = true
+	}
 
 	err := row.ForEachCell(func(cell *Cell) error {
 		var XfId int
 
 		col := row.Sheet.Col(cell.num)
-		if col != nil {
+		inheritedNumFmt, inherited := "", false
+		switch {
+		case xRow.CustomFormat:
+			XfId = xRow.S
+			inheritedNumFmt, inherited = row.numFmt, true
+		case col != nil:
 			XfId = col.outXfID
+			inheritedNumFmt, inherited = col.numFmt, true
 		}
 
 		// generate NumFmtId and add new NumFmt
@@ -654,13 +770,15 @@ func (worksheet *xlsxWorksheet) makeXlsxRowFromRow(row *Row, styles *xlsxStyleSh
 
 		style := cell.style
 		switch {
+		case cell.styleID != 0:
+			XfId = handleStyleIDForXLSX(cell.styleID, style, xNumFmt.NumFmtId, styles)
 		case style != nil:
 			XfId = handleStyleForXLSX(style, xNumFmt.NumFmtId, styles)
 		case len(cell.NumFmt) == 0:
 			// Do nothing
-		case col == nil:
+		case !inherited:
 			XfId = handleNumFmtIdForXLSX(xNumFmt.NumFmtId, styles)
-		case !compareFormatString(col.numFmt, cell.NumFmt):
+		case !compareFormatString(inheritedNumFmt, cell.NumFmt):
 			XfId = handleNumFmtIdForXLSX(xNumFmt.NumFmtId, styles)
 		}
 		xC := xlsxC{
@@ -725,6 +843,9 @@ func (worksheet *xlsxWorksheet) WriteXML(xw *xmlwriter.Writer, s *Sheet, styles
 		xw.StartElem(output),
 		xw.StartElem(xmlwriter.Elem{Name: "sheetData"}),
 		s.ForEachRow(func(row *Row) error {
+			if row.isEmpty() {
+				return nil
+			}
 			xRow, err := worksheet.makeXlsxRowFromRow(row, styles, refTable)
 			if err != nil {
 				return err
@@ -740,7 +861,7 @@ func (worksheet *xlsxWorksheet) WriteXML(xw *xmlwriter.Writer, s *Sheet, styles
 			}
 			return xw.Flush()
 
-		}, SkipEmptyRows),
+		}),
 		xw.EndElem("sheetData"),
 		func() error {
 			if worksheet.AutoFilter != nil {
@@ -779,7 +900,7 @@ func (worksheet *xlsxWorksheet) WriteXML(xw *xmlwriter.Writer, s *Sheet, styles
 					return err
 				}
 			}
-			return nil
+			return worksheet.writePageLayoutXML(xw)
 		}(),
 		xw.EndElem(output.Name),
 		xw.Flush(),
@@ -787,3 +908,33 @@ func (worksheet *xlsxWorksheet) WriteXML(xw *xmlwriter.Writer, s *Sheet, styles
 	return
 
 }
+
+// writePageLayoutXML emits the print and page layout elements of the
+// worksheet, which the schema requires to follow the hyperlinks.
+func (worksheet *xlsxWorksheet) writePageLayoutXML(xw *xmlwriter.Writer) error {
+	elems := []struct {
+		name  string
+		value interface{}
+	}{
+		{"printOptions", worksheet.PrintOptions},
+		{"pageMargins", worksheet.PageMargins},
+		{"pageSetup", worksheet.PageSetUp},
+		{"headerFooter", worksheet.HeaderFooter},
+		{"rowBreaks", worksheet.RowBreaks},
+		{"colBreaks", worksheet.ColBreaks},
+	}
+	for _, e := range elems {
+		v := reflect.ValueOf(e.value)
+		if v.IsNil() {
+			continue
+		}
+		elem, err := emitStructAsXML(v, e.name, "")
+		if err != nil {
+			return err
+		}
+		if err := xw.Write(elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}